@@ -0,0 +1,47 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/autopause"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+var (
+	listen   = flag.String("listen", fmt.Sprintf(":%d", constants.AutoPauseProxyPort), "address to accept apiserver connections on")
+	target   = flag.String("target", fmt.Sprintf("127.0.0.1:%d", constants.APIServerPort), "address of the apiserver")
+	interval = flag.Duration("interval", time.Minute, "idle time after which the cluster is paused")
+	runtime  = flag.String("container-runtime", "docker", "container runtime of the node (docker, crio, containerd)")
+)
+
+func main() {
+	flag.Parse()
+	if *interval <= 0 {
+		log.Printf("--interval must be positive, not %s", *interval)
+		os.Exit(1)
+	}
+	if err := autopause.Run(*listen, *target, *interval, *runtime); err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+}
@@ -17,34 +17,57 @@ limitations under the License.
 package cmd
 
 import (
+	"io"
 	"os"
+	"strings"
+	"text/tabwriter"
 	"text/template"
+	"time"
 
+	units "github.com/docker/go-units"
 	"github.com/spf13/cobra"
 	cmdConfig "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
 )
 
-const defaultCacheListFormat = "{{.CacheImage}}\n"
+const defaultCacheListFormat = "{{.CacheImage}}\t{{.Size}}\t{{.LastUsed}}\t{{.Profiles}}\n"
 
-var cacheListFormat string
+var (
+	cacheListFormat string
+	cacheListAll    bool
+)
 
 // CacheListTemplate represents the cache list template
 type CacheListTemplate struct {
 	CacheImage string
+	// Size is the size of the cached image on disk
+	Size string
+	// LastUsed is how long ago minikube last used the cached image
+	LastUsed string
+	// Profiles are the profiles which use the cached image, separated by commas
+	Profiles string
 }
 
 // listCacheCmd represents the cache list command
 var listCacheCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all available images from the local cache.",
-	Long:  "List all available images from the local cache.",
+	Long:  "List the images added to the local cache with 'minikube cache add', along with their size on disk, when they were last used and which profiles use them. Pass --all to list the other cached images too, such as those of Kubernetes.",
 	Run: func(cmd *cobra.Command, args []string) {
-		images, err := cmdConfig.ListConfigMap(cacheImageConfigKey)
+		entries, err := cacheEntries(machine.CacheImage)
 		if err != nil {
-			exit.WithError("Failed to get image map", err)
+			exit.WithError("Failed to list cached images", err)
 		}
-		if err := cacheList(images); err != nil {
+		if !cacheListAll {
+			added, err := cmdConfig.ListConfigMap(cacheImageConfigKey)
+			if err != nil {
+				exit.WithError("Failed to get image map", err)
+			}
+			entries = addedCacheEntries(entries, added)
+		}
+		if err := cacheList(entries, os.Stdout); err != nil {
 			exit.WithError("Failed to list cached images", err)
 		}
 	},
@@ -54,20 +77,62 @@ func init() {
 	listCacheCmd.Flags().StringVar(&cacheListFormat, "format", defaultCacheListFormat,
 		`Go template format string for the cache list output.  The format for Go templates can be found here: https://golang.org/pkg/text/template/
 For the list of accessible variables for the template, see the struct values here: https://godoc.org/k8s.io/minikube/cmd/minikube/cmd#CacheListTemplate`)
+	listCacheCmd.Flags().BoolVar(&cacheListAll, "all", false, "List every image of the local cache, rather than only those added with 'minikube cache add'")
 	cacheCmd.AddCommand(listCacheCmd)
 }
 
-// cacheList returns a formatted list of images found within the local cache
-func cacheList(images []string) error {
-	for _, image := range images {
-		tmpl, err := template.New("list").Parse(cacheListFormat)
-		if err != nil {
-			return err
+// cacheEntries returns the entries of the local cache of a kind, or of every kind if kind is empty, along with the profiles which use them
+func cacheEntries(kind string) ([]machine.CacheEntry, error) {
+	added, err := cmdConfig.ListConfigMap(cacheImageConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	profiles, _, err := config.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+	return machine.CacheEntries(kind, profiles, added)
+}
+
+// addedCacheEntries returns the entries of the images added with 'minikube cache add', in the order they were added.
+// Images which are not cached yet are listed without a size.
+func addedCacheEntries(entries []machine.CacheEntry, added []string) []machine.CacheEntry {
+	byName := map[string]machine.CacheEntry{}
+	for _, e := range entries {
+		byName[e.Name] = e
+	}
+	result := []machine.CacheEntry{}
+	for _, img := range added {
+		e, ok := byName[img]
+		if !ok {
+			e = machine.CacheEntry{Kind: machine.CacheImage, Name: img}
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// cacheList writes a formatted list of images found within the local cache
+func cacheList(entries []machine.CacheEntry, out io.Writer) error {
+	tmpl, err := template.New("list").Parse(cacheListFormat)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, e := range entries {
+		listTmplt := CacheListTemplate{
+			CacheImage: e.Name,
+			Size:       "-",
+			LastUsed:   "-",
+			Profiles:   strings.Join(e.Profiles, ","),
+		}
+		if e.Path != "" {
+			listTmplt.Size = units.HumanSize(float64(e.Size))
+			listTmplt.LastUsed = units.HumanDuration(time.Since(e.LastUsed)) + " ago"
 		}
-		listTmplt := CacheListTemplate{image}
-		if err := tmpl.Execute(os.Stdout, listTmplt); err != nil {
+		if err := tmpl.Execute(w, listTmplt); err != nil {
 			return err
 		}
 	}
-	return nil
+	return w.Flush()
 }
@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+func TestAddedCacheEntries(t *testing.T) {
+	entries := []machine.CacheEntry{
+		{Name: "k8s.gcr.io/pause:3.1", Path: "/cache/pause", Size: 1},
+		{Name: "busybox", Path: "/cache/busybox", Size: 2},
+		{Name: "alpine", Path: "/cache/alpine", Size: 3},
+	}
+	got := addedCacheEntries(entries, []string{"alpine", "busybox", "nginx"})
+	want := []machine.CacheEntry{
+		{Name: "alpine", Path: "/cache/alpine", Size: 3},
+		{Name: "busybox", Path: "/cache/busybox", Size: 2},
+		{Kind: machine.CacheImage, Name: "nginx"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("addedCacheEntries() = %+v, want: %+v", got, want)
+	}
+	for i := range got {
+		if got[i].Kind != want[i].Kind || got[i].Name != want[i].Name || got[i].Path != want[i].Path || got[i].Size != want[i].Size {
+			t.Errorf("addedCacheEntries()[%d] = %+v, want: %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCacheListNotCached(t *testing.T) {
+	cacheListFormat = defaultCacheListFormat
+	var b bytes.Buffer
+	if err := cacheList([]machine.CacheEntry{{Kind: machine.CacheImage, Name: "nginx"}}, &b); err != nil {
+		t.Fatalf("cacheList: %v", err)
+	}
+	if got, want := b.String(), "nginx  -  -  \n"; got != want {
+		t.Errorf("cacheList() = %q, want: %q", got, want)
+	}
+}
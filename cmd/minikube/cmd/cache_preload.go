@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/docker/machine/libmachine/state"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+var preloadImages []string
+
+// preloadCacheCmd represents the cache preload command
+var preloadCacheCmd = &cobra.Command{
+	Use:   "preload",
+	Short: "Manage preloaded images tarballs.",
+	Long:  "Manage the preloaded images tarballs which speed up starting clusters.",
+}
+
+// buildPreloadCacheCmd represents the cache preload build command
+var buildPreloadCacheCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Generate a preloaded images tarball from a running profile.",
+	Long: `Generate a preloaded images tarball for the Kubernetes version and container runtime of a running profile.
+
+The tarball holds the Kubernetes images and binaries, the images added with 'minikube cache add', and any images passed with --images.
+Images the cluster already has are kept, images within the local image cache are loaded from it, and only the others are pulled, so that a cluster started offline can be preloaded.
+The container runtime and the kubelet of the cluster are stopped while the tarball is written, and restarted afterwards.
+It is saved to the local cache along with its checksum, and is used instead of downloading a tarball when starting clusters with the same Kubernetes version and container runtime.`,
+	Example: `minikube cache preload build -p offline --images=my-org/my-app:1.0`,
+	Run: func(cmd *cobra.Command, args []string) {
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+
+		cc, err := config.Load(viper.GetString(config.ProfileName))
+		if err != nil {
+			exit.WithError("Error getting config", err)
+		}
+		cp, err := config.PrimaryControlPlane(*cc)
+		if err != nil {
+			exit.WithError("Error getting primary control plane", err)
+		}
+
+		machineName := driver.MachineName(*cc, cp)
+		hs, err := machine.GetHostStatus(api, machineName)
+		if err != nil {
+			exit.WithError("Error getting host status", err)
+		}
+		if hs != state.Running.String() {
+			exit.WithCodeT(exit.Unavailable, `The "{{.name}}" cluster is not running. To start it, run: minikube start -p {{.name}}`, out.V{"name": cc.Name})
+		}
+
+		host, err := machine.CheckIfHostExistsAndLoad(api, machineName)
+		if err != nil {
+			exit.WithError("Error getting host", err)
+		}
+		r, err := machine.CommandRunner(host)
+		if err != nil {
+			exit.WithError("Failed to get command runner", err)
+		}
+
+		cached, err := node.ImagesInConfigFile()
+		if err != nil {
+			exit.WithError("Failed to read cached images", err)
+		}
+
+		k8s := cc.KubernetesConfig
+		if k8s.ContainerRuntime == "" {
+			k8s.ContainerRuntime = "docker"
+		}
+		path := download.TarballPath(k8s.KubernetesVersion, k8s.ContainerRuntime)
+		if err := node.BuildPreload(r, *cc, append(cached, preloadImages...), path); err != nil {
+			exit.WithError("Failed to build preloaded images tarball", err)
+		}
+		if err := download.SaveLocalChecksum(k8s.KubernetesVersion, k8s.ContainerRuntime); err != nil {
+			exit.WithError("Failed to save the checksum of the preloaded images tarball", err)
+		}
+		out.T(out.Ready, "Saved the preloaded images tarball to {{.path}}", out.V{"path": path})
+	},
+}
+
+func init() {
+	buildPreloadCacheCmd.Flags().StringSliceVar(&preloadImages, "images", []string{}, "Extra images to include in the preloaded tarball.")
+	preloadCacheCmd.AddCommand(buildPreloadCacheCmd)
+	cacheCmd.AddCommand(preloadCacheCmd)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+var (
+	pruneOlderThan time.Duration
+	pruneUnused    bool
+)
+
+// pruneCacheCmd represents the cache prune command
+var pruneCacheCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove unused or stale files from the local cache.",
+	Long: `Remove cached images, preloaded images tarballs, ISOs and kic images from the local cache.
+Files are removed when they match every given option. Files which are still needed are downloaded again when they are next used.`,
+	Example: `minikube cache prune --unused
+minikube cache prune --older-than=720h`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pruneUnused && pruneOlderThan == 0 {
+			exit.UsageT("Usage: minikube cache prune [--unused] [--older-than=DURATION]")
+		}
+		entries, err := cacheEntries("")
+		if err != nil {
+			exit.WithError("Failed to list the local cache", err)
+		}
+
+		pruned := prunableCacheEntries(entries, pruneUnused, pruneOlderThan, time.Now())
+		if len(pruned) == 0 {
+			out.T(out.Empty, "There is nothing to prune from the local cache")
+			return
+		}
+		var size int64
+		for _, e := range pruned {
+			out.T(out.Deleted, "Removing {{.kind}} {{.name}} ({{.size}})", out.V{"kind": e.Kind, "name": e.Name, "size": units.HumanSize(float64(e.Size))})
+			size += e.Size
+		}
+		if err := machine.RemoveCacheEntries(pruned); err != nil {
+			exit.WithError("Failed to prune the local cache", err)
+		}
+		out.T(out.Check, "Freed {{.size}}", out.V{"size": units.HumanSize(float64(size))})
+	},
+}
+
+// prunableCacheEntries returns the cache entries which are unused, if unused is set, and were last used longer ago than olderThan, if it is set
+func prunableCacheEntries(entries []machine.CacheEntry, unused bool, olderThan time.Duration, now time.Time) []machine.CacheEntry {
+	pruned := []machine.CacheEntry{}
+	for _, e := range entries {
+		if unused && len(e.Profiles) > 0 {
+			continue
+		}
+		if olderThan > 0 && now.Sub(e.LastUsed) < olderThan {
+			continue
+		}
+		pruned = append(pruned, e)
+	}
+	return pruned
+}
+
+func init() {
+	pruneCacheCmd.Flags().BoolVar(&pruneUnused, "unused", false, "Remove the files which no profile uses")
+	pruneCacheCmd.Flags().DurationVar(&pruneOlderThan, "older-than", 0, "Remove the files which were last used longer ago than this duration, for example 720h")
+	cacheCmd.AddCommand(pruneCacheCmd)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+func TestPrunableCacheEntries(t *testing.T) {
+	now := time.Now()
+	entries := []machine.CacheEntry{
+		{Name: "used-recent", LastUsed: now.Add(-time.Hour), Profiles: []string{"minikube"}},
+		{Name: "used-old", LastUsed: now.Add(-1000 * time.Hour), Profiles: []string{"minikube"}},
+		{Name: "unused-recent", LastUsed: now.Add(-time.Hour)},
+		{Name: "unused-old", LastUsed: now.Add(-1000 * time.Hour)},
+	}
+
+	var tests = []struct {
+		name      string
+		unused    bool
+		olderThan time.Duration
+		want      []string
+	}{
+		{"unused", true, 0, []string{"unused-recent", "unused-old"}},
+		{"older than", false, 720 * time.Hour, []string{"used-old", "unused-old"}},
+		{"unused and older than", true, 720 * time.Hour, []string{"unused-old"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []string{}
+			for _, e := range prunableCacheEntries(entries, tc.unused, tc.olderThan, now) {
+				got = append(got, e.Name)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("prunableCacheEntries() = %v, want: %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("prunableCacheEntries() = %v, want: %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -172,6 +172,11 @@ var settings = []Setting{
 		name: "native-ssh",
 		set:  SetBool,
 	},
+	{
+		name:        config.ArtifactMirror,
+		set:         SetString,
+		validations: []setFn{IsValidURL},
+	},
 }
 
 // ConfigCmd represents the config command
@@ -23,26 +23,39 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/out/register"
 )
 
+var forceDisable bool
+
 var addonsDisableCmd = &cobra.Command{
 	Use:   "disable ADDON_NAME",
 	Short: "Disables the addon w/ADDON_NAME within minikube (example: minikube addons disable dashboard). For a list of available addons use: minikube addons list ",
 	Long:  "Disables the addon w/ADDON_NAME within minikube (example: minikube addons disable dashboard). For a list of available addons use: minikube addons list ",
 	Run: func(cmd *cobra.Command, args []string) {
+		SetOutputFormat(addonsOutputFormat)
 		if len(args) != 1 {
 			exit.UsageT("usage: minikube addons disable ADDON_NAME")
 		}
+		register.Reg.SetStep(register.DisablingAddons)
 
 		addon := args[0]
-		err := addons.Set(addon, "false", viper.GetString(config.ProfileName))
+		var err error
+		if forceDisable {
+			err = addons.SetForce(addon, "false", viper.GetString(config.ProfileName))
+		} else {
+			err = addons.Set(addon, "false", viper.GetString(config.ProfileName))
+		}
 		if err != nil {
 			exit.WithError("disable failed", err)
 		}
+		register.Reg.SetStep(register.Done)
 		out.T(out.AddonDisable, `"The '{{.minikube_addon}}' addon is disabled`, out.V{"minikube_addon": addon})
 	},
 }
 
 func init() {
+	addonsDisableCmd.Flags().BoolVar(&forceDisable, "force", false, "Disable the addon even if enabled addons require it")
+	addonsDisableCmd.Flags().StringVarP(&addonsOutputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
 	AddonsCmd.AddCommand(addonsDisableCmd)
 }
@@ -17,12 +17,24 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/minikube/pkg/addons"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/out/register"
+)
+
+var (
+	waitEnable        bool
+	waitEnableTimeout time.Duration
+	imagesEnable      string
+	registriesEnable  string
 )
 
 var addonsEnableCmd = &cobra.Command{
@@ -30,18 +42,61 @@ var addonsEnableCmd = &cobra.Command{
 	Short: "Enables the addon w/ADDON_NAME within minikube (example: minikube addons enable dashboard). For a list of available addons use: minikube addons list ",
 	Long:  "Enables the addon w/ADDON_NAME within minikube (example: minikube addons enable dashboard). For a list of available addons use: minikube addons list ",
 	Run: func(cmd *cobra.Command, args []string) {
+		SetOutputFormat(addonsOutputFormat)
 		if len(args) != 1 {
 			exit.UsageT("usage: minikube addons enable ADDON_NAME")
 		}
+		register.Reg.SetStep(register.EnablingAddons)
 		addon := args[0]
-		err := addons.Set(addon, "true", viper.GetString(config.ProfileName))
+		profile := viper.GetString(config.ProfileName)
+		images, err := parseImageOverrides(imagesEnable)
+		if err != nil {
+			exit.UsageT("invalid --images: {{.error}}", out.V{"error": err})
+		}
+		registries, err := parseImageOverrides(registriesEnable)
+		if err != nil {
+			exit.UsageT("invalid --registries: {{.error}}", out.V{"error": err})
+		}
+		if err := addons.SetImageOverrides(addon, profile, images, registries); err != nil {
+			exit.WithError("enable failed", err)
+		}
+
+		err = addons.Set(addon, "true", profile)
 		if err != nil {
 			exit.WithError("enable failed", err)
 		}
+		if waitEnable {
+			out.T(out.Waiting, "Waiting for the '{{.addonName}}' addon to become ready ...", out.V{"addonName": addon})
+			if err := addons.Wait(addon, profile, waitEnableTimeout); err != nil {
+				exit.WithError("addon did not become ready", err)
+			}
+		}
+		register.Reg.SetStep(register.Done)
 		out.T(out.AddonEnable, "The '{{.addonName}}' addon is enabled", out.V{"addonName": addon})
 	},
 }
 
+// parseImageOverrides parses a comma separated list of Name=Value overrides of addon image slots
+func parseImageOverrides(s string) (map[string]string, error) {
+	overrides := map[string]string{}
+	if s == "" {
+		return overrides, nil
+	}
+	for _, kv := range strings.Split(s, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("%q is not of the form Name=Value", kv)
+		}
+		overrides[parts[0]] = parts[1]
+	}
+	return overrides, nil
+}
+
 func init() {
+	addonsEnableCmd.Flags().BoolVar(&waitEnable, "wait", false, "Block until the addon, and any addons it requires, are ready")
+	addonsEnableCmd.Flags().DurationVar(&waitEnableTimeout, "wait-timeout", 6*time.Minute, "The maximum time to wait for the addon to become ready")
+	addonsEnableCmd.Flags().StringVar(&imagesEnable, "images", "", "Images used by the addon, as a comma separated list of Name=repository/image:tag. For the image names, see: minikube addons images ADDON_NAME")
+	addonsEnableCmd.Flags().StringVar(&registriesEnable, "registries", "", "Registries the addon's images are pulled from, as a comma separated list of Name=registry. For the image names, see: minikube addons images ADDON_NAME")
+	addonsEnableCmd.Flags().StringVarP(&addonsOutputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
 	AddonsCmd.AddCommand(addonsEnableCmd)
 }
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImageOverrides(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected map[string]string
+		err      bool
+	}{
+		{input: "", expected: map[string]string{}},
+		{input: "Dashboard=my-org/dashboard:dev", expected: map[string]string{"Dashboard": "my-org/dashboard:dev"}},
+		{input: "Dashboard=mirror.example.com:5000,MetricsScraper=mirror.example.com", expected: map[string]string{"Dashboard": "mirror.example.com:5000", "MetricsScraper": "mirror.example.com"}},
+		{input: "Dashboard", err: true},
+		{input: "Dashboard=", err: true},
+		{input: "=my-org/dashboard:dev", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := parseImageOverrides(test.input)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseImageOverrides: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("overrides = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
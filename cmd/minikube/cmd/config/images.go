@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+var addonsImagesCmd = &cobra.Command{
+	Use:   "images ADDON_NAME",
+	Short: "List the images an addon uses, which may be overridden with 'minikube addons enable --images --registries'",
+	Long:  "List the images an addon uses, which may be overridden with 'minikube addons enable --images --registries'",
+	Example: `minikube addons images ingress
+minikube addons enable ingress --images=IngressController=my-org/nginx-ingress-controller:0.26.1 --registries=IngressController=mirror.example.com`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.UsageT("usage: minikube addons images ADDON_NAME")
+		}
+
+		addon := args[0]
+		bundle, ok := assets.Addons[addon]
+		if !ok {
+			exit.WithCodeT(exit.Data, "{{.name}} is not a valid addon", out.V{"name": addon})
+		}
+		if len(bundle.Images) == 0 {
+			out.T(out.Check, "The '{{.name}}' addon has no images to override", out.V{"name": addon})
+			return
+		}
+
+		// Overrides are only shown for existing profiles
+		cc, err := config.Load(viper.GetString(config.ProfileName))
+		if err != nil {
+			cc = &config.ClusterConfig{}
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Image Name", "Default Image", "Default Registry", "Override"})
+		table.SetAutoFormatHeaders(true)
+		table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+		table.SetCenterSeparator("|")
+
+		for _, name := range bundle.ImageNames() {
+			slot := bundle.Images[name]
+			image, err := slot.DefaultImage()
+			if err != nil {
+				exit.WithError("invalid image", err)
+			}
+			table.Append([]string{name, image, slot.Registry, imageOverride(cc, addon, name)})
+		}
+		table.Render()
+	},
+}
+
+// imageOverride describes the overrides of an addon's image slot within a profile
+func imageOverride(cc *config.ClusterConfig, addon, name string) string {
+	image, hasImage := cc.CustomAddonImages[addon][name]
+	registry, hasRegistry := cc.CustomAddonRegistries[addon][name]
+	switch {
+	case hasImage && hasRegistry:
+		return registry + "/" + image
+	case hasImage:
+		return image
+	case hasRegistry:
+		return registry + "/"
+	}
+	return ""
+}
+
+func init() {
+	AddonsCmd.AddCommand(addonsImagesCmd)
+}
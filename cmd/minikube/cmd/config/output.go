@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// addonsOutputFormat is the format enabling and disabling addons print their progress in
+var addonsOutputFormat string
+
+// SetOutputFormat configures how progress is printed, exiting if the format is invalid.
+func SetOutputFormat(format string) {
+	switch format {
+	case "text":
+	case "json":
+		out.SetJSON(true)
+	default:
+		exit.UsageT("invalid output format: {{.output}}. Valid values: 'text', 'json'", out.V{"output": format})
+	}
+}
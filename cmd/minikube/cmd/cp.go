@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+var (
+	cpNode string
+)
+
+// cpPath is a path on either the host or a node
+type cpPath struct {
+	// node is the name of the node the path is on, or empty for the host
+	node string
+	path string
+}
+
+// cpCmd represents the cp command, similar to docker cp
+var cpCmd = &cobra.Command{
+	Use:   "cp [node:]<source> [node:]<target>",
+	Short: "Copy files between the host and a node",
+	Long: `Copy files and directories between the host and a node. Directories are copied recursively, preserving permissions.
+
+Paths on a node are prefixed with the node name, for example "m01:/etc/kubernetes/admin.conf".
+If neither path names a node, the target is written to the node selected by --node.`,
+	Example: `minikube cp ./kubeadm.yaml /var/tmp/minikube/kubeadm.yaml
+minikube cp m01:/var/lib/minikube/certs/ca.crt ./ca.crt`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			exit.UsageT("Usage: minikube cp [node:]<source> [node:]<target>")
+		}
+
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+
+		cc, err := config.Load(viper.GetString(config.ProfileName))
+		if err != nil {
+			exit.WithError("Error getting config", err)
+		}
+
+		src, dst, err := parseCpArgs(cc, args[0], args[1], cpNode)
+		if err != nil {
+			exit.UsageT("Invalid arguments: {{.error}}", out.V{"error": err})
+		}
+
+		nodeName := src.node
+		if nodeName == "" {
+			nodeName = dst.node
+		}
+		n, _, err := node.Retrieve(cc, nodeName)
+		if err != nil {
+			exit.WithError("Error retrieving node", err)
+		}
+
+		host, err := machine.CheckIfHostExistsAndLoad(api, driver.MachineName(*cc, *n))
+		if err != nil {
+			exit.WithError("Error getting host", err)
+		}
+		r, err := machine.CommandRunner(host)
+		if err != nil {
+			exit.WithError("Failed to get command runner", err)
+		}
+
+		if src.node == "" {
+			err = machine.CopyTo(r, src.path, dst.path)
+		} else {
+			err = machine.CopyFrom(r, src.path, dst.path)
+		}
+		if err != nil {
+			exit.WithError("Copy failed", err)
+		}
+	},
+}
+
+// parseCpArgs splits the source and target arguments into host and node paths.
+// The default node is used for the target if neither argument names a node.
+func parseCpArgs(cc *config.ClusterConfig, srcArg string, dstArg string, defaultNode string) (cpPath, cpPath, error) {
+	src := splitCpPath(cc, srcArg)
+	dst := splitCpPath(cc, dstArg)
+
+	if src.node != "" && dst.node != "" {
+		return src, dst, errors.New("copying directly between nodes is not supported")
+	}
+	if src.node == "" && dst.node == "" {
+		dst.node = defaultNode
+		if dst.node == "" {
+			cp, err := config.PrimaryControlPlane(*cc)
+			if err != nil {
+				return src, dst, errors.Wrap(err, "primary control plane")
+			}
+			dst.node = cp.Name
+		}
+		if _, _, err := node.Retrieve(cc, dst.node); err != nil {
+			return src, dst, err
+		}
+	}
+
+	remote := dst
+	if src.node != "" {
+		remote = src
+	}
+	if !strings.HasPrefix(remote.path, "/") {
+		return src, dst, fmt.Errorf("path on node %s must be absolute: %q", remote.node, remote.path)
+	}
+	return src, dst, nil
+}
+
+// splitCpPath returns the node and path for an argument of the form [node:]path.
+// The prefix is only treated as a node name if it matches a node in the cluster,
+// so that host paths such as C:\Users are left intact.
+func splitCpPath(cc *config.ClusterConfig, arg string) cpPath {
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 {
+		return cpPath{path: arg}
+	}
+	for _, n := range cc.Nodes {
+		if n.Name == parts[0] {
+			return cpPath{node: n.Name, path: parts[1]}
+		}
+	}
+	return cpPath{path: arg}
+}
+
+func init() {
+	cpCmd.Flags().StringVarP(&cpNode, "node", "n", "", "The node to copy to, if neither path names a node. Defaults to the primary control plane.")
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestParseCpArgs(t *testing.T) {
+	cc := &config.ClusterConfig{
+		Name: "minikube",
+		Nodes: []config.Node{
+			{Name: "m01", ControlPlane: true},
+			{Name: "m02"},
+		},
+	}
+
+	var tests = []struct {
+		description string
+		src         string
+		dst         string
+		defaultNode string
+		wantSrc     cpPath
+		wantDst     cpPath
+		err         bool
+	}{
+		{
+			description: "upload to primary control plane",
+			src:         "./kubeadm.yaml",
+			dst:         "/var/tmp/kubeadm.yaml",
+			wantSrc:     cpPath{path: "./kubeadm.yaml"},
+			wantDst:     cpPath{node: "m01", path: "/var/tmp/kubeadm.yaml"},
+		},
+		{
+			description: "upload to --node",
+			src:         "./kubeadm.yaml",
+			dst:         "/var/tmp/kubeadm.yaml",
+			defaultNode: "m02",
+			wantSrc:     cpPath{path: "./kubeadm.yaml"},
+			wantDst:     cpPath{node: "m02", path: "/var/tmp/kubeadm.yaml"},
+		},
+		{
+			description: "upload to named node",
+			src:         "certs",
+			dst:         "m02:/etc/certs",
+			wantSrc:     cpPath{path: "certs"},
+			wantDst:     cpPath{node: "m02", path: "/etc/certs"},
+		},
+		{
+			description: "download from named node",
+			src:         "m01:/etc/kubernetes/admin.conf",
+			dst:         `C:\Users\minikube`,
+			wantSrc:     cpPath{node: "m01", path: "/etc/kubernetes/admin.conf"},
+			wantDst:     cpPath{path: `C:\Users\minikube`},
+		},
+		{
+			description: "between nodes",
+			src:         "m01:/etc/hosts",
+			dst:         "m02:/etc/hosts",
+			err:         true,
+		},
+		{
+			description: "unknown --node",
+			src:         "./kubeadm.yaml",
+			dst:         "/var/tmp/kubeadm.yaml",
+			defaultNode: "m03",
+			err:         true,
+		},
+		{
+			description: "relative node path",
+			src:         "m01:etc/hosts",
+			dst:         "hosts",
+			err:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			src, dst, err := parseCpArgs(cc, test.src, test.dst, test.defaultNode)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got src=%+v dst=%+v", src, dst)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCpArgs: %v", err)
+			}
+			if src != test.wantSrc {
+				t.Errorf("src = %+v, want %+v", src, test.wantSrc)
+			}
+			if dst != test.wantDst {
+				t.Errorf("dst = %+v, want %+v", dst, test.wantDst)
+			}
+		})
+	}
+}
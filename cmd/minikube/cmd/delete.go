@@ -43,6 +43,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/out/register"
 )
 
 var deleteAll bool
@@ -81,6 +82,7 @@ func (error DeletionError) Error() string {
 func init() {
 	deleteCmd.Flags().BoolVar(&deleteAll, "all", false, "Set flag to delete all profiles")
 	deleteCmd.Flags().BoolVar(&purge, "purge", false, "Set this flag to delete the '.minikube' folder from your user directory.")
+	deleteCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
 
 	if err := viper.BindPFlags(deleteCmd.Flags()); err != nil {
 		exit.WithError("unable to bind flags", err)
@@ -90,9 +92,11 @@ func init() {
 
 // runDelete handles the executes the flow of "minikube delete"
 func runDelete(cmd *cobra.Command, args []string) {
+	cmdcfg.SetOutputFormat(outputFormat)
 	if len(args) > 0 {
 		exit.UsageT("Usage: minikube delete")
 	}
+	register.Reg.SetStep(register.Deleting)
 
 	validProfiles, invalidProfiles, err := config.ListProfiles()
 	if err != nil {
@@ -126,6 +130,11 @@ func runDelete(cmd *cobra.Command, args []string) {
 			glog.Warningf("error pruning volumes by label %q (might be okay): %+v", delLabel, errs)
 		}
 
+		errs = oci.DeleteAllNetworksByLabel(oci.Docker, delLabel)
+		if len(errs) > 0 { // it will not error if there is nothing to delete
+			glog.Warningf("error deleting networks by label %q (might be okay): %+v", delLabel, errs)
+		}
+
 		errs = DeleteProfiles(profilesToDelete)
 		if len(errs) > 0 {
 			HandleDeletionErrors(errs)
@@ -153,6 +162,7 @@ func runDelete(cmd *cobra.Command, args []string) {
 	if purge {
 		purgeMinikubeDirectory()
 	}
+	register.Reg.SetStep(register.Done)
 }
 
 func purgeMinikubeDirectory() {
@@ -202,6 +212,11 @@ func deleteProfile(profile *config.Profile) error {
 	if len(errs) > 0 { // it will not error if there is nothing to delete
 		glog.Warningf("error pruning volume (might be okay):\n%v", errs)
 	}
+
+	errs = oci.DeleteAllNetworksByLabel(oci.Docker, delLabel)
+	if len(errs) > 0 { // it will not error if there is nothing to delete
+		glog.Warningf("error deleting networks (might be okay):\n%v", errs)
+	}
 	api, err := machine.NewAPIClient()
 	if err != nil {
 		delErr := profileDeletionErr(profile.Name, fmt.Sprintf("error getting client %v", err))
@@ -230,6 +245,12 @@ func deleteProfile(profile *config.Profile) error {
 		out.T(out.FailureType, "Failed to kill mount process: {{.error}}", out.V{"error": err})
 	}
 
+	if cc != nil {
+		if err := killScheduledStop(cc.ScheduledStop); err != nil {
+			out.T(out.FailureType, "Failed to kill the scheduled stop process: {{.error}}", out.V{"error": err})
+		}
+	}
+
 	if cc != nil {
 		for _, n := range cc.Nodes {
 			machineName := driver.MachineName(*cc, n)
@@ -245,6 +266,13 @@ func deleteProfile(profile *config.Profile) error {
 		}
 	}
 
+	// podman networks carry no labels, so they are removed by name once their containers are gone
+	if cc != nil && cc.Driver == driver.Podman && cc.Network != "" {
+		if err := oci.RemoveNetwork(oci.Podman, cc.Network); err != nil {
+			glog.Warningf("error deleting network %s (might be okay): %v", cc.Network, err)
+		}
+	}
+
 	// In case DeleteHost didn't complete the job.
 	deleteProfileDirectory(profile.Name)
 
@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+var (
+	imageNode      string
+	imageTag       string
+	imageBuildFile string
+)
+
+// imageCmd represents the image command
+var imageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Manage images within the container runtime of the cluster",
+	Long:  "Load, list, remove, pull and build images within the container runtime of every node of the cluster, whichever runtime it uses.",
+}
+
+// loadImageCmd represents the image load command
+var loadImageCmd = &cobra.Command{
+	Use:   "load IMAGE | ARCHIVE",
+	Short: "Load an image into every node",
+	Long:  "Load an image archive, as written by 'docker save', or an image from the local docker daemon into every node. The image cache is not used.",
+	Example: `minikube image load my-app:dev
+minikube image load ./my-app.tar`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.UsageT("Usage: minikube image load IMAGE | ARCHIVE")
+		}
+		api, cc := imageProfile()
+		defer api.Close()
+
+		if err := machine.LoadImage(api, cc, args[0]); err != nil {
+			exit.WithError("Failed to load image", err)
+		}
+		out.T(out.Check, "Loaded {{.image}}", out.V{"image": args[0]})
+	},
+}
+
+// listImageCmd represents the image ls command
+var listImageCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List the images of a node",
+	Long:    "List the images within the container runtime of a node, by default the primary control plane.",
+	Run: func(cmd *cobra.Command, args []string) {
+		api, cc := imageProfile()
+		defer api.Close()
+
+		var n config.Node
+		if imageNode == "" {
+			cp, err := config.PrimaryControlPlane(*cc)
+			if err != nil {
+				exit.WithError("Error getting primary control plane", err)
+			}
+			n = cp
+		} else {
+			np, _, err := node.Retrieve(cc, imageNode)
+			if err != nil {
+				exit.WithError("Error retrieving node", err)
+			}
+			n = *np
+		}
+
+		images, err := machine.ListImages(api, cc, n)
+		if err != nil {
+			exit.WithError("Failed to list images", err)
+		}
+		sort.Strings(images)
+		for _, img := range images {
+			out.Ln("%s", img)
+		}
+	},
+}
+
+// removeImageCmd represents the image rm command
+var removeImageCmd = &cobra.Command{
+	Use:     "rm IMAGE [IMAGE...]",
+	Aliases: []string{"remove"},
+	Short:   "Remove images from every node",
+	Long:    "Remove images from the container runtime of every node.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			exit.UsageT("Usage: minikube image rm IMAGE [IMAGE...]")
+		}
+		api, cc := imageProfile()
+		defer api.Close()
+
+		if err := machine.RemoveImages(api, cc, args); err != nil {
+			exit.WithError("Failed to remove images", err)
+		}
+	},
+}
+
+// pullImageCmd represents the image pull command
+var pullImageCmd = &cobra.Command{
+	Use:   "pull IMAGE [IMAGE...]",
+	Short: "Pull images into every node",
+	Long:  "Pull images into the container runtime of every node.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			exit.UsageT("Usage: minikube image pull IMAGE [IMAGE...]")
+		}
+		api, cc := imageProfile()
+		defer api.Close()
+
+		if err := machine.PullImages(api, cc, args); err != nil {
+			exit.WithError("Failed to pull images", err)
+		}
+	},
+}
+
+// buildImageCmd represents the image build command
+var buildImageCmd = &cobra.Command{
+	Use:   "build PATH",
+	Short: "Build an image in the cluster",
+	Long: `Copy the local build context at PATH to every node and build it there into an image, which pods may use without it being pushed to a registry.
+Images are built with docker for the docker runtime, buildkit for containerd and podman for CRI-O.`,
+	Example: `minikube image build -t my-app:dev .
+minikube image build -t my-app:dev -f build/Dockerfile .`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.UsageT("Usage: minikube image build -t TAG PATH")
+		}
+		if imageTag == "" {
+			exit.UsageT("The image to build must be named with --tag")
+		}
+		api, cc := imageProfile()
+		defer api.Close()
+
+		if err := machine.BuildImage(api, cc, args[0], imageBuildFile, imageTag); err != nil {
+			exit.WithError("Failed to build image", err)
+		}
+		out.T(out.Check, "Built {{.image}}", out.V{"image": imageTag})
+		if !strings.Contains(imageTag, ":") || strings.HasSuffix(imageTag, ":latest") {
+			out.T(out.Tip, "Images tagged latest are pulled by default: set imagePullPolicy to IfNotPresent to use the image built in the cluster.")
+		}
+	},
+}
+
+// imageProfile returns an API client and the config of the profile whose images are managed
+func imageProfile() (libmachine.API, *config.ClusterConfig) {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		exit.WithError("Error getting client", err)
+	}
+	cc, err := config.Load(viper.GetString(config.ProfileName))
+	if err != nil {
+		api.Close()
+		exit.WithError("Error getting config", err)
+	}
+	return api, cc
+}
+
+func init() {
+	listImageCmd.Flags().StringVarP(&imageNode, "node", "n", "", "The node to list the images of. Defaults to the primary control plane.")
+	buildImageCmd.Flags().StringVarP(&imageTag, "tag", "t", "", "The name of the image to build, for example my-app:dev")
+	buildImageCmd.Flags().StringVarP(&imageBuildFile, "file", "f", "", "The path of the Dockerfile within PATH. Defaults to PATH/Dockerfile.")
+
+	imageCmd.AddCommand(loadImageCmd)
+	imageCmd.AddCommand(listImageCmd)
+	imageCmd.AddCommand(removeImageCmd)
+	imageCmd.AddCommand(pullImageCmd)
+	imageCmd.AddCommand(buildImageCmd)
+}
@@ -21,6 +21,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -31,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/minikube/pkg/drivers/kic"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
@@ -120,6 +122,9 @@ var mountCmd = &cobra.Command{
 		if host.Driver.DriverName() == driver.None {
 			exit.UsageT(`'none' driver does not support 'minikube mount' command`)
 		}
+		if driver.IsKIC(host.Driver.DriverName()) {
+			kicMount(*cc, hostPath, vmPath)
+		}
 		var ip net.IP
 		if mountIP == "" {
 			ip, err = cluster.GetVMHostIP(host)
@@ -244,3 +249,30 @@ func getPort() (int, error) {
 	defer l.Close()
 	return l.Addr().(*net.TCPAddr).Port, nil
 }
+
+// kicMount handles minikube mount for kic clusters, whose nodes bind mount a directory when they are created rather than running a 9p server
+func kicMount(cc config.ClusterConfig, hostPath string, nodePath string) {
+	if cc.Mount {
+		m, err := kic.ParseMount(cc.MountString)
+		if err == nil && m.ContainerPath == nodePath && sameDir(m.HostPath, hostPath) {
+			out.T(out.Check, "{{.path}} is already bind mounted into the {{.driver}} node", out.V{"path": cc.MountString, "driver": cc.Driver})
+			os.Exit(0)
+		}
+	}
+	exit.WithCodeT(exit.Config, `The {{.driver}} driver does not support 'minikube mount'. Instead, directories are bind mounted when the cluster is created:
+
+    minikube delete && minikube start --driver={{.driver}} --mount --mount-string="{{.host}}:{{.node}}"`, out.V{"driver": cc.Driver, "host": hostPath, "node": nodePath})
+}
+
+// sameDir reports whether two paths name the same directory
+func sameDir(a string, b string) bool {
+	aa, err := filepath.Abs(a)
+	if err != nil {
+		return false
+	}
+	ba, err := filepath.Abs(b)
+	if err != nil {
+		return false
+	}
+	return aa == ba
+}
@@ -17,8 +17,6 @@ limitations under the License.
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -44,18 +42,16 @@ var nodeAddCmd = &cobra.Command{
 		}
 
 		//name := profile + strconv.Itoa(len(mc.Nodes)+1)
-		name := fmt.Sprintf("m%d", len(cc.Nodes)+1)
+		name := node.NextNames(*cc, 1)[0]
 
 		out.T(out.Happy, "Adding node {{.name}} to cluster {{.cluster}}", out.V{"name": name, "cluster": profile})
 
-		n, err := node.Add(cc, name, cp, worker, "", profile)
-		if err != nil {
-			exit.WithError("Error adding node to cluster", err)
+		if cp && !cc.HA {
+			exit.UsageT("Control planes can only be added to clusters started with --ha")
 		}
 
-		_, err = node.Start(*cc, *n, false, nil)
-		if err != nil {
-			exit.WithError("Error starting node", err)
+		if _, err := node.Add(cc, name, cp, worker, "", profile); err != nil {
+			exit.WithError("Error adding node to cluster", err)
 		}
 
 		out.T(out.Ready, "Successfully added {{.name}} to {{.cluster}}!", out.V{"name": name, "cluster": profile})
@@ -63,7 +59,7 @@ var nodeAddCmd = &cobra.Command{
 }
 
 func init() {
-	nodeAddCmd.Flags().BoolVar(&cp, "control-plane", false, "If true, the node added will also be a control plane in addition to a worker.")
+	nodeAddCmd.Flags().BoolVar(&cp, "control-plane", false, "If true, the node added will also be a control plane in addition to a worker. Requires a cluster started with --ha.")
 	nodeAddCmd.Flags().BoolVar(&worker, "worker", true, "If true, the added node will be marked for work. Defaults to true.")
 	//We should figure out which of these flags to actually import
 	startCmd.Flags().Visit(
@@ -99,9 +99,14 @@ func runPause(cmd *cobra.Command, args []string) {
 			out.T(out.Unpause, "Paused kubelet and {{.count}} containers in: {{.namespaces}}", out.V{"count": len(ids), "namespaces": strings.Join(namespaces, ", ")})
 		}
 	}
+
+	cluster.MarkPaused(cc, namespaces)
+	if err := config.SaveProfile(cname, cc); err != nil {
+		exit.WithError("Failed to save config", err)
+	}
 }
 
 func init() {
-	pauseCmd.Flags().StringSliceVarP(&namespaces, "--namespaces", "n", cluster.DefaultNamespaces, "namespaces to pause")
+	pauseCmd.Flags().StringSliceVarP(&namespaces, "namespaces", "n", cluster.DefaultNamespaces, "namespaces to pause")
 	pauseCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "If set, pause all namespaces")
 }
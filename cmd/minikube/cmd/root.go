@@ -32,6 +32,7 @@ import (
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/translate"
@@ -73,6 +74,8 @@ var RootCmd = &cobra.Command{
 				exit.WithError("logdir set failed", err)
 			}
 		}
+
+		download.SetMirror(viper.GetString(config.ArtifactMirror))
 	},
 }
 
@@ -184,6 +187,7 @@ func init() {
 				dockerEnvCmd,
 				podmanEnvCmd,
 				cacheCmd,
+				imageCmd,
 			},
 		},
 		{
@@ -207,6 +211,7 @@ func init() {
 			Commands: []*cobra.Command{
 				mountCmd,
 				sshCmd,
+				cpCmd,
 				kubectlCmd,
 				nodeCmd,
 			},
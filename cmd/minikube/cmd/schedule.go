@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/mitchellh/go-ps"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// scheduleStop starts a background minikube process which stops the cluster after d, replacing any earlier schedule
+func scheduleStop(profile string, d time.Duration) {
+	cc, err := config.Load(profile)
+	if err != nil {
+		exit.WithError("Error getting cluster config", err)
+	}
+	if err := killScheduledStop(cc.ScheduledStop); err != nil {
+		exit.WithError("Unable to cancel the previously scheduled stop", err)
+	}
+
+	// The schedule is saved before the child is started, so that the child never reads a profile without it
+	deadline := time.Now().Add(d).Truncate(time.Second)
+	cc.ScheduledStop = &config.ScheduledStopConfig{Deadline: deadline}
+	if err := config.SaveProfile(profile, cc); err != nil {
+		exit.WithError("Unable to save the scheduled stop", err)
+	}
+
+	c := exec.Command(os.Args[0], "stop", "--profile", profile, fmt.Sprintf("--%s=%s", scheduledStopDeadlineFlag, deadline.Format(time.RFC3339)))
+	detach(c)
+	glog.Infof("scheduling stop: %v", c.Args)
+	if err := c.Start(); err != nil {
+		exit.WithError("Unable to start the scheduled stop process", err)
+	}
+	// The child is not waited for, release it so that it outlives us
+	pid := c.Process.Pid
+	if err := c.Process.Release(); err != nil {
+		glog.Warningf("release scheduled stop process %d: %v", pid, err)
+	}
+
+	// Record the pid unless the child already ran, or the schedule was cancelled meanwhile
+	cc, err = config.Load(profile)
+	if err != nil {
+		exit.WithError("Error getting cluster config", err)
+	}
+	if cc.ScheduledStop != nil && cc.ScheduledStop.Deadline.Equal(deadline) {
+		cc.ScheduledStop.Pid = pid
+		if err := config.SaveProfile(profile, cc); err != nil {
+			exit.WithError("Unable to save the scheduled stop", err)
+		}
+	}
+	out.T(out.Waiting, `"{{.profile_name}}" will be stopped in {{.duration}}, at {{.deadline}}.`, out.V{"profile_name": profile, "duration": d, "deadline": deadline.Format(time.RFC1123)})
+}
+
+// cancelScheduled cancels the stop scheduled for the profile, if any
+func cancelScheduled(profile string) {
+	cc, err := config.Load(profile)
+	if err != nil {
+		exit.WithError("Error getting cluster config", err)
+	}
+	if cc.ScheduledStop == nil {
+		out.T(out.Meh, `No stop is scheduled for "{{.profile_name}}".`, out.V{"profile_name": profile})
+		return
+	}
+	if err := killScheduledStop(cc.ScheduledStop); err != nil {
+		out.WarningT("Unable to kill the scheduled stop process: {{.error}}", out.V{"error": err})
+	}
+	cc.ScheduledStop = nil
+	if err := config.SaveProfile(profile, cc); err != nil {
+		exit.WithError("Unable to cancel the scheduled stop", err)
+	}
+	out.T(out.Check, `Cancelled the scheduled stop of "{{.profile_name}}".`, out.V{"profile_name": profile})
+}
+
+// waitScheduledStop sleeps until the deadline, and reports whether the profile still schedules a stop at that deadline
+func waitScheduledStop(profile string, deadline time.Time) bool {
+	// Sleep in steps, so that the wall clock is followed across host suspends
+	for {
+		left := time.Until(deadline)
+		if left <= 0 {
+			break
+		}
+		if left > time.Minute {
+			left = time.Minute
+		}
+		time.Sleep(left)
+	}
+
+	cc, err := config.Load(profile)
+	if err != nil {
+		glog.Warningf("load %q for scheduled stop: %v", profile, err)
+		return false
+	}
+	if cc.ScheduledStop == nil || !cc.ScheduledStop.Deadline.Equal(deadline) {
+		glog.Infof("scheduled stop of %q was cancelled or replaced", profile)
+		return false
+	}
+	return true
+}
+
+// scheduledStopRunning reports whether the process recorded for a scheduled stop is still alive
+func scheduledStopRunning(s *config.ScheduledStopConfig) bool {
+	if s == nil || s.Pid == 0 {
+		return false
+	}
+	entry, err := ps.FindProcess(s.Pid)
+	if err != nil {
+		glog.Warningf("ps.FindProcess(%d): %v", s.Pid, err)
+		return false
+	}
+	return entry != nil && isMinikubeExecutable(entry.Executable())
+}
+
+// killScheduledStop kills the process recorded for a scheduled stop, if it is running
+func killScheduledStop(s *config.ScheduledStopConfig) error {
+	if s == nil || s.Pid == os.Getpid() || !scheduledStopRunning(s) {
+		return nil
+	}
+	proc, err := os.FindProcess(s.Pid)
+	if err != nil {
+		return errors.Wrap(err, "os.FindProcess")
+	}
+	glog.Infof("Killing scheduled stop pid %d ...", s.Pid)
+	if err := proc.Kill(); err != nil {
+		return errors.Wrapf(err, "kill %d", s.Pid)
+	}
+	return nil
+}
+
+// isMinikubeExecutable guards against killing an unrelated process which reused the pid
+func isMinikubeExecutable(name string) bool {
+	return strings.HasPrefix(filepath.Base(name), "minikube")
+}
+
+// timeToStop returns how long is left until the scheduled stop, or an empty string if none is pending
+func timeToStop(cc config.ClusterConfig, now time.Time) string {
+	if cc.ScheduledStop == nil {
+		return ""
+	}
+	left := cc.ScheduledStop.Deadline.Sub(now).Round(time.Second)
+	if left < 0 {
+		left = 0
+	}
+	return left.String()
+}
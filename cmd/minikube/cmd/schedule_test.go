@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestTimeToStop(t *testing.T) {
+	now := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		name     string
+		stop     *config.ScheduledStopConfig
+		expected string
+	}{
+		{"none", nil, ""},
+		{"pending", &config.ScheduledStopConfig{Deadline: now.Add(5 * time.Minute), Pid: 1}, "5m0s"},
+		{"rounded", &config.ScheduledStopConfig{Deadline: now.Add(90*time.Second + 400*time.Millisecond), Pid: 1}, "1m30s"},
+		{"overdue", &config.ScheduledStopConfig{Deadline: now.Add(-time.Minute), Pid: 1}, "0s"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := timeToStop(config.ClusterConfig{ScheduledStop: tc.stop}, now)
+			if got != tc.expected {
+				t.Errorf("timeToStop() = %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestIsMinikubeExecutable(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected bool
+	}{
+		{"minikube", true},
+		{"minikube.exe", true},
+		{"minikube-linux-amd64", true},
+		{"/usr/local/bin/minikube", true},
+		{"bash", false},
+		{"", false},
+	}
+	for _, tc := range tests {
+		if got := isMinikubeExecutable(tc.name); got != tc.expected {
+			t.Errorf("isMinikubeExecutable(%q) = %v, expected %v", tc.name, got, tc.expected)
+		}
+	}
+}
@@ -21,13 +21,12 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"runtime"
-	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
+	"github.com/docker/machine/libmachine"
 	"github.com/golang/glog"
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
@@ -38,7 +37,6 @@ import (
 	pkg_config "k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
-	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/service"
@@ -98,7 +96,7 @@ var serviceCmd = &cobra.Command{
 		}
 
 		if runtime.GOOS == "darwin" && cfg.Driver == oci.Docker {
-			startKicServiceTunnel(svc, cfg.Name)
+			startKicServiceTunnel(api, svc, *cfg)
 			return
 		}
 
@@ -122,7 +120,7 @@ func init() {
 
 }
 
-func startKicServiceTunnel(svc, configName string) {
+func startKicServiceTunnel(api libmachine.API, svc string, cc config.ClusterConfig) {
 	ctrlC := make(chan os.Signal, 1)
 	signal.Notify(ctrlC, os.Interrupt)
 
@@ -131,13 +129,7 @@ func startKicServiceTunnel(svc, configName string) {
 		exit.WithError("error creating clientset", err)
 	}
 
-	port, err := oci.HostPortBinding(oci.Docker, configName, 22)
-	if err != nil {
-		exit.WithError("error getting ssh port", err)
-	}
-	sshPort := strconv.Itoa(port)
-	sshKey := filepath.Join(localpath.MiniPath(), "machines", configName, "id_rsa")
-
+	sshPort, sshKey := kicSSHEndpoint(api, cc)
 	serviceTunnel := kic.NewServiceTunnel(sshPort, sshKey, clientset.CoreV1())
 	urls, err := serviceTunnel.Start(svc, namespace)
 	if err != nil {
@@ -25,6 +25,7 @@ import (
 	"os"
 	"os/exec"
 	"os/user"
+	"reflect"
 	"runtime"
 	"strings"
 	"time"
@@ -42,6 +43,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/drivers/kic"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
@@ -57,6 +59,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/notify"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/proxy"
 	"k8s.io/minikube/pkg/minikube/registry"
 	"k8s.io/minikube/pkg/minikube/translate"
@@ -120,6 +123,12 @@ const (
 	autoUpdate              = "auto-update-drivers"
 	hostOnlyNicType         = "host-only-nic-type"
 	natNicType              = "nat-nic-type"
+	ha                      = "ha"
+	autoPauseInterval       = "auto-pause-interval"
+	subnet                  = "subnet"
+	ports                   = "ports"
+	nodes                   = "nodes"
+	haControlPlanes         = 3
 )
 
 var (
@@ -127,6 +136,7 @@ var (
 	insecureRegistry []string
 	apiServerNames   []string
 	apiServerIPs     []net.IP
+	outputFormat     string // shared by the --output flags of start, stop and delete
 )
 
 func init() {
@@ -157,11 +167,12 @@ func initMinikubeFlags() {
 	startCmd.Flags().Bool(downloadOnly, false, "If true, only download and cache files for later use - don't install or start anything.")
 	startCmd.Flags().Bool(cacheImages, true, "If true, cache docker images for the current bootstrapper and load them into the machine. Always false with --driver=none.")
 	startCmd.Flags().StringSlice(isoURL, download.DefaultISOURLs(), "Locations to fetch the minikube ISO from.")
+	startCmd.Flags().String(config.ArtifactMirror, "", "Base URL or local directory to download the ISO, preloaded tarballs, kic base image, Kubernetes binaries and drivers from, instead of their default locations.")
 	startCmd.Flags().Bool(keepContext, false, "This will keep the existing kubectl context and will create a minikube context.")
 	startCmd.Flags().Bool(embedCerts, false, "if true, will embed the certs in kubeconfig.")
 	startCmd.Flags().String(containerRuntime, "docker", "The container runtime to be used (docker, crio, containerd).")
 	startCmd.Flags().Bool(createMount, false, "This will start the mount daemon and automatically mount files into minikube.")
-	startCmd.Flags().String(mountString, constants.DefaultMountDir+":/minikube-host", "The argument to pass the minikube mount command on start.")
+	startCmd.Flags().String(mountString, constants.DefaultMountDir+":/minikube-host", "The argument to pass the minikube mount command on start. With the docker and podman drivers the directory is bind mounted instead, and options such as ro or rshared may be appended, e.g. /src:/src:ro,rshared.")
 	startCmd.Flags().StringArrayVar(&node.AddonList, "addons", nil, "Enable addons. see `minikube addons list` for a list of valid addon names.")
 	startCmd.Flags().String(criSocket, "", "The cri socket path to be used.")
 	startCmd.Flags().String(networkPlugin, "", "The name of the network plugin.")
@@ -171,6 +182,10 @@ func initMinikubeFlags() {
 	startCmd.Flags().Bool(nativeSSH, true, "Use native Golang SSH client (default true). Set to 'false' to use the command line 'ssh' command when accessing the docker machine. Useful for the machine drivers when they will not start with 'Waiting for SSH'.")
 	startCmd.Flags().Bool(autoUpdate, true, "If set, automatically updates drivers to the latest version. Defaults to true.")
 	startCmd.Flags().Bool(installAddons, true, "If set, install addons. Defaults to true.")
+	startCmd.Flags().Int(nodes, 1, "The number of nodes to spin up, including control planes. Workers are provisioned in parallel once the control plane is running.")
+	startCmd.Flags().Bool(ha, false, "Create a highly available cluster with three control planes fronted by a load balancer. Requires Kubernetes v1.16 or newer.")
+	startCmd.Flags().Duration(autoPauseInterval, 0, "Pause the cluster once it has received no API requests for this long, and unpause it on the next request. Kept for the profile until set again, 0 disables auto-pause.")
+	startCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
 }
 
 // initKubernetesFlags inits the commandline flags for kubernetes related options
@@ -216,6 +231,10 @@ func initDriverFlags() {
 	startCmd.Flags().StringSlice(nfsShare, []string{}, "Local folders to share with Guest via NFS mounts (hyperkit driver only)")
 	startCmd.Flags().String(nfsSharesRoot, "/nfsshares", "Where to root the NFS Shares, defaults to /nfsshares (hyperkit driver only)")
 
+	// docker & podman
+	startCmd.Flags().StringSlice(ports, []string{}, "Ports to publish from the primary node on fixed host ports, as [hostIP:]hostPort:containerPort[/proto], e.g. --ports=8080:80,0.0.0.0:30053:30053/udp. The host IP defaults to 127.0.0.1. Cannot be changed once the cluster exists. (docker and podman drivers only)")
+	startCmd.Flags().String(subnet, "", fmt.Sprintf("The subnet of the network created for the cluster, within which nodes get static IPs. Defaults to the first free subnet from %s. Cannot be changed once the cluster exists. (docker and podman drivers only)", kic.DefaultSubnet))
+
 	// hyperv
 	startCmd.Flags().String(hypervVirtualSwitch, "", "The hyperv virtual switch name. Defaults to first found. (hyperv driver only)")
 	startCmd.Flags().Bool(hypervUseExternalSwitch, false, "Whether to use external switch over Default Switch if virtual switch not explicitly specified. (hyperv driver only)")
@@ -275,6 +294,8 @@ func platform() string {
 
 // runStart handles the executes the flow of "minikube start"
 func runStart(cmd *cobra.Command, args []string) {
+	cmdcfg.SetOutputFormat(outputFormat)
+	register.Reg.SetStep(register.InitialSetup)
 	displayVersion(version.GetVersion())
 	displayEnviron(os.Environ())
 
@@ -294,6 +315,7 @@ func runStart(cmd *cobra.Command, args []string) {
 		exit.WithCodeT(exit.Data, "Unable to load config: {{.error}}", out.V{"error": err})
 	}
 
+	register.Reg.SetStep(register.SelectingDriver)
 	ds := selectDriver(existing)
 	driverName := ds.Name
 	glog.Infof("selected driver: %s", driverName)
@@ -312,10 +334,21 @@ func runStart(cmd *cobra.Command, args []string) {
 	}
 
 	k8sVersion := getKubernetesVersion(existing)
+	validateHA(existing, driverName, k8sVersion)
 	mc, n, err := generateCfgFromFlags(cmd, k8sVersion, driverName)
 	if err != nil {
 		exit.WithError("Failed to generate config", err)
 	}
+	if existing != nil {
+		keepExistingNodes(&mc, existing)
+		if !cmd.Flags().Changed(autoPauseInterval) {
+			mc.AutoPauseInterval = existing.AutoPauseInterval
+		}
+		keepExistingNetwork(cmd, &mc, existing)
+		keepExistingPorts(cmd, &mc, existing)
+		keepExistingMount(cmd, &mc, existing)
+	}
+	validateAutoPause(mc)
 
 	// This is about as far as we can go without overwriting config files
 	if viper.GetBool(dryRun) {
@@ -323,7 +356,26 @@ func runStart(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Detect conflicts before the container is created, rather than leaving it half created
+	if existing == nil && len(mc.ExposedPorts) > 0 {
+		pms, err := kic.ParsePorts(mc.ExposedPorts)
+		if err != nil {
+			exit.UsageT("Invalid --ports: {{.error}}", out.V{"error": err})
+		}
+		if err := kic.CheckPortsAvailable(pms); err != nil {
+			exit.WithCodeT(exit.Unavailable, "Unable to publish the requested ports: {{.error}}", out.V{"error": err})
+		}
+	}
+
+	// Starting the cluster cancels any stop scheduled for it
+	if existing != nil && existing.ScheduledStop != nil {
+		if err := killScheduledStop(existing.ScheduledStop); err != nil {
+			out.WarningT("Unable to cancel the scheduled stop: {{.error}}", out.V{"error": err})
+		}
+	}
+
 	if !driver.BareMetal(driverName) && !driver.IsKIC(driverName) {
+		register.Reg.SetStep(register.DownloadingArtifacts)
 		url, err := download.ISO(viper.GetStringSlice(isoURL), cmd.Flags().Changed(isoURL))
 		if err != nil {
 			exit.WithError("Failed to cache ISO", err)
@@ -348,10 +400,15 @@ func runStart(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exit.WithError("Starting node", err)
 	}
+	failed := startSecondaryNodes(mc.Name, existing != nil)
 
 	if err := showKubectlInfo(kubeconfig, k8sVersion, mc.Name); err != nil {
 		glog.Errorf("kubectl info: %v", err)
 	}
+
+	if failed > 0 {
+		exit.WithCodeT(exit.Unavailable, "{{.count}} of the requested nodes failed to start and were removed from the cluster. Run 'minikube start --nodes={{.nodes}}' to retry them.", out.V{"count": failed, "nodes": viper.GetInt(nodes)})
+	}
 }
 
 func updateDriver(driverName string) {
@@ -390,6 +447,7 @@ func displayEnviron(env []string) {
 }
 
 func showKubectlInfo(kcs *kubeconfig.Settings, k8sVersion string, machineName string) error {
+	register.Reg.SetStep(register.Done)
 	if kcs.KeepContext {
 		out.T(out.Kubectl, "To connect to this cluster, use: kubectl --context={{.name}}", out.V{"name": kcs.ClusterName})
 	} else {
@@ -486,6 +544,11 @@ func validateDriver(ds registry.DriverState, existing *config.ClusterConfig) {
 	st := ds.State
 	glog.Infof("status for %s: %+v", name, st)
 
+	// the status is for the default Kubernetes version, while rootless nodes may run the one asked for
+	if st.Error == oci.ErrRootlessKubernetesVersion && oci.RootlessKubernetesSupported(requestedKubernetesVersion(existing)) {
+		st.Error = nil
+	}
+
 	if st.Error != nil {
 		out.ErrLn("")
 
@@ -496,6 +559,11 @@ func validateDriver(ds registry.DriverState, existing *config.ClusterConfig) {
 		}
 		out.ErrLn("")
 
+		// a rootless daemon which is unable to run nodes would only fail once they are created
+		if (st.Error == oci.ErrRootlessCgroupV1 || st.Error == oci.ErrRootlessCgroupDriver || st.Error == oci.ErrRootlessKubernetesVersion) && !viper.GetBool(force) {
+			exit.WithCodeT(exit.Unavailable, "The rootless {{.driver}} is unable to run Kubernetes", out.V{"driver": name})
+		}
+
 		if !st.Installed && !viper.GetBool(force) {
 			if existing != nil && name == existing.Driver {
 				exit.WithCodeT(exit.Unavailable, "{{.driver}} does not appear to be installed, but is specified by an existing profile. Please run 'minikube delete' or install {{.driver}}", out.V{"driver": name})
@@ -604,6 +672,19 @@ func minikubeCmd() string {
 	return "minikube"
 }
 
+// rootless returns whether the daemon of a kic driver runs as the current user, which podman does when it is not run as root
+func rootless(drvName string) bool {
+	if !driver.IsKIC(drvName) {
+		return false
+	}
+	si, err := oci.DaemonInfo(drvName)
+	if err != nil {
+		glog.Warningf("unable to get %s info: %v", drvName, err)
+		return false
+	}
+	return si.Rootless
+}
+
 // validateUser validates minikube is run by the recommended user (privileged or regular)
 func validateUser(drvName string) {
 	u, err := user.Current()
@@ -614,7 +695,7 @@ func validateUser(drvName string) {
 
 	useForce := viper.GetBool(force)
 
-	if driver.NeedsRoot(drvName) && u.Uid != "0" && !useForce {
+	if driver.NeedsRoot(drvName) && u.Uid != "0" && !useForce && !rootless(drvName) {
 		exit.WithCodeT(exit.Permissions, `The "{{.driver_name}}" driver requires root privileges. Please run minikube using 'sudo minikube --driver={{.driver_name}}'.`, out.V{"driver_name": drvName})
 	}
 
@@ -672,6 +753,26 @@ func defaultMemorySize(drvName string) int {
 	return suggested
 }
 
+// hasResourceLimit returns whether the driver enforces the --cpus or --memory flag.
+// A rootless kic daemon only does if systemd delegated the cgroup controller to the user.
+func hasResourceLimit(drvName string, flag string) bool {
+	if !driver.HasResourceLimits(drvName) {
+		return false
+	}
+	if !driver.IsKIC(drvName) {
+		return true
+	}
+	si, err := oci.DaemonInfo(drvName)
+	if err != nil {
+		glog.Warningf("unable to get %s info: %v", drvName, err)
+		return true
+	}
+	if flag == cpus {
+		return si.CPULimit
+	}
+	return si.MemoryLimit
+}
+
 // validateMemorySize validates the memory size matches the minimum recommended
 func validateMemorySize() {
 	req := pkgutil.CalculateSizeInMB(viper.GetString(memory))
@@ -704,8 +805,174 @@ func validateCPUCount(local bool) {
 	}
 }
 
+// validateHA validates that a highly available cluster may be created with the selected driver and version
+func validateHA(existing *config.ClusterConfig, drvName string, k8sVersion string) {
+	if !viper.GetBool(ha) {
+		return
+	}
+	if existing != nil && !existing.HA {
+		exit.WithCodeT(exit.Config, "The existing \"{{.name}}\" cluster was not created with --ha, and cannot be made highly available. Run 'minikube delete' first.", out.V{"name": existing.Name})
+	}
+	if driver.BareMetal(drvName) {
+		exit.WithCodeT(exit.Config, "The '{{.name}}' driver does not support highly available clusters", out.V{"name": drvName})
+	}
+	v, err := bsutil.ParseKubernetesVersion(k8sVersion)
+	if err != nil {
+		exit.WithError("parsing kubernetes version", err)
+	}
+	if v.LT(semver.MustParse("1.16.0")) {
+		exit.WithCodeT(exit.Config, "Highly available clusters require Kubernetes v1.16 or newer, not {{.version}}", out.V{"version": k8sVersion})
+	}
+}
+
+// validateAutoPause validates that auto-pause may be enabled for the cluster
+func validateAutoPause(cc config.ClusterConfig) {
+	if cc.AutoPauseInterval < 0 {
+		exit.UsageT("The auto-pause interval must not be negative, not {{.interval}}", out.V{"interval": cc.AutoPauseInterval})
+	}
+	if cc.AutoPauseInterval > 0 && cc.HA {
+		exit.WithCodeT(exit.Config, "Auto-pause is not supported for highly available clusters")
+	}
+}
+
+// validateSubnet validates the --subnet flag, which must be an IPv4 CIDR with room for a few nodes
+func validateSubnet(drvName string, s string) {
+	if s == "" {
+		return
+	}
+	if !driver.IsKIC(drvName) {
+		exit.UsageT("--subnet is only supported by the docker and podman drivers")
+	}
+	ip, ipnet, err := net.ParseCIDR(s)
+	if err != nil || ip.To4() == nil {
+		exit.UsageT("--subnet must be an IPv4 CIDR such as {{.example}}, not {{.subnet}}", out.V{"example": kic.DefaultSubnet, "subnet": s})
+	}
+	if ones, _ := ipnet.Mask.Size(); ones > 28 {
+		exit.UsageT("--subnet must be /28 or larger, not {{.subnet}}", out.V{"subnet": s})
+	}
+}
+
+// keepExistingNetwork carries the network of an existing kic cluster, which cannot be moved to another subnet
+func keepExistingNetwork(cmd *cobra.Command, mc *config.ClusterConfig, existing *config.ClusterConfig) {
+	if cmd.Flags().Changed(subnet) && mc.Subnet != existing.Subnet {
+		out.WarningT("Ignoring --subnet={{.subnet}}, the existing cluster cannot be moved from its network. Run 'minikube delete' first.", out.V{"subnet": mc.Subnet})
+	}
+	mc.Network = existing.Network
+	mc.Subnet = existing.Subnet
+}
+
+// validatePorts validates the --ports flag, whose ports are published by the kic container of the primary node
+func validatePorts(drvName string, specs []string) {
+	if len(specs) == 0 {
+		return
+	}
+	if !driver.IsKIC(drvName) {
+		exit.UsageT("--ports is only supported by the docker and podman drivers")
+	}
+	if _, err := kic.ParsePorts(specs); err != nil {
+		exit.UsageT("Invalid --ports: {{.error}}", out.V{"error": err})
+	}
+}
+
+// keepExistingPorts carries the ports published by an existing kic cluster, which are fixed once its container exists
+func keepExistingPorts(cmd *cobra.Command, mc *config.ClusterConfig, existing *config.ClusterConfig) {
+	if cmd.Flags().Changed(ports) && !reflect.DeepEqual(mc.ExposedPorts, existing.ExposedPorts) {
+		out.WarningT("Ignoring --ports, the ports published by the existing cluster cannot be changed. Run 'minikube delete' first.")
+	}
+	mc.ExposedPorts = existing.ExposedPorts
+}
+
+// validateMount validates the --mount-string flag of kic clusters, whose directory is bind mounted when the nodes are created
+func validateMount(drvName string, mount bool, s string) {
+	if !mount || !driver.IsKIC(drvName) {
+		return
+	}
+	m, err := kic.ParseMount(s)
+	if err != nil {
+		exit.UsageT("Invalid --mount-string: {{.error}}", out.V{"error": err})
+	}
+	if _, err := os.Stat(m.HostPath); err != nil {
+		exit.WithCodeT(exit.NoInput, "Cannot find directory {{.path}} for mount", out.V{"path": m.HostPath})
+	}
+}
+
+// keepExistingMount carries the bind mount of an existing kic cluster, which is fixed once its containers exist
+func keepExistingMount(cmd *cobra.Command, mc *config.ClusterConfig, existing *config.ClusterConfig) {
+	if !driver.IsKIC(existing.Driver) {
+		return
+	}
+	if (cmd.Flags().Changed(createMount) || cmd.Flags().Changed(mountString)) && (mc.Mount != existing.Mount || mc.MountString != existing.MountString) {
+		out.WarningT("Ignoring --mount and --mount-string, the bind mount of the existing cluster cannot be changed. Run 'minikube delete' first.")
+	}
+	mc.Mount = existing.Mount
+	mc.MountString = existing.MountString
+}
+
+// keepExistingNodes carries the nodes added to an existing cluster, and its load balancer, into the config generated from flags
+func keepExistingNodes(mc *config.ClusterConfig, existing *config.ClusterConfig) {
+	mc.HA = existing.HA
+	mc.KubernetesConfig.APIServerHAVIP = existing.KubernetesConfig.APIServerHAVIP
+	for _, n := range existing.Nodes {
+		if !config.IsPrimaryControlPlane(*existing, n) {
+			mc.Nodes = append(mc.Nodes, n)
+		}
+	}
+}
+
+// startSecondaryNodes starts the existing nodes other than the primary control plane, adds the remaining
+// control planes of a new highly available cluster, and then adds any workers requested by --nodes.
+// It returns how many of the workers failed to start.
+func startSecondaryNodes(profile string, preExists bool) int {
+	cc, err := config.Load(profile)
+	if err != nil {
+		exit.WithError("Error getting cluster config", err)
+	}
+
+	if preExists {
+		for _, n := range cc.Nodes {
+			if config.IsPrimaryControlPlane(*cc, n) {
+				continue
+			}
+			if _, err := node.Start(*cc, n, false, nil); err != nil {
+				exit.WithError("Error starting node", err)
+			}
+		}
+	} else if cc.HA {
+		for _, name := range node.NextNames(*cc, haControlPlanes-len(config.ControlPlanes(*cc))) {
+			out.T(out.Happy, "Adding control plane {{.name}} to cluster {{.cluster}}", out.V{"name": name, "cluster": profile})
+			if _, err := node.Add(cc, name, true, true, "", profile); err != nil {
+				exit.WithError("Error adding control plane to cluster", err)
+			}
+		}
+	}
+
+	workers := viper.GetInt(nodes) - len(cc.Nodes)
+	if workers <= 0 {
+		return 0
+	}
+	names := node.NextNames(*cc, workers)
+	out.T(out.Happy, "Adding {{.count}} nodes to cluster {{.cluster}}: {{.names}}", out.V{"count": workers, "cluster": profile, "names": strings.Join(names, ", ")})
+	failed, err := node.AddWorkers(cc, names, profile)
+	if err != nil {
+		exit.WithError("Error adding nodes to cluster", err)
+	}
+
+	for _, name := range names {
+		if err, ok := failed[name]; ok {
+			out.FailureT("{{.name}}: failed to start, and was removed: {{.error}}", out.V{"name": name, "error": err})
+		} else {
+			out.T(out.Check, "{{.name}}: Running", out.V{"name": name})
+		}
+	}
+	return len(failed)
+}
+
 // validateFlags validates the supplied flags against known bad combinations
 func validateFlags(cmd *cobra.Command, drvName string) {
+	validateSubnet(drvName, viper.GetString(subnet))
+	validatePorts(drvName, viper.GetStringSlice(ports))
+	validateMount(drvName, viper.GetBool(createMount), viper.GetString(mountString))
+
 	if cmd.Flags().Changed(humanReadableDiskSize) {
 		diskSizeMB := pkgutil.CalculateSizeInMB(viper.GetString(humanReadableDiskSize))
 		if diskSizeMB < pkgutil.CalculateSizeInMB(minimumDiskSize) && !viper.GetBool(force) {
@@ -715,19 +982,26 @@ func validateFlags(cmd *cobra.Command, drvName string) {
 
 	if cmd.Flags().Changed(cpus) {
 		validateCPUCount(driver.BareMetal(drvName))
-		if !driver.HasResourceLimits(drvName) {
+		if !hasResourceLimit(drvName, cpus) {
 			out.WarningT("The '{{.name}}' driver does not respect the --cpus flag", out.V{"name": drvName})
 		}
 	}
 
 	if cmd.Flags().Changed(memory) {
 		validateMemorySize()
-		if !driver.HasResourceLimits(drvName) {
+		if !hasResourceLimit(drvName, memory) {
 			out.WarningT("The '{{.name}}' driver does not respect the --memory flag", out.V{"name": drvName})
 		}
 	}
 
+	if viper.GetInt(nodes) < 1 {
+		exit.UsageT("The number of nodes must be at least 1, not {{.nodes}}", out.V{"nodes": viper.GetInt(nodes)})
+	}
+
 	if driver.BareMetal(drvName) {
+		if viper.GetInt(nodes) > 1 {
+			exit.WithCodeT(exit.Config, "The '{{.name}}' driver does not support multiple nodes", out.V{"name": drvName})
+		}
 		if viper.GetString(config.ProfileName) != constants.DefaultClusterName {
 			exit.WithCodeT(exit.Config, "The '{{.name}} driver does not support multiple profiles: https://minikube.sigs.k8s.io/docs/reference/drivers/none/", out.V{"name": drvName})
 		}
@@ -880,7 +1154,18 @@ func generateCfgFromFlags(cmd *cobra.Command, k8sVersion string, drvName string)
 			ShouldLoadCachedImages: viper.GetBool(cacheImages),
 			EnableDefaultCNI:       selectedEnableDefaultCNI,
 		},
-		Nodes: []config.Node{cp},
+		Nodes:             []config.Node{cp},
+		HA:                viper.GetBool(ha),
+		AutoPauseInterval: viper.GetDuration(autoPauseInterval),
+		Mount:             viper.GetBool(createMount),
+		MountString:       viper.GetString(mountString),
+	}
+	// kic clusters get a network of their own, named after the profile
+	if driver.IsKIC(drvName) {
+		cfg.Network = cfg.Name
+		cfg.Subnet = viper.GetString(subnet)
+		cfg.ExposedPorts = viper.GetStringSlice(ports)
+		cfg.Rootless = rootless(drvName)
 	}
 	return cfg, cp, nil
 }
@@ -936,6 +1221,18 @@ func autoSetDriverOptions(cmd *cobra.Command, drvName string) (err error) {
 }
 
 // getKubernetesVersion ensures that the requested version is reasonable
+// requestedKubernetesVersion returns the Kubernetes version given with --kubernetes-version, or else the one of the
+// existing cluster or the default one, without the upgrade checks of getKubernetesVersion
+func requestedKubernetesVersion(existing *config.ClusterConfig) string {
+	if v := viper.GetString(kubernetesVersion); v != "" {
+		return v
+	}
+	if existing != nil && existing.KubernetesConfig.KubernetesVersion != "" {
+		return existing.KubernetesConfig.KubernetesVersion
+	}
+	return constants.DefaultKubernetesVersion
+}
+
 func getKubernetesVersion(old *config.ClusterConfig) string {
 	paramVersion := viper.GetString(kubernetesVersion)
 
@@ -20,9 +20,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/docker/machine/libmachine"
 	"github.com/docker/machine/libmachine/state"
@@ -30,6 +32,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/autopause"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -56,14 +60,20 @@ const (
 
 	// Nonexistent means nonexistent
 	Nonexistent = "Nonexistent" // ~state.None
+	// Irrelevant is used for components which a node does not run, such as the apiserver of a worker
+	Irrelevant = "Irrelevant"
 )
 
 // Status holds string representations of component states
 type Status struct {
+	Name       string
 	Host       string
 	Kubelet    string
 	APIServer  string
 	Kubeconfig string
+	Worker     bool
+	AutoPause  string `json:",omitempty"` // Only reported for the primary control plane of clusters with auto-pause enabled
+	TimeToStop string `json:",omitempty"` // Only reported for the primary control plane while a stop is scheduled
 }
 
 const (
@@ -74,6 +84,30 @@ const (
 kubelet: {{.Kubelet}}
 apiserver: {{.APIServer}}
 kubeconfig: {{.Kubeconfig}}
+{{- if .AutoPause}}
+auto-pause: {{.AutoPause}}
+{{- end}}
+{{- if .TimeToStop}}
+time-to-stop: {{.TimeToStop}}
+{{- end}}
+`
+	controlPlaneStatusFormat = `{{.Name}}
+type: Control Plane
+host: {{.Host}}
+kubelet: {{.Kubelet}}
+apiserver: {{.APIServer}}
+kubeconfig: {{.Kubeconfig}}
+{{- if .AutoPause}}
+auto-pause: {{.AutoPause}}
+{{- end}}
+{{- if .TimeToStop}}
+time-to-stop: {{.TimeToStop}}
+{{- end}}
+`
+	workerStatusFormat = `{{.Name}}
+type: Worker
+host: {{.Host}}
+kubelet: {{.Kubelet}}
 `
 )
 
@@ -83,7 +117,8 @@ var statusCmd = &cobra.Command{
 	Short: "Gets the status of a local kubernetes cluster",
 	Long: `Gets the status of a local kubernetes cluster.
 	Exit status contains the status of minikube's VM, cluster and kubernetes encoded on it's bits in this order from right to left.
-	Eg: 7 meaning: 1 (for minikube NOK) + 2 (for cluster NOK) + 4 (for kubernetes NOK)`,
+	Eg: 7 meaning: 1 (for minikube NOK) + 2 (for cluster NOK) + 4 (for kubernetes NOK)
+	For clusters with several nodes, a bit is set when it is set for any of the nodes.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		if output != "text" && statusFormat != defaultStatusFormat {
@@ -101,57 +136,78 @@ var statusCmd = &cobra.Command{
 			exit.WithError("getting config", err)
 		}
 
-		cp, err := config.PrimaryControlPlane(*cc)
-		if err != nil {
-			exit.WithError("getting primary control plane", err)
-		}
-
-		machineName := driver.MachineName(*cc, cp)
-		st, err := status(api, machineName)
-		if err != nil {
-			glog.Errorf("status error: %v", err)
-		}
-		if st.Host == Nonexistent {
-			glog.Errorf("The %q cluster does not exist!", machineName)
+		statuses := []*Status{}
+		for _, n := range cc.Nodes {
+			machineName := driver.MachineName(*cc, n)
+			st, err := status(api, *cc, n)
+			if err != nil {
+				glog.Errorf("status error: %v", err)
+			}
+			if st.Host == Nonexistent {
+				glog.Errorf("The %q host does not exist!", machineName)
+			}
+			statuses = append(statuses, st)
 		}
 
 		switch strings.ToLower(output) {
 		case "text":
-			if err := statusText(st, os.Stdout); err != nil {
+			if err := statusesText(statuses, os.Stdout); err != nil {
 				exit.WithError("status text failure", err)
 			}
 		case "json":
-			if err := statusJSON(st, os.Stdout); err != nil {
+			if err := statusesJSON(statuses, os.Stdout); err != nil {
 				exit.WithError("status json failure", err)
 			}
 		default:
 			exit.WithCodeT(exit.BadUsage, fmt.Sprintf("invalid output format: %s. Valid values: 'text', 'json'", output))
 		}
 
-		os.Exit(exitCode(st))
+		os.Exit(exitCode(statuses...))
 	},
 }
 
-func exitCode(st *Status) int {
+// exitCode returns the status bitmask, setting each bit which is set for any of the nodes
+func exitCode(statuses ...*Status) int {
 	c := 0
-	if st.Host != state.Running.String() {
-		c |= minikubeNotRunningStatusFlag
-	}
-	if st.APIServer != state.Running.String() || st.Kubelet != state.Running.String() {
-		c |= clusterNotRunningStatusFlag
-	}
-	if st.Kubeconfig != Configured {
-		c |= k8sNotRunningStatusFlag
+	for _, st := range statuses {
+		if st.Host != state.Running.String() {
+			c |= minikubeNotRunningStatusFlag
+		}
+		if (st.APIServer != state.Running.String() && st.APIServer != Irrelevant) || st.Kubelet != state.Running.String() {
+			c |= clusterNotRunningStatusFlag
+		}
+		if st.Kubeconfig != Configured && st.Kubeconfig != Irrelevant {
+			c |= k8sNotRunningStatusFlag
+		}
 	}
 	return c
 }
 
-func status(api libmachine.API, name string) (*Status, error) {
+func status(api libmachine.API, cc config.ClusterConfig, n config.Node) (*Status, error) {
+	name := driver.MachineName(cc, n)
+	primary := config.IsPrimaryControlPlane(cc, n)
+
 	st := &Status{
+		Name:       name,
 		Host:       Nonexistent,
 		APIServer:  Nonexistent,
 		Kubelet:    Nonexistent,
 		Kubeconfig: Nonexistent,
+		Worker:     !n.ControlPlane,
+	}
+	// the kubeconfig describes the whole cluster, so only the primary control plane reports it
+	if st.Worker {
+		st.APIServer = Irrelevant
+	}
+	if !primary {
+		st.Kubeconfig = Irrelevant
+	}
+	autoPause := primary && cc.AutoPauseInterval > 0
+	if autoPause {
+		st.AutoPause = Nonexistent
+	}
+	if primary && scheduledStopRunning(cc.ScheduledStop) {
+		st.TimeToStop = timeToStop(cc, time.Now())
 	}
 
 	hs, err := machine.GetHostStatus(api, name)
@@ -169,9 +225,16 @@ func status(api libmachine.API, name string) (*Status, error) {
 	// If it's not running, quickly bail out rather than delivering conflicting messages
 	if st.Host != state.Running.String() {
 		glog.Infof("host is not running, skipping remaining checks")
-		st.APIServer = st.Host
+		if st.APIServer != Irrelevant {
+			st.APIServer = st.Host
+		}
 		st.Kubelet = st.Host
-		st.Kubeconfig = st.Host
+		if st.Kubeconfig != Irrelevant {
+			st.Kubeconfig = st.Host
+		}
+		if autoPause {
+			st.AutoPause = st.Host
+		}
 		return st, nil
 	}
 
@@ -183,17 +246,25 @@ func status(api libmachine.API, name string) (*Status, error) {
 		return st, err
 	}
 
-	port, err := kubeconfig.Port(name)
-	if err != nil {
-		glog.Warningf("unable to get port: %v", err)
-		port = constants.APIServerPort
-	}
+	port := constants.APIServerPort
+	if primary {
+		port, err = kubeconfig.Port(name)
+		if err != nil {
+			glog.Warningf("unable to get port: %v", err)
+			port = constants.APIServerPort
+		}
 
-	st.Kubeconfig = Misconfigured
-	ok, err := kubeconfig.IsClusterInConfig(ip, name)
-	glog.Infof("%s is in kubeconfig at ip %s: %v (err=%v)", name, ip, ok, err)
-	if ok {
-		st.Kubeconfig = Configured
+		st.Kubeconfig = Misconfigured
+		kip := ip
+		// VM clusters with several control planes are reached through their virtual IP
+		if cc.HA && !driver.IsKIC(cc.Driver) {
+			kip = net.ParseIP(cc.KubernetesConfig.APIServerHAVIP)
+		}
+		ok, err := kubeconfig.IsClusterInConfig(kip, name)
+		glog.Infof("%s is in kubeconfig at ip %s: %v (err=%v)", name, kip, ok, err)
+		if ok {
+			st.Kubeconfig = Configured
+		}
 	}
 
 	host, err := machine.CheckIfHostExistsAndLoad(api, name)
@@ -216,6 +287,29 @@ func status(api libmachine.API, name string) (*Status, error) {
 		st.Kubelet = stk.String()
 	}
 
+	if autoPause {
+		st.AutoPause = autopause.Status(cr).String()
+		if autopause.Paused(cr) {
+			cluster.MarkPaused(&cc, cluster.DefaultNamespaces)
+		}
+	}
+
+	if st.Worker {
+		markPaused(cc, st)
+		return st, nil
+	}
+
+	// The kubeconfig points at the primary control plane, at the load balancer of HA clusters,
+	// or at the auto-pause proxy which would unpause the cluster, so other cases are checked directly.
+	if !primary || cc.HA || autoPause {
+		ip, port, err = apiServerEndpoint(cc, n, name)
+		if err != nil {
+			glog.Errorln("Error apiserver endpoint:", err)
+			st.APIServer = state.Error.String()
+			return st, nil
+		}
+	}
+
 	sta, err := kverify.APIServerStatus(cr, ip, port)
 	glog.Infof("%s apiserver status = %s (err=%v)", name, stk, err)
 
@@ -226,9 +320,43 @@ func status(api libmachine.API, name string) (*Status, error) {
 		st.APIServer = sta.String()
 	}
 
+	markPaused(cc, st)
 	return st, nil
 }
 
+// markPaused reports the components stopped by minikube pause as paused, as the apiserver can only be seen frozen through the cgroup v1 freezer
+func markPaused(cc config.ClusterConfig, st *Status) {
+	if !cc.Paused {
+		return
+	}
+	if st.Kubelet == state.Stopped.String() {
+		st.Kubelet = state.Paused.String()
+	}
+	if st.APIServer == state.Stopped.String() && cluster.IsPaused(cc, "kube-system") {
+		st.APIServer = state.Paused.String()
+	}
+}
+
+// apiServerEndpoint returns the address at which the host reaches the apiserver of a control plane node
+func apiServerEndpoint(cc config.ClusterConfig, n config.Node, machineName string) (net.IP, int, error) {
+	port := n.Port
+	if port <= 0 {
+		port = constants.APIServerPort
+	}
+	if driver.IsKIC(cc.Driver) {
+		hostPort, err := oci.HostPortBinding(cc.Driver, machineName, port)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "get host-bind port %d for container %s", port, machineName)
+		}
+		return net.ParseIP(oci.DefaultBindIPV4), hostPort, nil
+	}
+	ip := net.ParseIP(n.IP)
+	if ip == nil {
+		return nil, 0, fmt.Errorf("invalid IP for node %s: %q", n.Name, n.IP)
+	}
+	return ip, port, nil
+}
+
 func init() {
 	statusCmd.Flags().StringVarP(&statusFormat, "format", "f", defaultStatusFormat,
 		`Go template format string for the status output.  The format for Go templates can be found here: https://golang.org/pkg/text/template/
@@ -237,8 +365,33 @@ For the list accessible variables for the template, see the struct values here:
 		`minikube status --output OUTPUT. json, text`)
 }
 
-func statusText(st *Status, w io.Writer) error {
-	tmpl, err := template.New("status").Parse(statusFormat)
+// statusesText writes the status of every node, naming the nodes of clusters with several
+func statusesText(statuses []*Status, w io.Writer) error {
+	if len(statuses) == 1 {
+		return statusText(statuses[0], statusFormat, w)
+	}
+	for i, st := range statuses {
+		format := statusFormat
+		if format == defaultStatusFormat {
+			format = controlPlaneStatusFormat
+			if st.Worker {
+				format = workerStatusFormat
+			}
+		}
+		if i > 0 {
+			if _, err := w.Write([]byte("\n")); err != nil {
+				return err
+			}
+		}
+		if err := statusText(st, format, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func statusText(st *Status, format string, w io.Writer) error {
+	tmpl, err := template.New("status").Parse(format)
 	if err != nil {
 		return err
 	}
@@ -252,8 +405,9 @@ func statusText(st *Status, w io.Writer) error {
 	return nil
 }
 
-func statusJSON(st *Status, w io.Writer) error {
-	js, err := json.Marshal(st)
+// statusesJSON writes the statuses as a list with one object per node, whatever the number of nodes
+func statusesJSON(statuses []*Status, w io.Writer) error {
+	js, err := json.Marshal(statuses)
 	if err != nil {
 		return err
 	}
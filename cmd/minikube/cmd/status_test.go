@@ -20,6 +20,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/config"
 )
 
 func TestExitCode(t *testing.T) {
@@ -29,9 +32,10 @@ func TestExitCode(t *testing.T) {
 		state *Status
 	}{
 		{"ok", 0, &Status{Host: "Running", Kubelet: "Running", APIServer: "Running", Kubeconfig: Configured}},
-		{"paused", 2, &Status{Host: "Running", Kubelet: "Stopped", APIServer: "Paused", Kubeconfig: Configured}},
+		{"paused", 2, &Status{Host: "Running", Kubelet: "Paused", APIServer: "Paused", Kubeconfig: Configured}},
 		{"down", 7, &Status{Host: "Stopped", Kubelet: "Stopped", APIServer: "Stopped", Kubeconfig: Misconfigured}},
 		{"missing", 7, &Status{Host: "Nonexistent", Kubelet: "Nonexistent", APIServer: "Nonexistent", Kubeconfig: "Nonexistent"}},
+		{"worker", 0, &Status{Host: "Running", Kubelet: "Running", APIServer: Irrelevant, Kubeconfig: Irrelevant, Worker: true}},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -56,8 +60,18 @@ func TestStatusText(t *testing.T) {
 		},
 		{
 			name:  "paused",
-			state: &Status{Host: "Running", Kubelet: "Stopped", APIServer: "Paused", Kubeconfig: Configured},
-			want:  "host: Running\nkubelet: Stopped\napiserver: Paused\nkubeconfig: Configured\n",
+			state: &Status{Host: "Running", Kubelet: "Paused", APIServer: "Paused", Kubeconfig: Configured},
+			want:  "host: Running\nkubelet: Paused\napiserver: Paused\nkubeconfig: Configured\n",
+		},
+		{
+			name:  "auto-paused",
+			state: &Status{Host: "Running", Kubelet: "Paused", APIServer: "Paused", Kubeconfig: Configured, AutoPause: "Running"},
+			want:  "host: Running\nkubelet: Paused\napiserver: Paused\nkubeconfig: Configured\nauto-pause: Running\n",
+		},
+		{
+			name:  "scheduled",
+			state: &Status{Host: "Running", Kubelet: "Running", APIServer: "Running", Kubeconfig: Configured, TimeToStop: "4m59s"},
+			want:  "host: Running\nkubelet: Running\napiserver: Running\nkubeconfig: Configured\ntime-to-stop: 4m59s\n",
 		},
 		{
 			name:  "down",
@@ -68,7 +82,7 @@ func TestStatusText(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			var b bytes.Buffer
-			err := statusText(tc.state, &b)
+			err := statusText(tc.state, defaultStatusFormat, &b)
 			if err != nil {
 				t.Errorf("text(%+v) error: %v", tc.state, err)
 			}
@@ -87,21 +101,181 @@ func TestStatusJSON(t *testing.T) {
 		state *Status
 	}{
 		{"ok", &Status{Host: "Running", Kubelet: "Running", APIServer: "Running", Kubeconfig: Configured}},
-		{"paused", &Status{Host: "Running", Kubelet: "Stopped", APIServer: "Paused", Kubeconfig: Configured}},
+		{"paused", &Status{Host: "Running", Kubelet: "Paused", APIServer: "Paused", Kubeconfig: Configured}},
 		{"down", &Status{Host: "Stopped", Kubelet: "Stopped", APIServer: "Stopped", Kubeconfig: Misconfigured}},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			var b bytes.Buffer
-			err := statusJSON(tc.state, &b)
+			err := statusesJSON([]*Status{tc.state}, &b)
 			if err != nil {
 				t.Errorf("json(%+v) error: %v", tc.state, err)
 			}
 
-			st := &Status{}
-			if err := json.Unmarshal(b.Bytes(), st); err != nil {
+			st := []*Status{}
+			if err := json.Unmarshal(b.Bytes(), &st); err != nil {
 				t.Errorf("json(%+v) unmarshal error: %v", tc.state, err)
 			}
+			if len(st) != 1 || *st[0] != *tc.state {
+				t.Errorf("json(%+v) = %+v, want a list of the status", tc.state, st)
+			}
+		})
+	}
+}
+
+func TestExitCodeNodes(t *testing.T) {
+	cp := &Status{Host: "Running", Kubelet: "Running", APIServer: "Running", Kubeconfig: Configured}
+	worker := &Status{Host: "Running", Kubelet: "Running", APIServer: Irrelevant, Kubeconfig: Irrelevant, Worker: true}
+	stoppedWorker := &Status{Host: "Stopped", Kubelet: "Stopped", APIServer: Irrelevant, Kubeconfig: Irrelevant, Worker: true}
+	stoppedCP := &Status{Host: "Running", Kubelet: "Running", APIServer: "Stopped", Kubeconfig: Irrelevant}
+
+	var tests = []struct {
+		name     string
+		want     int
+		statuses []*Status
+	}{
+		{"ok", 0, []*Status{cp, worker, worker}},
+		{"stopped worker", 3, []*Status{cp, worker, stoppedWorker}},
+		{"stopped control plane", 2, []*Status{cp, stoppedCP, worker}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := exitCode(tc.statuses...)
+			if got != tc.want {
+				t.Errorf("exitcode(%v) = %d, want: %d", tc.statuses, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStatusesText(t *testing.T) {
+	statuses := []*Status{
+		{Name: "minikube", Host: "Running", Kubelet: "Running", APIServer: "Running", Kubeconfig: Configured},
+		{Name: "minikube-m02", Host: "Running", Kubelet: "Stopped", APIServer: Irrelevant, Kubeconfig: Irrelevant, Worker: true},
+	}
+
+	var tests = []struct {
+		name     string
+		format   string
+		statuses []*Status
+		want     string
+	}{
+		{
+			name:     "single node",
+			format:   defaultStatusFormat,
+			statuses: statuses[:1],
+			want:     "host: Running\nkubelet: Running\napiserver: Running\nkubeconfig: Configured\n",
+		},
+		{
+			name:     "several nodes",
+			format:   defaultStatusFormat,
+			statuses: statuses,
+			want:     "minikube\ntype: Control Plane\nhost: Running\nkubelet: Running\napiserver: Running\nkubeconfig: Configured\n\nminikube-m02\ntype: Worker\nhost: Running\nkubelet: Stopped\n",
+		},
+		{
+			name:     "custom format",
+			format:   "{{.Name}}: {{.Kubelet}}\n",
+			statuses: statuses,
+			want:     "minikube: Running\n\nminikube-m02: Stopped\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			original := statusFormat
+			statusFormat = tc.format
+			defer func() { statusFormat = original }()
+
+			var b bytes.Buffer
+			if err := statusesText(tc.statuses, &b); err != nil {
+				t.Errorf("text(%v) error: %v", tc.statuses, err)
+			}
+			got := b.String()
+			if got != tc.want {
+				t.Errorf("text(%v) = %q, want: %q", tc.statuses, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStatusesJSON(t *testing.T) {
+	statuses := []*Status{
+		{Name: "minikube", Host: "Running", Kubelet: "Running", APIServer: "Running", Kubeconfig: Configured},
+		{Name: "minikube-m02", Host: "Running", Kubelet: "Running", APIServer: Irrelevant, Kubeconfig: Irrelevant, Worker: true},
+	}
+
+	var b bytes.Buffer
+	if err := statusesJSON(statuses[:1], &b); err != nil {
+		t.Fatalf("json error: %v", err)
+	}
+	single := []*Status{}
+	if err := json.Unmarshal(b.Bytes(), &single); err != nil {
+		t.Errorf("single node should be a json list too: %v", err)
+	}
+	if len(single) != 1 || single[0].Name != "minikube" {
+		t.Errorf("unexpected statuses: %+v", single)
+	}
+
+	b.Reset()
+	if err := statusesJSON(statuses, &b); err != nil {
+		t.Fatalf("json error: %v", err)
+	}
+	got := []*Status{}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("several nodes should be a json list: %v", err)
+	}
+	if len(got) != 2 || got[1].Name != "minikube-m02" || !got[1].Worker {
+		t.Errorf("unexpected statuses: %+v", got)
+	}
+}
+
+func TestMarkPaused(t *testing.T) {
+	running := config.ClusterConfig{}
+	paused := config.ClusterConfig{Paused: true}
+	pausedApps := config.ClusterConfig{Paused: true, PausedNamespaces: []string{"default"}}
+
+	var tests = []struct {
+		name  string
+		cc    config.ClusterConfig
+		state *Status
+		want  *Status
+	}{
+		{
+			name:  "stopped",
+			cc:    running,
+			state: &Status{Host: "Running", Kubelet: "Stopped", APIServer: "Stopped", Kubeconfig: Configured},
+			want:  &Status{Host: "Running", Kubelet: "Stopped", APIServer: "Stopped", Kubeconfig: Configured},
+		},
+		{
+			name:  "paused",
+			cc:    paused,
+			state: &Status{Host: "Running", Kubelet: "Stopped", APIServer: "Stopped", Kubeconfig: Configured},
+			want:  &Status{Host: "Running", Kubelet: "Paused", APIServer: "Paused", Kubeconfig: Configured},
+		},
+		{
+			name:  "frozen",
+			cc:    paused,
+			state: &Status{Host: "Running", Kubelet: "Stopped", APIServer: "Paused", Kubeconfig: Configured},
+			want:  &Status{Host: "Running", Kubelet: "Paused", APIServer: "Paused", Kubeconfig: Configured},
+		},
+		{
+			name:  "paused apps",
+			cc:    pausedApps,
+			state: &Status{Host: "Running", Kubelet: "Stopped", APIServer: "Running", Kubeconfig: Configured},
+			want:  &Status{Host: "Running", Kubelet: "Paused", APIServer: "Running", Kubeconfig: Configured},
+		},
+		{
+			name:  "paused worker",
+			cc:    paused,
+			state: &Status{Host: "Running", Kubelet: "Stopped", APIServer: Irrelevant, Kubeconfig: Irrelevant, Worker: true},
+			want:  &Status{Host: "Running", Kubelet: "Paused", APIServer: Irrelevant, Kubeconfig: Irrelevant, Worker: true},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			markPaused(tc.cc, tc.state)
+			if diff := cmp.Diff(tc.want, tc.state); diff != "" {
+				t.Errorf("markPaused mismatch (-want +got):\n%s", diff)
+			}
 		})
 	}
 }
@@ -25,6 +25,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	pkg_config "k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
@@ -32,9 +35,18 @@ import (
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/util/retry"
 )
 
+const scheduledStopDeadlineFlag = "scheduled-stop-deadline"
+
+var (
+	scheduledStopDuration time.Duration
+	cancelScheduledStop   bool
+	scheduledStopDeadline string
+)
+
 // stopCmd represents the stop command
 var stopCmd = &cobra.Command{
 	Use:   "stop",
@@ -46,7 +58,34 @@ itself, leaving all files intact. The cluster can be started again with the "sta
 
 // runStop handles the executes the flow of "minikube stop"
 func runStop(cmd *cobra.Command, args []string) {
+	cmdcfg.SetOutputFormat(outputFormat)
+	register.Reg.SetStep(register.Stopping)
 	profile := viper.GetString(pkg_config.ProfileName)
+
+	if scheduledStopDuration < 0 {
+		exit.UsageT("--schedule must be a positive duration")
+	}
+	if cancelScheduledStop && scheduledStopDuration > 0 {
+		exit.UsageT("--schedule and --cancel-scheduled cannot be used together")
+	}
+	if cancelScheduledStop {
+		cancelScheduled(profile)
+		return
+	}
+	if scheduledStopDuration > 0 {
+		scheduleStop(profile, scheduledStopDuration)
+		return
+	}
+	if scheduledStopDeadline != "" {
+		deadline, err := time.Parse(time.RFC3339, scheduledStopDeadline)
+		if err != nil {
+			exit.WithError("Invalid scheduled stop deadline", err)
+		}
+		if !waitScheduledStop(profile, deadline) {
+			return
+		}
+	}
+
 	api, err := machine.NewAPIClient()
 	if err != nil {
 		exit.WithError("Error getting client", err)
@@ -58,6 +97,17 @@ func runStop(cmd *cobra.Command, args []string) {
 		exit.WithError("Error getting cluster config", err)
 	}
 
+	// Stopping now supersedes any scheduled stop
+	if cc.ScheduledStop != nil {
+		if err := killScheduledStop(cc.ScheduledStop); err != nil {
+			glog.Warningf("unable to kill the scheduled stop of %q: %v", profile, err)
+		}
+		cc.ScheduledStop = nil
+		if err := config.SaveProfile(profile, cc); err != nil {
+			glog.Warningf("unable to clear the scheduled stop of %q: %v", profile, err)
+		}
+	}
+
 	for _, n := range cc.Nodes {
 		nonexistent := stop(api, *cc, n)
 
@@ -66,6 +116,20 @@ func runStop(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// Stopping the hosts discards the paused containers, and start runs the kubelet again
+	if cc.Paused {
+		cluster.MarkUnpaused(cc, nil)
+		if err := config.SaveProfile(profile, cc); err != nil {
+			glog.Warningf("unable to clear the pause state of %q: %v", profile, err)
+		}
+	}
+
+	if cc.HA && driver.IsKIC(cc.Driver) {
+		if err := kic.StopLoadBalancer(cc.Driver, cc.Name); err != nil {
+			out.WarningT("Unable to stop the load balancer: {{.error}}", out.V{"error": err})
+		}
+	}
+
 	if err := killMountProcess(); err != nil {
 		out.T(out.WarningType, "Unable to kill mount process: {{.error}}", out.V{"error": err})
 	}
@@ -74,6 +138,17 @@ func runStop(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exit.WithError("update config", err)
 	}
+	register.Reg.SetStep(register.Done)
+}
+
+func init() {
+	stopCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
+	stopCmd.Flags().DurationVar(&scheduledStopDuration, "schedule", 0, "Stop the cluster after this duration from a background process, e.g. --schedule=5m. The CLI returns immediately.")
+	stopCmd.Flags().BoolVar(&cancelScheduledStop, "cancel-scheduled", false, "Cancel the stop scheduled with --schedule")
+	stopCmd.Flags().StringVar(&scheduledStopDeadline, scheduledStopDeadlineFlag, "", "Used by the scheduled stop process: wait until this RFC3339 time, then stop")
+	if err := stopCmd.Flags().MarkHidden(scheduledStopDeadlineFlag); err != nil {
+		exit.WithError("unable to hide flag", err)
+	}
 }
 
 func stop(api libmachine.API, cluster config.ClusterConfig, n config.Node) bool {
@@ -20,19 +20,19 @@ import (
 	"context"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"runtime"
 	"strconv"
 	"time"
 
+	"github.com/docker/machine/libmachine"
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
-	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/service"
 	"k8s.io/minikube/pkg/minikube/tunnel"
@@ -90,13 +90,7 @@ var tunnelCmd = &cobra.Command{
 		}()
 
 		if runtime.GOOS == "darwin" && cfg.Driver == oci.Docker {
-			port, err := oci.HostPortBinding(oci.Docker, cfg.Name, 22)
-			if err != nil {
-				exit.WithError("error getting ssh port", err)
-			}
-			sshPort := strconv.Itoa(port)
-			sshKey := filepath.Join(localpath.MiniPath(), "machines", cfg.Name, "id_rsa")
-
+			sshPort, sshKey := kicSSHEndpoint(api, *cfg)
 			kicSSHTunnel := kic.NewSSHTunnel(ctx, sshPort, sshKey, clientset.CoreV1())
 			err = kicSSHTunnel.Start()
 			if err != nil {
@@ -117,3 +111,20 @@ var tunnelCmd = &cobra.Command{
 func init() {
 	tunnelCmd.Flags().BoolVarP(&cleanup, "cleanup", "c", false, "call with cleanup=true to remove old tunnels")
 }
+
+// kicSSHEndpoint returns the host port forwarding to SSH on the primary node of a kic cluster, and the key the driver created for it
+func kicSSHEndpoint(api libmachine.API, cc config.ClusterConfig) (string, string) {
+	cp, err := config.PrimaryControlPlane(cc)
+	if err != nil {
+		exit.WithError("Error getting control plane", err)
+	}
+	h, err := api.Load(driver.MachineName(cc, cp))
+	if err != nil {
+		exit.WithError("Error loading host", err)
+	}
+	port, err := h.Driver.GetSSHPort()
+	if err != nil {
+		exit.WithError("error getting ssh port", err)
+	}
+	return strconv.Itoa(port), h.Driver.GetSSHKeyPath()
+}
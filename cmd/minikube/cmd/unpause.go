@@ -84,7 +84,7 @@ var unpauseCmd = &cobra.Command{
 
 			ids, err := cluster.Unpause(cr, r, namespaces)
 			if err != nil {
-				exit.WithError("Pause", err)
+				exit.WithError("Unpause", err)
 			}
 
 			if namespaces == nil {
@@ -94,10 +94,15 @@ var unpauseCmd = &cobra.Command{
 			}
 		}
 
+		cluster.MarkUnpaused(cc, namespaces)
+		if err := config.SaveProfile(cname, cc); err != nil {
+			exit.WithError("Failed to save config", err)
+		}
+
 	},
 }
 
 func init() {
-	unpauseCmd.Flags().StringSliceVarP(&namespaces, "--namespaces", "n", cluster.DefaultNamespaces, "namespaces to unpause")
+	unpauseCmd.Flags().StringSliceVarP(&namespaces, "namespaces", "n", cluster.DefaultNamespaces, "namespaces to unpause")
 	unpauseCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "If set, unpause all namespaces")
 }
@@ -0,0 +1,26 @@
+################################################################################
+#
+# buildkit-bin
+#
+################################################################################
+
+BUILDKIT_BIN_VERSION = v0.7.1
+BUILDKIT_BIN_SITE = https://github.com/moby/buildkit/releases/download/$(BUILDKIT_BIN_VERSION)
+BUILDKIT_BIN_SOURCE = buildkit-$(BUILDKIT_BIN_VERSION).linux-amd64.tar.gz
+
+define BUILDKIT_BIN_INSTALL_TARGET_CMDS
+	$(INSTALL) -D -m 0755 \
+		$(@D)/buildctl \
+		$(TARGET_DIR)/usr/bin/buildctl
+	$(INSTALL) -D -m 0755 \
+		$(@D)/buildkitd \
+		$(TARGET_DIR)/usr/bin/buildkitd
+endef
+
+define BUILDKIT_BIN_INSTALL_INIT_SYSTEMD
+	$(INSTALL) -Dm644 \
+		$(BUILDKIT_BIN_PKGDIR)/buildkit.service \
+		$(TARGET_DIR)/usr/lib/systemd/system/buildkit.service
+endef
+
+$(eval $(generic-package))
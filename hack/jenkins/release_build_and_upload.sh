@@ -46,7 +46,9 @@ env BUILD_IN_DOCKER=y \
   "out/minikube_${DEB_VERSION}-0_amd64.deb" \
   "out/minikube-${RPM_VERSION}-0.x86_64.rpm" \
   "out/docker-machine-driver-kvm2_${DEB_VERSION}-0_amd64.deb" \
-  "out/docker-machine-driver-kvm2-${RPM_VERSION}-0.x86_64.rpm"
+  "out/docker-machine-driver-kvm2-${RPM_VERSION}-0.x86_64.rpm" \
+  out/auto-pause-linux-amd64 \
+  out/auto-pause-linux-arm64
 
 make checksum
 
@@ -90,6 +90,10 @@ FILES_TO_UPLOAD=(
     'docker-machine-driver-kvm2.sha256'
     'docker-machine-driver-hyperkit'
     'docker-machine-driver-hyperkit.sha256'
+    'auto-pause-linux-amd64'
+    'auto-pause-linux-amd64.sha256'
+    'auto-pause-linux-arm64'
+    'auto-pause-linux-arm64.sha256'
 )
 
 # ISO files are special, as they are generated pre-release tagging
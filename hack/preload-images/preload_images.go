@@ -27,12 +27,12 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/drivers/kic"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
-	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
-	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
-	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/node"
 )
 
 const (
@@ -42,34 +42,38 @@ const (
 
 var (
 	kubernetesVersion       = ""
-	tarballFilename         = ""
-	dockerStorageDriver     = ""
 	preloadedTarballVersion = ""
-	containerRuntime        = ""
+	containerRuntimes       = ""
 )
 
 func init() {
 	flag.StringVar(&kubernetesVersion, "kubernetes-version", "", "desired kubernetes version, for example `v1.17.2`")
-	flag.StringVar(&dockerStorageDriver, "docker-storage-driver", "overlay2", "docker storage driver backend")
 	flag.StringVar(&preloadedTarballVersion, "preloaded-tarball-version", "", "preloaded tarball version")
-	flag.StringVar(&containerRuntime, "container-runtime", "docker", "container runtime")
+	flag.StringVar(&containerRuntimes, "container-runtimes", "docker,containerd,cri-o", "comma separated list of container runtimes to generate tarballs for")
 
 	flag.Parse()
-	tarballFilename = fmt.Sprintf("preloaded-images-k8s-%s-%s-%s-%s.tar.lz4", preloadedTarballVersion, kubernetesVersion, containerRuntime, dockerStorageDriver)
 }
 
 func main() {
-	if err := verifyDockerStorage(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-	if err := executePreloadImages(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	for _, cr := range strings.Split(containerRuntimes, ",") {
+		if cr == "docker" {
+			if err := verifyDockerStorage(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		if err := executePreloadImages(cr); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 	}
 }
 
-func executePreloadImages() error {
+func tarballFilename(containerRuntime string) string {
+	return fmt.Sprintf("preloaded-images-k8s-%s-%s-%s-%s.tar.lz4", preloadedTarballVersion, kubernetesVersion, containerRuntime, download.PreloadStorageDriver(containerRuntime))
+}
+
+func executePreloadImages(containerRuntime string) error {
 	defer func() {
 		if err := deleteMinikube(); err != nil {
 			fmt.Println(err)
@@ -78,7 +82,7 @@ func executePreloadImages() error {
 
 	driver := kic.NewDriver(kic.Config{
 		KubernetesVersion: kubernetesVersion,
-		ContainerRuntime:  driver.Docker,
+		ContainerRuntime:  containerRuntime,
 		OCIBinary:         oci.Docker,
 		MachineName:       profile,
 		ImageDigest:       kic.BaseImage,
@@ -98,58 +102,31 @@ func executePreloadImages() error {
 		return errors.Wrap(err, "creating kic driver")
 	}
 
-	// Now, get images to pull
-	imgs, err := images.Kubeadm("", kubernetesVersion)
+	runner := command.NewKICRunner(profile, driver.OCIBinary)
+	cr, err := cruntime.New(cruntime.Config{Type: containerRuntime, Runner: runner, KubernetesVersion: kubernetesVersion})
 	if err != nil {
-		return errors.Wrap(err, "kubeadm images")
+		return errors.Wrap(err, "container runtime")
 	}
-
-	for _, img := range append(imgs, kic.OverlayImage) {
-		cmd := exec.Command("docker", "exec", profile, "docker", "pull", img)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
-			return errors.Wrapf(err, "downloading %s", img)
-		}
+	if err := cr.Enable(true); err != nil {
+		return errors.Wrapf(err, "enabling %s", containerRuntime)
 	}
 
-	// Transfer in k8s binaries
-	kcfg := config.KubernetesConfig{
-		KubernetesVersion: kubernetesVersion,
+	cc := config.ClusterConfig{
+		Driver: "docker",
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: kubernetesVersion,
+			ContainerRuntime:  containerRuntime,
+		},
 	}
-	runner := command.NewKICRunner(profile, driver.OCIBinary)
-	if err := bsutil.TransferBinaries(kcfg, runner); err != nil {
-		return errors.Wrap(err, "transferring k8s binaries")
+	if err := node.PullPreloadImages(runner, cc, nil); err != nil {
+		return errors.Wrap(err, "pulling images")
 	}
-	// Create image tarball
-	if err := createImageTarball(); err != nil {
-		return err
-	}
-	return copyTarballToHost()
-}
-
-func createImageTarball() error {
-	dirs := []string{
-		fmt.Sprintf("./lib/docker/%s", dockerStorageDriver),
-		"./lib/docker/image",
-		"./lib/minikube/binaries",
-	}
-	args := []string{"exec", profile, "sudo", "tar", "-I", "lz4", "-C", "/var", "-cvf", tarballFilename}
-	args = append(args, dirs...)
-	cmd := exec.Command("docker", args...)
-	cmd.Stdout = os.Stdout
-	if err := cmd.Run(); err != nil {
-		return errors.Wrap(err, "creating image tarball")
+	// Stop the runtime, so that its image store is consistent on disk
+	if err := cr.Disable(); err != nil {
+		return errors.Wrapf(err, "disabling %s", containerRuntime)
 	}
-	return nil
-}
-
-func copyTarballToHost() error {
-	dest := filepath.Join("out/", tarballFilename)
-	cmd := exec.Command("docker", "cp", fmt.Sprintf("%s:/%s", profile, tarballFilename), dest)
-	cmd.Stdout = os.Stdout
-	if err := cmd.Run(); err != nil {
-		return errors.Wrap(err, "copying tarball to host")
+	if err := node.WritePreload(runner, cc, filepath.Join("out", tarballFilename(containerRuntime))); err != nil {
+		return errors.Wrap(err, "writing preloaded tarball")
 	}
 	return nil
 }
@@ -167,8 +144,8 @@ func verifyDockerStorage() error {
 		return err
 	}
 	driver := strings.Trim(string(output), " \n")
-	if driver != dockerStorageDriver {
-		return fmt.Errorf("docker storage driver %s does not match requested %s", driver, dockerStorageDriver)
+	if want := download.PreloadStorageDriver("docker"); driver != want {
+		return fmt.Errorf("docker storage driver %s does not match the expected %s", driver, want)
 	}
 	return nil
 }
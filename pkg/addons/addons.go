@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/glog"
@@ -38,23 +39,112 @@ import (
 	pkgutil "k8s.io/minikube/pkg/util"
 )
 
-// defaultStorageClassProvisioner is the name of the default storage class provisioner
-const defaultStorageClassProvisioner = "standard"
+const (
+	// defaultStorageClassProvisioner is the name of the default storage class provisioner
+	defaultStorageClassProvisioner = "standard"
 
-// Set sets a value
+	// maxParallelAddons is the number of addons which may be enabled at the same time
+	maxParallelAddons = 4
+)
+
+var (
+	// For testing
+	hostRunner = runningHostRunner
+
+	// configLock serializes changes to the profile config by addons being set concurrently
+	configLock sync.RWMutex
+)
+
+// Set sets a value, enabling any required addons first
 func Set(name, value, profile string) error {
+	return set(name, value, profile, false)
+}
+
+// SetForce sets a value, disabling the addon even if enabled addons require it
+func SetForce(name, value, profile string) error {
+	return set(name, value, profile, true)
+}
+
+// SetImageOverrides persists overrides for the images and registries of an addon's image slots
+func SetImageOverrides(name, profile string, images, registries map[string]string) error {
+	bundle, ok := assets.Addons[name]
+	if !ok {
+		return errors.Errorf("%s is not a valid addon", name)
+	}
+	for _, overrides := range []map[string]string{images, registries} {
+		for slot := range overrides {
+			if _, ok := bundle.Images[slot]; !ok {
+				return errors.Errorf("addon %s has no image named %q, see: minikube addons images %s", name, slot, name)
+			}
+		}
+	}
+	if len(images) == 0 && len(registries) == 0 {
+		return nil
+	}
+
+	configLock.Lock()
+	defer configLock.Unlock()
+	c, err := config.Load(profile)
+	if err != nil {
+		return errors.Wrap(err, "loading profile")
+	}
+	c.CustomAddonImages = mergeOverrides(c.CustomAddonImages, name, images)
+	c.CustomAddonRegistries = mergeOverrides(c.CustomAddonRegistries, name, registries)
+
+	glog.Infof("Writing out %q config to override %s images=%v registries=%v", profile, name, images, registries)
+	return config.Write(profile, c)
+}
+
+// mergeOverrides merges the slot overrides of an addon into the overrides of every addon
+func mergeOverrides(all map[string]map[string]string, name string, overrides map[string]string) map[string]map[string]string {
+	if len(overrides) == 0 {
+		return all
+	}
+	if all == nil {
+		all = map[string]map[string]string{}
+	}
+	if all[name] == nil {
+		all[name] = map[string]string{}
+	}
+	for slot, v := range overrides {
+		all[name][slot] = v
+	}
+	return all
+}
+
+func set(name, value, profile string, force bool) error {
 	glog.Infof("Setting %s=%s in profile %q", name, value, profile)
 	a, valid := isAddonValid(name)
 	if !valid {
 		return errors.Errorf("%s is not a valid addon", name)
 	}
 
+	enable, err := strconv.ParseBool(value)
+	if err != nil {
+		return errors.Wrapf(err, "parsing bool: %s", name)
+	}
+	if enable {
+		err = enablePrerequisites(name, profile)
+	} else {
+		err = checkDependents(name, profile, force)
+	}
+	if err != nil {
+		return err
+	}
+
 	// Run any additional validations for this property
 	if err := run(name, value, profile, a.validations); err != nil {
 		return errors.Wrap(err, "running validations")
 	}
 
-	// Set the value
+	// Run any callbacks for this property
+	if err := run(name, value, profile, a.callbacks); err != nil {
+		return errors.Wrap(err, "running callbacks")
+	}
+
+	// Set the value, reloading the config in case other addons were set meanwhile
+	configLock.Lock()
+	defer configLock.Unlock()
 	c, err := config.Load(profile)
 	if err != nil {
 		return errors.Wrap(err, "loading profile")
@@ -64,15 +154,24 @@ func Set(name, value, profile string) error {
 		return errors.Wrap(err, "setting new value of addon")
 	}
 
-	// Run any callbacks for this property
-	if err := run(name, value, profile, a.callbacks); err != nil {
-		return errors.Wrap(err, "running callbacks")
-	}
-
 	glog.Infof("Writing out %q config to set %s=%v...", profile, name, value)
 	return config.Write(profile, c)
 }
 
+// loadConfig loads the profile config, without racing addons being set concurrently
+func loadConfig(profile string) (*config.ClusterConfig, error) {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return config.Load(profile)
+}
+
+// isEnabled returns whether an addon is enabled, without racing addons being set concurrently
+func isEnabled(addon *assets.Addon, profile string) (bool, error) {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return addon.IsEnabled(profile)
+}
+
 // Runs all the validation or callback functions and collects errors
 func run(name, value, profile string, fns []setFn) error {
 	var errors []error
@@ -134,35 +233,51 @@ func enableOrDisableAddon(name, val, profile string) error {
 		}
 	}
 
+	cfg, err := loadConfig(profile)
+	if err != nil && !config.IsNotExist(err) {
+		exit.WithCodeT(exit.Data, "Unable to load config: {{.error}}", out.V{"error": err})
+	}
+
+	cmd, err := hostRunner(profile)
+	if err != nil {
+		return err
+	}
+	if cmd == nil {
+		glog.Warningf("%q is not running, writing %s=%v to disk and skipping enablement", profile, addon.Name(), enable)
+		return nil
+	}
+
+	data, err := assets.GenerateTemplateData(addon, *cfg)
+	if err != nil {
+		return errors.Wrap(err, "template data")
+	}
+	return enableOrDisableAddonInternal(addon, cmd, data, enable, profile)
+}
+
+// runningHostRunner returns a command runner for the profile's host, or nil if the host is not running
+func runningHostRunner(profile string) (command.Runner, error) {
 	// TODO(r2d4): config package should not reference API, pull this out
 	api, err := machine.NewAPIClient()
 	if err != nil {
-		return errors.Wrap(err, "machine client")
+		return nil, errors.Wrap(err, "machine client")
 	}
 	defer api.Close()
 
-	cfg, err := config.Load(profile)
-	if err != nil && !config.IsNotExist(err) {
-		exit.WithCodeT(exit.Data, "Unable to load config: {{.error}}", out.V{"error": err})
-	}
-
 	host, err := machine.CheckIfHostExistsAndLoad(api, profile)
 	if err != nil || !machine.IsHostRunning(api, profile) {
-		glog.Warningf("%q is not running, writing %s=%v to disk and skipping enablement (err=%v)", profile, addon.Name(), enable, err)
-		return nil
+		glog.Infof("%q is not running (err=%v)", profile, err)
+		return nil, nil
 	}
 
 	cmd, err := machine.CommandRunner(host)
 	if err != nil {
-		return errors.Wrap(err, "command runner")
+		return nil, errors.Wrap(err, "command runner")
 	}
-
-	data := assets.GenerateTemplateData(cfg.KubernetesConfig)
-	return enableOrDisableAddonInternal(addon, cmd, data, enable, profile)
+	return cmd, nil
 }
 
 func isAddonAlreadySet(addon *assets.Addon, enable bool, profile string) (bool, error) {
-	addonStatus, err := addon.IsEnabled(profile)
+	addonStatus, err := isEnabled(addon, profile)
 	if err != nil {
 		return false, errors.Wrap(err, "is enabled")
 	}
@@ -233,8 +348,8 @@ func enableOrDisableStorageClasses(name, val, profile string) error {
 	}
 
 	class := defaultStorageClassProvisioner
-	if name == "storage-provisioner-gluster" {
-		class = "glusterfile"
+	if a, ok := assets.Addons[name]; ok && a.StorageClass != "" {
+		class = a.StorageClass
 	}
 	storagev1, err := storageclass.GetStoragev1()
 	if err != nil {
@@ -247,7 +362,7 @@ func enableOrDisableStorageClasses(name, val, profile string) error {
 	}
 	defer api.Close()
 
-	cc, err := config.Load(profile)
+	cc, err := loadConfig(profile)
 	if err != nil {
 		return errors.Wrap(err, "getting cluster")
 	}
@@ -288,7 +403,7 @@ func Start(profile string, toEnable map[string]bool, additional []string) {
 
 	// Get the default values of any addons not saved to our config
 	for name, a := range assets.Addons {
-		defaultVal, err := a.IsEnabled(profile)
+		defaultVal, err := isEnabled(a, profile)
 		if err != nil {
 			glog.Errorf("is-enabled failed for %q: %v", a.Name(), err)
 			continue
@@ -313,12 +428,72 @@ func Start(profile string, toEnable map[string]bool, additional []string) {
 	}
 	sort.Strings(toEnableList)
 
-	out.T(out.AddonEnable, "Enabling addons: {{.addons}}", out.V{"addons": strings.Join(toEnableList, ", ")})
-	for _, a := range toEnableList {
-		err := Set(a, "true", profile)
-		if err != nil {
-			// Intentionally non-fatal
-			out.WarningT("Enabling '{{.name}}' returned an error: {{.error}}", out.V{"name": a, "error": err})
+	// Group the addons so that each is enabled after the addons it requires
+	levels, err := dependencyLevels(assets.Addons, toEnableList)
+	if err != nil {
+		out.WarningT("Unable to order addons by their dependencies: {{.error}}", out.V{"error": err})
+		levels = [][]string{}
+		for _, a := range toEnableList {
+			levels = append(levels, []string{a})
 		}
 	}
+
+	names := []string{}
+	for _, level := range levels {
+		names = append(names, level...)
+	}
+	out.T(out.AddonEnable, "Enabling addons: {{.addons}}", out.V{"addons": strings.Join(names, ", ")})
+
+	failed := map[string]bool{}
+	for i, level := range levels {
+		enableLevel(profile, level, failed)
+		if i == len(levels)-1 {
+			break
+		}
+
+		// Addons required by later levels must be usable, not just applied
+		checks := []assets.ReadinessCheck{}
+		for _, name := range requiredBy(assets.Addons, level, levels[i+1:]) {
+			if !failed[name] {
+				checks = append(checks, assets.Addons[name].Readiness...)
+			}
+		}
+		if err := waitForChecks(profile, checks, prerequisiteTimeout); err != nil {
+			out.WarningT("Required addons are not ready: {{.error}}", out.V{"error": err})
+		}
+	}
+}
+
+// enableLevel concurrently enables addons which do not require each other, recording those which fail
+func enableLevel(profile string, level []string, failed map[string]bool) {
+	toEnable := []string{}
+	for _, name := range level {
+		if p := failedPrerequisite(assets.Addons, name, failed); p != "" {
+			out.WarningT("Skipping '{{.name}}', as the required addon '{{.prerequisite}}' could not be enabled", out.V{"name": name, "prerequisite": p})
+			failed[name] = true
+			continue
+		}
+		toEnable = append(toEnable, name)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, maxParallelAddons)
+	for _, name := range toEnable {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			if err := Set(name, "true", profile); err != nil {
+				// Intentionally non-fatal
+				out.WarningT("Enabling '{{.name}}' returned an error: {{.error}}", out.V{"name": name, "error": err})
+				mu.Lock()
+				failed[name] = true
+				mu.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
 }
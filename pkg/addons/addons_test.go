@@ -129,3 +129,44 @@ func TestStart(t *testing.T) {
 		t.Errorf("expected dashboard to be enabled")
 	}
 }
+
+func TestSetImageOverrides(t *testing.T) {
+	profile := createTestProfile(t)
+
+	if err := SetImageOverrides("dashboard", profile, map[string]string{"Unknown": "img:1"}, nil); err == nil {
+		t.Errorf("expected unknown image name to fail")
+	}
+	if err := SetImageOverrides("dashboard", profile, map[string]string{"Dashboard": "my-org/dashboard:dev"}, map[string]string{"MetricsScraper": "mirror.example.com"}); err != nil {
+		t.Fatalf("SetImageOverrides: %v", err)
+	}
+	if err := SetImageOverrides("dashboard", profile, map[string]string{"MetricsScraper": "my-org/scraper:dev"}, nil); err != nil {
+		t.Fatalf("SetImageOverrides: %v", err)
+	}
+
+	cc, err := config.DefaultLoader.LoadConfigFromFile(profile)
+	if err != nil {
+		t.Fatalf("unable to load profile: %v", err)
+	}
+	dashboard := cc.CustomAddonImages["dashboard"]
+	if dashboard["Dashboard"] != "my-org/dashboard:dev" || dashboard["MetricsScraper"] != "my-org/scraper:dev" {
+		t.Errorf("unexpected image overrides: %v", cc.CustomAddonImages)
+	}
+	if cc.CustomAddonRegistries["dashboard"]["MetricsScraper"] != "mirror.example.com" {
+		t.Errorf("unexpected registry overrides: %v", cc.CustomAddonRegistries)
+	}
+
+	// addons with a slot of the same name keep their own image
+	if err := SetImageOverrides("gvisor", profile, map[string]string{"Pause": "my-org/pause:dev"}, nil); err != nil {
+		t.Fatalf("SetImageOverrides: %v", err)
+	}
+	cc, err = config.DefaultLoader.LoadConfigFromFile(profile)
+	if err != nil {
+		t.Fatalf("unable to load profile: %v", err)
+	}
+	if cc.CustomAddonImages["gvisor"]["Pause"] != "my-org/pause:dev" {
+		t.Errorf("unexpected gvisor overrides: %v", cc.CustomAddonImages)
+	}
+	if _, ok := cc.CustomAddonImages["nvidia-driver-installer"]; ok {
+		t.Errorf("gvisor overrides leaked to nvidia-driver-installer: %v", cc.CustomAddonImages)
+	}
+}
@@ -16,7 +16,13 @@ limitations under the License.
 
 package addons
 
-import "k8s.io/minikube/pkg/minikube/config"
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+)
 
 type setFn func(string, string, string) error
 
@@ -28,98 +34,48 @@ type Addon struct {
 	callbacks   []setFn
 }
 
-// Addons is a list of all addons
-var Addons = []*Addon{
-	{
-		name:      "dashboard",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
+// validationFns are the validations an addon descriptor may refer to by name
+var validationFns = map[string]setFn{
+	"containerd": IsContainerdRuntime,
+}
+
+// Addons is a list of all addons, generated from the addon descriptors
+var Addons = fromDescriptors(assets.Addons)
 
-	{
-		name:      "default-storageclass",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableStorageClasses},
-	},
-	{
-		name:      "efk",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "freshpod",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:        "gvisor",
-		set:         SetBool,
-		validations: []setFn{IsContainerdRuntime},
-		callbacks:   []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "helm-tiller",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "ingress",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "ingress-dns",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "istio-provisioner",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "istio",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name: "logviewer",
-		set:  SetBool,
-	},
-	{
-		name:      "metrics-server",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "nvidia-driver-installer",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "nvidia-gpu-device-plugin",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
+// fromDescriptors generates the addon list from the addons loaded by the assets package
+func fromDescriptors(bundles map[string]*assets.Addon) []*Addon {
+	names := []string{}
+	for name := range bundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	addons := []*Addon{}
+	for _, name := range names {
+		b := bundles[name]
+		a := &Addon{
+			name:      name,
+			set:       SetBool,
+			callbacks: []setFn{enableOrDisableAddon},
+		}
+		if b.StorageClass != "" {
+			a.callbacks = []setFn{enableOrDisableStorageClasses}
+		}
+		for _, v := range b.Validations {
+			fn, ok := validationFns[v]
+			if !ok {
+				fn = unknownValidation(v)
+			}
+			a.validations = append(a.validations, fn)
+		}
+		addons = append(addons, a)
+	}
+	return addons
+}
 
-	{
-		name:      "registry",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "registry-creds",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "storage-provisioner",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableAddon},
-	},
-	{
-		name:      "storage-provisioner-gluster",
-		set:       SetBool,
-		callbacks: []setFn{enableOrDisableStorageClasses},
-	},
+// unknownValidation returns a validation which always fails, so that addons requiring checks this version of minikube does not know cannot be enabled
+func unknownValidation(v string) setFn {
+	return func(name, _, _ string) error {
+		return fmt.Errorf("addon %s requires unknown validation %q", name, v)
+	}
 }
@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// resolveOrder returns the named addons along with every addon they require,
+// ordered so that each addon comes after the addons it requires.
+func resolveOrder(bundles map[string]*assets.Addon, names []string) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	order := []string{}
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("addon dependency cycle: %s -> %s", strings.Join(path, " -> "), name)
+		}
+
+		b, ok := bundles[name]
+		if !ok {
+			if len(path) == 0 {
+				return fmt.Errorf("%s is not a valid addon", name)
+			}
+			return fmt.Errorf("addon %s requires unknown addon %s", path[len(path)-1], name)
+		}
+
+		state[name] = visiting
+		next := append(append([]string{}, path...), name)
+		reqs := append([]string{}, b.Requires...)
+		sort.Strings(reqs)
+		for _, r := range reqs {
+			if err := visit(r, next); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	for _, n := range sorted {
+		if err := visit(n, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// dependencyLevels groups the named addons, along with every addon they require, into levels
+// such that each addon is in a later level than the addons it requires.
+func dependencyLevels(bundles map[string]*assets.Addon, names []string) ([][]string, error) {
+	order, err := resolveOrder(bundles, names)
+	if err != nil {
+		return nil, err
+	}
+
+	depth := map[string]int{}
+	levels := [][]string{}
+	for _, name := range order {
+		d := 0
+		for _, r := range bundles[name].Requires {
+			if depth[r]+1 > d {
+				d = depth[r] + 1
+			}
+		}
+		depth[name] = d
+		if d == len(levels) {
+			levels = append(levels, []string{})
+		}
+		levels[d] = append(levels[d], name)
+	}
+	for _, level := range levels {
+		sort.Strings(level)
+	}
+	return levels, nil
+}
+
+// requiredBy returns the addons within level which are required by an addon within later levels
+func requiredBy(bundles map[string]*assets.Addon, level []string, later [][]string) []string {
+	required := map[string]bool{}
+	for _, l := range later {
+		for _, name := range l {
+			for _, r := range bundles[name].Requires {
+				required[r] = true
+			}
+		}
+	}
+
+	names := []string{}
+	for _, name := range level {
+		if required[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// failedPrerequisite returns the first addon required by the named addon which failed, if any
+func failedPrerequisite(bundles map[string]*assets.Addon, name string, failed map[string]bool) string {
+	for _, r := range bundles[name].Requires {
+		if failed[r] {
+			return r
+		}
+	}
+	return ""
+}
+
+// conflicting returns whether either addon declares a conflict with the other
+func conflicting(bundles map[string]*assets.Addon, a string, b string) bool {
+	for _, c := range bundles[a].Conflicts {
+		if c == b {
+			return true
+		}
+	}
+	for _, c := range bundles[b].Conflicts {
+		if c == a {
+			return true
+		}
+	}
+	return false
+}
+
+// checkConflicts returns an error if any of the addons to enable conflict with each other or with an enabled addon
+func checkConflicts(bundles map[string]*assets.Addon, toEnable []string, enabled map[string]bool) error {
+	others := []string{}
+	for name, on := range enabled {
+		if on {
+			others = append(others, name)
+		}
+	}
+	others = append(others, toEnable...)
+	sort.Strings(others)
+
+	for _, name := range toEnable {
+		for _, o := range others {
+			if o != name && bundles[o] != nil && conflicting(bundles, name, o) {
+				return fmt.Errorf("addon %s conflicts with addon %s", name, o)
+			}
+		}
+	}
+	return nil
+}
+
+// enabledDependents returns the enabled addons which require the named addon
+func enabledDependents(bundles map[string]*assets.Addon, name string, enabled map[string]bool) []string {
+	deps := []string{}
+	for n, on := range enabled {
+		if !on || bundles[n] == nil {
+			continue
+		}
+		for _, r := range bundles[n].Requires {
+			if r == name {
+				deps = append(deps, n)
+			}
+		}
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// enabledAddons returns the enabled state of every addon within a profile
+func enabledAddons(profile string) (map[string]bool, error) {
+	enabled := map[string]bool{}
+	for name, a := range assets.Addons {
+		on, err := isEnabled(a, profile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "is enabled %s", name)
+		}
+		enabled[name] = on
+	}
+	return enabled, nil
+}
+
+// enablePrerequisites enables any disabled addons required by the named addon, prerequisites first
+func enablePrerequisites(name string, profile string) error {
+	order, err := resolveOrder(assets.Addons, []string{name})
+	if err != nil {
+		return err
+	}
+	enabled, err := enabledAddons(profile)
+	if err != nil {
+		return err
+	}
+	if err := checkConflicts(assets.Addons, order, enabled); err != nil {
+		return err
+	}
+
+	for _, p := range order {
+		if p == name || enabled[p] {
+			continue
+		}
+		out.T(out.AddonEnable, "Enabling '{{.name}}', which is required by '{{.dependent}}'", out.V{"name": p, "dependent": name})
+		if err := set(p, "true", profile, false); err != nil {
+			return errors.Wrapf(err, "enabling %s, which is required by %s", p, name)
+		}
+	}
+	return nil
+}
+
+// checkDependents returns an error if enabled addons require the named addon, unless forced
+func checkDependents(name string, profile string, force bool) error {
+	enabled, err := enabledAddons(profile)
+	if err != nil {
+		return err
+	}
+	deps := enabledDependents(assets.Addons, name, enabled)
+	if len(deps) == 0 {
+		return nil
+	}
+	if force {
+		out.WarningT("Disabling '{{.name}}', which is required by: {{.dependents}}", out.V{"name": name, "dependents": strings.Join(deps, ", ")})
+		return nil
+	}
+	return fmt.Errorf("addon %s is required by enabled addons: %s (use --force to disable it anyway)", name, strings.Join(deps, ", "))
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// testBundles returns addons with the given requirements and conflicts
+func testBundles(requires map[string][]string, conflicts map[string][]string) map[string]*assets.Addon {
+	bundles := map[string]*assets.Addon{}
+	for name, reqs := range requires {
+		a := assets.NewAddon(nil, false, name)
+		a.Requires = reqs
+		a.Conflicts = conflicts[name]
+		bundles[name] = a
+	}
+	return bundles
+}
+
+func TestResolveOrder(t *testing.T) {
+	var tests = []struct {
+		description string
+		requires    map[string][]string
+		names       []string
+		expected    []string
+		err         string
+	}{
+		{
+			description: "no dependencies",
+			requires:    map[string][]string{"b": nil, "a": nil},
+			names:       []string{"b", "a"},
+			expected:    []string{"a", "b"},
+		},
+		{
+			description: "prerequisites first",
+			requires:    map[string][]string{"ingress-dns": {"ingress"}, "ingress": nil, "dashboard": nil},
+			names:       []string{"dashboard", "ingress-dns"},
+			expected:    []string{"dashboard", "ingress", "ingress-dns"},
+		},
+		{
+			description: "transitive and shared prerequisites",
+			requires:    map[string][]string{"a": {"c", "b"}, "b": {"d"}, "c": {"d"}, "d": nil},
+			names:       []string{"a"},
+			expected:    []string{"d", "b", "c", "a"},
+		},
+		{
+			description: "cycle",
+			requires:    map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}},
+			names:       []string{"a"},
+			err:         "cycle: a -> b -> c -> a",
+		},
+		{
+			description: "unknown prerequisite",
+			requires:    map[string][]string{"a": {"missing"}},
+			names:       []string{"a"},
+			err:         "addon a requires unknown addon missing",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got, err := resolveOrder(testBundles(test.requires, nil), test.names)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v (order %v)", test.err, err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveOrder: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("order = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestDependencyLevels(t *testing.T) {
+	requires := map[string][]string{"a": {"c", "b"}, "b": {"d"}, "c": nil, "d": nil, "e": nil}
+	got, err := dependencyLevels(testBundles(requires, nil), []string{"e", "a"})
+	if err != nil {
+		t.Fatalf("dependencyLevels: %v", err)
+	}
+	expected := [][]string{{"c", "d", "e"}, {"b"}, {"a"}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("levels = %v, want %v", got, expected)
+	}
+
+	required := requiredBy(testBundles(requires, nil), got[0], got[1:])
+	if !reflect.DeepEqual(required, []string{"c", "d"}) {
+		t.Errorf("required = %v, want [c d]", required)
+	}
+}
+
+func TestCheckConflicts(t *testing.T) {
+	bundles := testBundles(
+		map[string][]string{"nginx": nil, "traefik": nil, "dashboard": nil},
+		map[string][]string{"traefik": {"nginx"}},
+	)
+
+	if err := checkConflicts(bundles, []string{"traefik"}, map[string]bool{"dashboard": true}); err != nil {
+		t.Errorf("unexpected conflict: %v", err)
+	}
+	if err := checkConflicts(bundles, []string{"nginx"}, map[string]bool{"traefik": true}); err == nil {
+		t.Errorf("expected nginx to conflict with enabled traefik")
+	}
+	if err := checkConflicts(bundles, []string{"nginx", "traefik"}, map[string]bool{}); err == nil {
+		t.Errorf("expected nginx and traefik to conflict with each other")
+	}
+}
+
+func TestEnabledDependents(t *testing.T) {
+	bundles := testBundles(map[string][]string{"ingress": nil, "ingress-dns": {"ingress"}, "other": {"ingress"}}, nil)
+	got := enabledDependents(bundles, "ingress", map[string]bool{"ingress": true, "ingress-dns": true, "other": false})
+	if !reflect.DeepEqual(got, []string{"ingress-dns"}) {
+		t.Errorf("dependents = %v, want [ingress-dns]", got)
+	}
+}
+
+// useFakeRunner makes addon enablement run against a fake runner which accepts kubectl for every addon,
+// returning the runner and a function restoring the real one
+func useFakeRunner(t *testing.T) (*command.FakeCommandRunner, func()) {
+	t.Helper()
+	originalK8sVersion := k8sVersion
+	originalHostRunner := hostRunner
+	k8sVersion = func(_ string) (string, error) {
+		return "v1.18.0", nil
+	}
+
+	originalKubeClient := kubeClient
+	kubeClient = func(_ string) (kubernetes.Interface, error) {
+		return nil, fmt.Errorf("no cluster in tests")
+	}
+
+	runner := command.NewFakeCommandRunner()
+	hostRunner = func(_ string) (command.Runner, error) {
+		return runner, nil
+	}
+
+	cmds := map[string]string{}
+	for _, a := range assets.Addons {
+		files := []string{}
+		for _, f := range a.Assets {
+			if strings.HasSuffix(f.GetTargetName(), ".yaml") {
+				files = append(files, f.GetTargetDir()+"/"+f.GetTargetName())
+			}
+		}
+		for _, enable := range []bool{true, false} {
+			c, err := kubectlCommand("", files, enable)
+			if err != nil {
+				t.Fatalf("kubectl command: %v", err)
+			}
+			cmds[strings.Join(c.Args, " ")] = ""
+		}
+	}
+	runner.SetCommandToOutput(cmds)
+
+	return runner, func() {
+		k8sVersion = originalK8sVersion
+		hostRunner = originalHostRunner
+		kubeClient = originalKubeClient
+	}
+}
+
+func TestEnableWithPrerequisites(t *testing.T) {
+	profile := createTestProfile(t)
+	runner, restore := useFakeRunner(t)
+	defer restore()
+
+	if err := Set("ingress-dns", "true", profile); err != nil {
+		t.Fatalf("enable ingress-dns: %v", err)
+	}
+
+	c, err := config.DefaultLoader.LoadConfigFromFile(profile)
+	if err != nil {
+		t.Fatalf("unable to load profile: %v", err)
+	}
+	if !c.Addons["ingress"] || !c.Addons["ingress-dns"] {
+		t.Errorf("expected ingress and ingress-dns to be enabled, got %v", c.Addons)
+	}
+	if _, err := runner.GetFileToContents("deploy/addons/ingress/ingress-configmap.yaml.tmpl"); err != nil {
+		t.Errorf("expected ingress to be copied: %v", err)
+	}
+	// the pod of ingress-dns is a template, which is copied once its image is rendered
+	pod, err := runner.GetFileToContents("deploy/addons/ingress-dns/ingress-dns-pod.yaml")
+	if err != nil {
+		t.Errorf("expected ingress-dns to be copied: %v", err)
+	}
+	if err == nil && !strings.Contains(pod, `image: "cryptexlabs/minikube-ingress-dns:0.2.1"`) {
+		t.Errorf("expected the ingress-dns image to be rendered, got:\n%s", pod)
+	}
+
+	if err := Set("ingress", "false", profile); err == nil {
+		t.Errorf("expected disabling ingress to fail while ingress-dns is enabled")
+	}
+	if err := SetForce("ingress", "false", profile); err != nil {
+		t.Errorf("force disable ingress: %v", err)
+	}
+	c, err = config.DefaultLoader.LoadConfigFromFile(profile)
+	if err != nil {
+		t.Fatalf("unable to load profile: %v", err)
+	}
+	if c.Addons["ingress"] || !c.Addons["ingress-dns"] {
+		t.Errorf("expected only ingress to be disabled, got %v", c.Addons)
+	}
+}
+
+func TestStartWithPrerequisites(t *testing.T) {
+	profile := createTestProfile(t)
+	_, restore := useFakeRunner(t)
+	defer restore()
+
+	waited := false
+	kubeClient = func(_ string) (kubernetes.Interface, error) {
+		waited = true
+		return nil, fmt.Errorf("no cluster in tests")
+	}
+
+	// Leave out the default addons, which require a running cluster
+	Start(profile, map[string]bool{"storage-provisioner": false, "default-storageclass": false}, []string{"ingress-dns"})
+
+	c, err := config.DefaultLoader.LoadConfigFromFile(profile)
+	if err != nil {
+		t.Fatalf("unable to load profile: %v", err)
+	}
+	for _, name := range []string{"ingress", "ingress-dns"} {
+		if !c.Addons[name] {
+			t.Errorf("expected %s to be enabled, got %v", name, c.Addons)
+		}
+	}
+	if !waited {
+		t.Errorf("expected ingress readiness to be awaited before enabling ingress-dns")
+	}
+}
@@ -53,7 +53,7 @@ func kubectlCommand(profile string, files []string, enable bool) (*exec.Cmd, err
 }
 
 func kubernetesVersion(profile string) (string, error) {
-	cc, err := config.Load(profile)
+	cc, err := loadConfig(profile)
 	if err != nil && !config.IsNotExist(err) {
 		return "", err
 	}
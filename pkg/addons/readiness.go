@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/assets"
+)
+
+// prerequisiteTimeout is how long to wait for addons required by other addons to become ready
+const prerequisiteTimeout = 3 * time.Minute
+
+var (
+	// For testing
+	kubeClient = func(profile string) (kubernetes.Interface, error) {
+		return kapi.Client(profile)
+	}
+)
+
+// Wait waits until the named addon, and any addons it requires, pass their readiness checks
+func Wait(name string, profile string, timeout time.Duration) error {
+	order, err := resolveOrder(assets.Addons, []string{name})
+	if err != nil {
+		return err
+	}
+
+	checks := []assets.ReadinessCheck{}
+	for _, n := range order {
+		checks = append(checks, assets.Addons[n].Readiness...)
+	}
+	return waitForChecks(profile, checks, timeout)
+}
+
+// waitForChecks waits until the readiness checks pass within the profile's cluster
+func waitForChecks(profile string, checks []assets.ReadinessCheck, timeout time.Duration) error {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	client, err := kubeClient(profile)
+	if err != nil {
+		return errors.Wrap(err, "kubernetes client")
+	}
+	return waitForReadiness(client, checks, timeout)
+}
+
+// waitForReadiness waits until every readiness check passes, sharing the timeout between them
+func waitForReadiness(client kubernetes.Interface, checks []assets.ReadinessCheck, timeout time.Duration) error {
+	start := time.Now()
+	for _, rc := range checks {
+		remaining := timeout - time.Since(start)
+		if remaining <= 0 {
+			return fmt.Errorf("timed out waiting for %s", describeCheck(rc))
+		}
+
+		var err error
+		if rc.Deployment != "" {
+			err = kapi.WaitForDeploymentToStabilize(client, rc.Namespace, rc.Deployment, remaining)
+		} else {
+			var selector labels.Selector
+			selector, err = labels.Parse(rc.Selector)
+			if err == nil {
+				err = kapi.WaitForPodsWithLabelRunning(client, rc.Namespace, selector, remaining)
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "waiting for %s", describeCheck(rc))
+		}
+	}
+	glog.Infof("duration metric: took %s for %d readiness checks to pass", time.Since(start), len(checks))
+	return nil
+}
+
+// describeCheck returns a human readable description of a readiness check
+func describeCheck(rc assets.ReadinessCheck) string {
+	if rc.Deployment != "" {
+		return fmt.Sprintf("deployment %s/%s", rc.Namespace, rc.Deployment)
+	}
+	return fmt.Sprintf("pods %q in %s", rc.Selector, rc.Namespace)
+}
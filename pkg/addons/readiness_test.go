@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"testing"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/minikube/pkg/minikube/assets"
+)
+
+func pod(name string, app string, phase core.PodPhase) *core.Pod {
+	return &core.Pod{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "kube-system", Labels: map[string]string{"app": app}},
+		Status:     core.PodStatus{Phase: phase},
+	}
+}
+
+func TestWaitForReadiness(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		pod("registry-1", "registry", core.PodRunning),
+		pod("registry-2", "registry", core.PodRunning),
+		pod("dns", "dns", core.PodPending),
+	)
+
+	var tests = []struct {
+		description string
+		checks      []assets.ReadinessCheck
+		err         bool
+	}{
+		{
+			description: "no checks",
+		},
+		{
+			description: "running pods",
+			checks:      []assets.ReadinessCheck{{Namespace: "kube-system", Selector: "app=registry"}},
+		},
+		{
+			description: "pending pod",
+			checks:      []assets.ReadinessCheck{{Namespace: "kube-system", Selector: "app=registry"}, {Namespace: "kube-system", Selector: "app=dns"}},
+			err:         true,
+		},
+		{
+			description: "no matching pods",
+			checks:      []assets.ReadinessCheck{{Namespace: "default", Selector: "app=registry"}},
+			err:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := waitForReadiness(client, test.checks, 2*time.Second)
+			if test.err && err == nil {
+				t.Errorf("expected readiness checks to time out")
+			}
+			if !test.err && err != nil {
+				t.Errorf("waitForReadiness: %v", err)
+			}
+		})
+	}
+}
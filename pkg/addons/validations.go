@@ -19,7 +19,6 @@ package addons
 import (
 	"fmt"
 
-	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/cruntime"
 )
 
@@ -35,7 +34,7 @@ minikube start --container-runtime=containerd --docker-opt containerd=/var/run/c
 
 // IsContainerdRuntime is a validator which returns an error if the current runtime is not containerd
 func IsContainerdRuntime(_, _, profile string) error {
-	config, err := config.Load(profile)
+	config, err := loadConfig(profile)
 	if err != nil {
 		return fmt.Errorf("config.Load: %v", err)
 	}
@@ -73,9 +73,12 @@ func (d *Driver) Create() error {
 		CPUs:          strconv.Itoa(d.NodeConfig.CPU),
 		Memory:        strconv.Itoa(d.NodeConfig.Memory) + "mb",
 		Envs:          d.NodeConfig.Envs,
+		Mounts:        d.NodeConfig.Mounts,
 		ExtraArgs:     []string{"--expose", fmt.Sprintf("%d", d.NodeConfig.APIServerPort)},
 		OCIBinary:     d.NodeConfig.OCIBinary,
 		APIServerPort: d.NodeConfig.APIServerPort,
+		Network:       d.NodeConfig.Network,
+		IP:            d.NodeConfig.IP,
 	}
 
 	// control plane specific options
@@ -92,6 +95,7 @@ func (d *Driver) Create() error {
 			ContainerPort: constants.DockerDaemonPort,
 		},
 	)
+	params.PortMappings = append(params.PortMappings, d.NodeConfig.PortMappings...)
 
 	exists, err := oci.ContainerExists(d.OCIBinary, params.Name)
 	if err != nil {
@@ -122,7 +126,7 @@ func (d *Driver) Create() error {
 	t := time.Now()
 	glog.Infof("Starting extracting preloaded images to volume")
 	// Extract preloaded images to container
-	if err := oci.ExtractTarballToVolume(download.TarballPath(d.NodeConfig.KubernetesVersion), params.Name, BaseImage); err != nil {
+	if err := oci.ExtractTarballToVolume(download.TarballPath(d.NodeConfig.KubernetesVersion, d.NodeConfig.ContainerRuntime), params.Name, BaseImage); err != nil {
 		glog.Infof("Unable to extract preloaded tarball to volume: %v", err)
 	} else {
 		glog.Infof("Took %f seconds to extract preloaded images to volume", time.Since(t).Seconds())
@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+)
+
+// LoadBalancerBackend is an apiserver fronted by the load balancer of an HA cluster
+type LoadBalancerBackend struct {
+	Name    string // name of the node running the apiserver
+	Address string // ip:port of the apiserver
+}
+
+// loadBalancerConfigTmpl is the haproxy configuration balancing TCP connections across apiservers
+var loadBalancerConfigTmpl = template.Must(template.New("haproxy").Parse(`global
+  log stdout format raw local0 info
+
+defaults
+  mode tcp
+  timeout connect 5s
+  timeout client 1h
+  timeout server 1h
+
+frontend control-plane
+  bind *:{{.Port}}
+  default_backend apiservers
+
+backend apiservers
+  option httpchk GET /healthz
+{{- range .Backends}}
+  server {{.Name}} {{.Address}} check check-ssl verify none
+{{- end}}
+`))
+
+// LoadBalancerName returns the name of the container fronting the apiservers of a profile
+func LoadBalancerName(profile string) string {
+	return profile + "-lb"
+}
+
+// loadBalancerConfig returns the haproxy configuration for the given apiservers
+func loadBalancerConfig(port int, backends []LoadBalancerBackend) ([]byte, error) {
+	var b bytes.Buffer
+	opts := struct {
+		Port     int
+		Backends []LoadBalancerBackend
+	}{
+		Port:     port,
+		Backends: backends,
+	}
+	if err := loadBalancerConfigTmpl.Execute(&b, opts); err != nil {
+		return nil, errors.Wrap(err, "haproxy template")
+	}
+	return b.Bytes(), nil
+}
+
+// StartLoadBalancer writes the load balancer configuration into configDir and creates, starts or reloads
+// the load balancer container of a profile so that it fronts the given apiservers, returning its IP.
+// The container is attached to network with a static IP, unless network is empty.
+func StartLoadBalancer(ociBin string, profile string, network string, subnet string, configDir string, port int, backends []LoadBalancerBackend) (string, error) {
+	name := LoadBalancerName(profile)
+	cfg, err := loadBalancerConfig(port, backends)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", errors.Wrapf(err, "mkdir %s", configDir)
+	}
+	if err := ioutil.WriteFile(filepath.Join(configDir, "haproxy.cfg"), cfg, 0644); err != nil {
+		return "", errors.Wrap(err, "write haproxy config")
+	}
+
+	exists, err := oci.ContainerExists(ociBin, name)
+	if err != nil {
+		glog.Warningf("failed to check if %s exists: %v", name, err)
+	}
+
+	if !exists {
+		glog.Infof("creating load balancer %s for %d apiservers", name, len(backends))
+		ip := ""
+		if network != "" {
+			ip, err = LoadBalancerIP(subnet)
+			if err != nil {
+				return "", errors.Wrap(err, "load balancer ip")
+			}
+		}
+		err = oci.CreateContainer(oci.CreateParams{
+			Name:         name,
+			Image:        LoadBalancerImage,
+			ClusterLabel: oci.ProfileLabelKey + "=" + profile,
+			NodeLabel:    oci.NodeLabelKey + "=" + name,
+			Role:         "load-balancer",
+			Mounts: []oci.Mount{{
+				HostPath:      configDir,
+				ContainerPath: "/usr/local/etc/haproxy",
+				Readonly:      true,
+			}},
+			PortMappings: []oci.PortMapping{{
+				ListenAddress: oci.DefaultBindIPV4,
+				ContainerPort: int32(port),
+			}},
+			OCIBinary: ociBin,
+			Network:   network,
+			IP:        ip,
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "create load balancer")
+		}
+	} else {
+		st, err := oci.ContainerStatus(ociBin, name)
+		if err != nil {
+			return "", errors.Wrap(err, "load balancer status")
+		}
+		if st == "running" {
+			glog.Infof("reloading load balancer %s for %d apiservers", name, len(backends))
+			if err := oci.SignalContainer(ociBin, name, "HUP"); err != nil {
+				return "", errors.Wrap(err, "reload load balancer")
+			}
+		} else if err := oci.StartContainer(ociBin, name); err != nil {
+			return "", errors.Wrap(err, "start load balancer")
+		}
+	}
+
+	ip, _, err := oci.ContainerIPs(ociBin, name)
+	if err != nil {
+		return "", errors.Wrap(err, "load balancer ip")
+	}
+	if ip == "" {
+		return "", fmt.Errorf("load balancer %s has no IP", name)
+	}
+	return ip, nil
+}
+
+// StopLoadBalancer stops the load balancer container of a profile, if there is one
+func StopLoadBalancer(ociBin string, profile string) error {
+	name := LoadBalancerName(profile)
+	exists, err := oci.ContainerExists(ociBin, name)
+	if err != nil || !exists {
+		return err
+	}
+	return oci.StopContainer(ociBin, name)
+}
+
+// LoadBalancerHostPort returns the port on the host which forwards to the load balancer of a profile
+func LoadBalancerHostPort(ociBin string, profile string, port int) (int, error) {
+	return oci.HostPortBinding(ociBin, LoadBalancerName(profile), port)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadBalancerConfig(t *testing.T) {
+	cfg, err := loadBalancerConfig(8443, []LoadBalancerBackend{
+		{Name: "m01", Address: "172.17.0.3:8443"},
+		{Name: "m02", Address: "172.17.0.4:8443"},
+	})
+	if err != nil {
+		t.Fatalf("loadBalancerConfig: %v", err)
+	}
+	for _, want := range []string{
+		"bind *:8443",
+		"server m01 172.17.0.3:8443 check check-ssl verify none",
+		"server m02 172.17.0.4:8443 check check-ssl verify none",
+	} {
+		if !strings.Contains(string(cfg), want) {
+			t.Errorf("expected config to contain %q, got:\n%s", want, cfg)
+		}
+	}
+}
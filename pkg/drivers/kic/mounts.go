@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+)
+
+// mountPropagations maps the propagation options of a mount string to their modes
+var mountPropagations = map[string]oci.MountPropagation{
+	"private": oci.MountPropagationNone,
+	"rslave":  oci.MountPropagationHostToContainer,
+	"rshared": oci.MountPropagationBidirectional,
+}
+
+// ParseMount parses a bind mount given as <host directory>:<node directory>[:options], where options is a comma
+// separated list of ro or rw, and of private, rslave or rshared. The host directory is made absolute.
+func ParseMount(s string) (oci.Mount, error) {
+	m := oci.Mount{}
+	spec := s
+
+	// Options never start with a slash, unlike the node directory, and host directories may contain colons on Windows
+	if i := strings.LastIndex(spec, ":"); i >= 0 && !strings.HasPrefix(spec[i+1:], "/") {
+		if err := parseMountOptions(spec[i+1:], &m); err != nil {
+			return m, errors.Wrapf(err, "mount %q", s)
+		}
+		spec = spec[:i]
+	}
+
+	i := strings.LastIndex(spec, ":")
+	if i <= 0 {
+		return m, fmt.Errorf("mount %q must be in the form <host directory>:<node directory>[:options]", s)
+	}
+	m.ContainerPath = spec[i+1:]
+	if !strings.HasPrefix(m.ContainerPath, "/") {
+		return m, fmt.Errorf("node directory %q of mount %q must be an absolute path", m.ContainerPath, s)
+	}
+	host, err := filepath.Abs(spec[:i])
+	if err != nil {
+		return m, errors.Wrapf(err, "host directory of mount %q", s)
+	}
+	m.HostPath = host
+	return m, nil
+}
+
+// parseMountOptions applies the comma separated options of a mount string
+func parseMountOptions(opts string, m *oci.Mount) error {
+	access, propagation := "", ""
+	for _, o := range strings.Split(opts, ",") {
+		switch o {
+		case "ro", "rw":
+			if access != "" {
+				return fmt.Errorf("conflicting options %s and %s", access, o)
+			}
+			access = o
+			m.Readonly = o == "ro"
+		case "private", "rslave", "rshared":
+			if propagation != "" {
+				return fmt.Errorf("conflicting options %s and %s", propagation, o)
+			}
+			propagation = o
+			m.Propagation = mountPropagations[o]
+		default:
+			return fmt.Errorf("unknown option %q, must be ro, rw, private, rslave or rshared", o)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+)
+
+func TestParseMount(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	var tests = []struct {
+		mount string
+		want  oci.Mount
+		err   bool
+	}{
+		{"/home/user:/minikube-host", oci.Mount{HostPath: "/home/user", ContainerPath: "/minikube-host"}, false},
+		{"/home/user:/minikube-host:ro", oci.Mount{HostPath: "/home/user", ContainerPath: "/minikube-host", Readonly: true}, false},
+		{"/home/user:/minikube-host:rw,rshared", oci.Mount{HostPath: "/home/user", ContainerPath: "/minikube-host", Propagation: oci.MountPropagationBidirectional}, false},
+		{"/home/user:/minikube-host:rslave,ro", oci.Mount{HostPath: "/home/user", ContainerPath: "/minikube-host", Readonly: true, Propagation: oci.MountPropagationHostToContainer}, false},
+		{"src:/src", oci.Mount{HostPath: filepath.Join(wd, "src"), ContainerPath: "/src"}, false},
+		{"/home/user", oci.Mount{}, true},
+		{"/home/user:relative", oci.Mount{}, true},
+		{":/minikube-host", oci.Mount{}, true},
+		{"/home/user:/minikube-host:ro,rw", oci.Mount{}, true},
+		{"/home/user:/minikube-host:rshared,rslave", oci.Mount{}, true},
+		{"/home/user:/minikube-host:z", oci.Mount{}, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.mount, func(t *testing.T) {
+			got, err := ParseMount(tc.mount)
+			if (err != nil) != tc.err {
+				t.Fatalf("ParseMount(%q) error = %v, expected error: %v", tc.mount, err, tc.err)
+			}
+			if !tc.err && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParseMount(%q) = %+v, want %+v", tc.mount, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+)
+
+const (
+	// DefaultSubnet is the first subnet tried for the network of a profile, unless --subnet is given
+	DefaultSubnet = "192.168.49.0/24"
+	// subnetStep is how far apart, in the third octet, the subnets tried for the network of a profile are
+	subnetStep = 9
+	// subnetTries is how many subnets are tried before giving up
+	subnetTries = 20
+)
+
+// CreateNetwork creates the network of a profile, or reuses it if it already exists, returning its subnet.
+// If subnet is empty, the first free subnet counting up from DefaultSubnet is used.
+func CreateNetwork(ociBin string, profile string, name string, subnet string) (string, error) {
+	if existing, err := oci.NetworkSubnet(ociBin, name); err == nil {
+		if subnet != "" && existing != subnet {
+			return "", fmt.Errorf("network %s already exists with subnet %s, rather than %s", name, existing, subnet)
+		}
+		glog.Infof("reusing network %s with subnet %s", name, existing)
+		return existing, nil
+	}
+
+	candidates := []string{subnet}
+	if subnet == "" {
+		candidates = subnetCandidates(DefaultSubnet, subnetTries)
+	}
+	for _, c := range candidates {
+		gw, err := nthIP(c, 1)
+		if err != nil {
+			return "", err
+		}
+		err = oci.CreateNetwork(ociBin, profile, name, c, gw)
+		if err == nil {
+			glog.Infof("created network %s with subnet %s", name, c)
+			return c, nil
+		}
+		if subnet != "" || errors.Cause(err) != oci.ErrNetworkSubnetTaken {
+			return "", err
+		}
+		glog.Infof("subnet %s is taken, trying the next one: %v", c, err)
+	}
+	return "", fmt.Errorf("no free subnet for network %s among %v", name, candidates)
+}
+
+// subnetCandidates returns count /24 subnets, spaced by subnetStep in the third octet starting from first
+func subnetCandidates(first string, count int) []string {
+	ip, _, err := net.ParseCIDR(first)
+	if err != nil {
+		return nil
+	}
+	ip = ip.To4()
+	subnets := []string{}
+	for i := 0; i < count && int(ip[2])+i*subnetStep <= 255; i++ {
+		subnets = append(subnets, fmt.Sprintf("%d.%d.%d.0/24", ip[0], ip[1], int(ip[2])+i*subnetStep))
+	}
+	return subnets
+}
+
+// StaticIP returns the lowest address of the subnet, after the gateway, which is neither in use nor reserved for the load balancer
+func StaticIP(subnet string, used []string) (string, error) {
+	taken := map[string]bool{}
+	for _, ip := range used {
+		taken[ip] = true
+	}
+	lb, err := LoadBalancerIP(subnet)
+	if err != nil {
+		return "", err
+	}
+	taken[lb] = true
+
+	for n := 2; ; n++ {
+		ip, err := nthIP(subnet, n)
+		if err != nil {
+			return "", errors.Wrapf(err, "no free address in %s", subnet)
+		}
+		if !taken[ip] {
+			return ip, nil
+		}
+	}
+}
+
+// LoadBalancerIP returns the address reserved for the load balancer of HA clusters, the last usable address of the subnet
+func LoadBalancerIP(subnet string) (string, error) {
+	_, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse subnet %q", subnet)
+	}
+	ones, bits := ipnet.Mask.Size()
+	if ipnet.IP.To4() == nil || bits-ones < 2 {
+		return "", fmt.Errorf("subnet %s must be an IPv4 subnet of at least 4 addresses", subnet)
+	}
+	return nthIP(subnet, 1<<uint(bits-ones)-2)
+}
+
+// nthIP returns the address at offset n within the subnet, excluding its network and broadcast addresses
+func nthIP(subnet string, n int) (string, error) {
+	_, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse subnet %q", subnet)
+	}
+	base := ipnet.IP.To4()
+	if base == nil {
+		return "", fmt.Errorf("subnet %s is not IPv4", subnet)
+	}
+	ones, bits := ipnet.Mask.Size()
+	size := 1 << uint(bits-ones)
+	if n <= 0 || n >= size-1 {
+		return "", fmt.Errorf("offset %d is outside of %s", n, subnet)
+	}
+	v := uint32(base[0])<<24 | uint32(base[1])<<16 | uint32(base[2])<<8 | uint32(base[3])
+	v += uint32(n)
+	return net.IPv4(byte(v>>24), byte(v>>16), byte(v>>8), byte(v)).String(), nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubnetCandidates(t *testing.T) {
+	got := subnetCandidates(DefaultSubnet, 3)
+	want := []string{"192.168.49.0/24", "192.168.58.0/24", "192.168.67.0/24"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("subnetCandidates() = %v, want %v", got, want)
+	}
+	if got := subnetCandidates("192.168.250.0/24", 3); len(got) != 1 {
+		t.Errorf("expected candidates to stop at the end of the address space, got %v", got)
+	}
+}
+
+func TestStaticIP(t *testing.T) {
+	var tests = []struct {
+		name   string
+		subnet string
+		used   []string
+		want   string
+		err    bool
+	}{
+		{"first", "192.168.49.0/24", nil, "192.168.49.2", false},
+		{"next", "192.168.49.0/24", []string{"192.168.49.2", "192.168.49.3"}, "192.168.49.4", false},
+		{"reuses gaps", "192.168.49.0/24", []string{"192.168.49.2", "192.168.49.4"}, "192.168.49.3", false},
+		{"skips load balancer", "10.0.0.0/29", []string{"10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5"}, "", true},
+		{"invalid", "bogus", nil, "", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := StaticIP(tc.subnet, tc.used)
+			if (err != nil) != tc.err {
+				t.Fatalf("StaticIP() error = %v, expected error: %v", err, tc.err)
+			}
+			if got != tc.want {
+				t.Errorf("StaticIP() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerIP(t *testing.T) {
+	var tests = []struct {
+		subnet string
+		want   string
+		err    bool
+	}{
+		{"192.168.49.0/24", "192.168.49.254", false},
+		{"10.0.0.0/29", "10.0.0.6", false},
+		{"10.0.0.0/31", "", true},
+		{"fd00::/64", "", true},
+	}
+	for _, tc := range tests {
+		got, err := LoadBalancerIP(tc.subnet)
+		if (err != nil) != tc.err {
+			t.Fatalf("LoadBalancerIP(%q) error = %v, expected error: %v", tc.subnet, err, tc.err)
+		}
+		if got != tc.want {
+			t.Errorf("LoadBalancerIP(%q) = %q, want %q", tc.subnet, got, tc.want)
+		}
+	}
+}
@@ -20,30 +20,145 @@ import (
 	"encoding/json"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/blang/semver"
 	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/constants"
 )
 
 // SysInfo Info represents common system Information between docker and podman that minikube cares
 type SysInfo struct {
-	CPUs        int   // CPUs is Number of CPUs
-	TotalMemory int64 // TotalMemory Total available ram
+	CPUs          int    // CPUs is Number of CPUs
+	TotalMemory   int64  // TotalMemory Total available ram
+	Rootless      bool   // Rootless is whether the daemon runs as an unprivileged user
+	CgroupVersion int    // CgroupVersion is the cgroup hierarchy of the host, 1 or 2 (unified)
+	CgroupDriver  string // CgroupDriver is the manager of container cgroups, such as systemd or cgroupfs
+	CPULimit      bool   // CPULimit is whether the daemon is able to limit the cpus of a container
+	MemoryLimit   bool   // MemoryLimit is whether the daemon is able to limit the memory of a container
 }
 
-// DaemonInfo returns common docker/podman daemon system info that minikube cares about
+// ErrRootlessCgroupV1 is returned for rootless daemons on a cgroup v1 host, which cannot delegate cgroups to kic nodes
+var ErrRootlessCgroupV1 = errors.New("rootless mode requires cgroup v2")
+
+// ErrRootlessCgroupDriver is returned for rootless daemons which do not manage cgroups with systemd, the only way for an unprivileged user to be delegated cgroups
+var ErrRootlessCgroupDriver = errors.New("rootless mode requires the systemd cgroup driver")
+
+// ErrRootlessKubernetesVersion is returned for rootless daemons when Kubernetes is too old for its kubelet to run in a user namespace
+var ErrRootlessKubernetesVersion = errors.New("rootless mode requires Kubernetes " + constants.RootlessKubernetesVersion + " or newer")
+
+var (
+	// cachedInfo is the info of each daemon, which is asked for by the driver status, start validation and node creation
+	cachedInfo   = map[string]SysInfo{}
+	cachedInfoMu sync.Mutex
+)
+
+// DaemonInfo returns common docker/podman daemon system info that minikube cares about.
+// The info is retrieved once per process, as the daemon is slow to answer.
 func DaemonInfo(ociBin string) (SysInfo, error) {
-	var info SysInfo
+	cachedInfoMu.Lock()
+	defer cachedInfoMu.Unlock()
+	if si, ok := cachedInfo[ociBin]; ok {
+		return si, nil
+	}
+	si, err := daemonInfo(ociBin)
+	if err == nil {
+		cachedInfo[ociBin] = si
+	}
+	return si, err
+}
+
+// daemonInfo asks the daemon for its system info
+func daemonInfo(ociBin string) (SysInfo, error) {
 	if ociBin == Podman {
 		p, err := podmanSystemInfo()
-		info.CPUs = p.Host.Cpus
-		info.TotalMemory = p.Host.MemTotal
-		return info, err
+		return podmanInfo(p), err
 	}
 	d, err := dockerSystemInfo()
-	info.CPUs = d.NCPU
-	info.TotalMemory = d.MemTotal
-	return info, err
+	return dockerInfo(d), err
+}
+
+// CheckRootless returns an error if the daemon is rootless and unable to run kic nodes with the given Kubernetes version.
+// Nodes run systemd and the kubelet, which need a cgroup v2 subtree delegated by systemd when they are not root,
+// and a kubelet which knows it runs in a user namespace.
+func CheckRootless(si SysInfo, k8sVersion string) error {
+	if !si.Rootless {
+		return nil
+	}
+	if si.CgroupVersion != 2 {
+		return ErrRootlessCgroupV1
+	}
+	if si.CgroupDriver != "systemd" {
+		return ErrRootlessCgroupDriver
+	}
+	if !RootlessKubernetesSupported(k8sVersion) {
+		return ErrRootlessKubernetesVersion
+	}
+	return nil
+}
+
+// RootlessKubernetesSupported returns whether the kubelet of a Kubernetes version is able to run in a rootless node
+func RootlessKubernetesSupported(k8sVersion string) bool {
+	v, err := semver.Make(strings.TrimPrefix(k8sVersion, "v"))
+	if err != nil {
+		return false
+	}
+	return v.GTE(semver.MustParse(strings.TrimPrefix(constants.RootlessKubernetesVersion, "v")))
+}
+
+// dockerInfo converts the docker system info to the common info
+func dockerInfo(d dockerSysInfo) SysInfo {
+	info := SysInfo{
+		CPUs:          d.NCPU,
+		TotalMemory:   d.MemTotal,
+		CgroupVersion: cgroupVersion(d.CgroupVersion),
+		CgroupDriver:  d.CgroupDriver,
+		CPULimit:      d.CPUCfsQuota,
+		MemoryLimit:   d.MemoryLimit,
+	}
+	for _, o := range d.SecurityOptions {
+		if strings.Contains(o, "name=rootless") {
+			info.Rootless = true
+		}
+	}
+	return info
+}
+
+// podmanInfo converts the podman system info to the common info
+func podmanInfo(p podmanSysInfo) SysInfo {
+	info := SysInfo{
+		CPUs:          p.Host.Cpus,
+		TotalMemory:   p.Host.MemTotal,
+		Rootless:      p.Host.Rootless,
+		CgroupVersion: cgroupVersion(p.Host.CgroupVersion),
+		CgroupDriver:  p.Host.CgroupManager,
+	}
+	if !info.Rootless {
+		// as root, podman manages the cgroups of containers itself
+		info.CPULimit = true
+		info.MemoryLimit = true
+		return info
+	}
+	// an unprivileged user can only set limits with the controllers systemd delegated to them,
+	// which older versions of podman do not report
+	for _, c := range p.Host.CgroupControllers {
+		switch c {
+		case "cpu":
+			info.CPULimit = true
+		case "memory":
+			info.MemoryLimit = true
+		}
+	}
+	return info
+}
+
+// cgroupVersion parses the cgroup version reported by docker ("2") or podman ("v2"), which is 1 if not reported by older versions
+func cgroupVersion(v string) int {
+	if strings.TrimPrefix(v, "v") == "2" {
+		return 2
+	}
+	return 1
 }
 
 // dockerSysInfo represents the output of docker system info --format '{{json .}}'
@@ -82,6 +197,7 @@ type dockerSysInfo struct {
 	SystemTime         time.Time `json:"SystemTime"`
 	LoggingDriver      string    `json:"LoggingDriver"`
 	CgroupDriver       string    `json:"CgroupDriver"`
+	CgroupVersion      string    `json:"CgroupVersion"`
 	NEventsListener    int       `json:"NEventsListener"`
 	KernelVersion      string    `json:"KernelVersion"`
 	OperatingSystem    string    `json:"OperatingSystem"`
@@ -157,9 +273,11 @@ type dockerSysInfo struct {
 // podmanSysInfo represents the output of podman system info --format '{{json .}}'
 type podmanSysInfo struct {
 	Host struct {
-		BuildahVersion string `json:"BuildahVersion"`
-		CgroupVersion  string `json:"CgroupVersion"`
-		Conmon         struct {
+		BuildahVersion    string   `json:"BuildahVersion"`
+		CgroupManager     string   `json:"CgroupManager"`
+		CgroupVersion     string   `json:"CgroupVersion"`
+		CgroupControllers []string `json:"CgroupControllers"`
+		Conmon            struct {
 			Package string `json:"package"`
 			Path    string `json:"path"`
 			Version string `json:"version"`
@@ -234,7 +352,7 @@ func dockerSystemInfo() (dockerSysInfo, error) {
 // podmanSysInfo returns podman system info --format '{{json .}}'
 func podmanSystemInfo() (podmanSysInfo, error) {
 	var ps podmanSysInfo
-	cmd := exec.Command(Podman, "system", "info", "--format", "'{{json .}}'")
+	cmd := exec.Command(Podman, "system", "info", "--format", "{{json .}}")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return ps, errors.Wrap(err, "get podman system info")
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDockerInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want SysInfo
+	}{
+		{
+			name: "rootful cgroup v1",
+			json: `{"NCPU":4,"MemTotal":8000000000,"CgroupDriver":"cgroupfs","CpuCfsQuota":true,"MemoryLimit":true,"SecurityOptions":["name=apparmor","name=seccomp,profile=default"]}`,
+			want: SysInfo{CPUs: 4, TotalMemory: 8000000000, CgroupVersion: 1, CgroupDriver: "cgroupfs", CPULimit: true, MemoryLimit: true},
+		},
+		{
+			name: "rootless cgroup v2",
+			json: `{"NCPU":8,"MemTotal":16000000000,"CgroupDriver":"systemd","CgroupVersion":"2","CpuCfsQuota":false,"MemoryLimit":true,"SecurityOptions":["name=seccomp,profile=default","name=rootless","name=cgroupns"]}`,
+			want: SysInfo{CPUs: 8, TotalMemory: 16000000000, Rootless: true, CgroupVersion: 2, CgroupDriver: "systemd", MemoryLimit: true},
+		},
+		{
+			name: "rootless cgroup v1",
+			json: `{"NCPU":2,"MemTotal":4000000000,"CgroupDriver":"none","CgroupVersion":"1","SecurityOptions":["name=rootless"]}`,
+			want: SysInfo{CPUs: 2, TotalMemory: 4000000000, Rootless: true, CgroupVersion: 1, CgroupDriver: "none"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var d dockerSysInfo
+			if err := json.Unmarshal([]byte(tc.json), &d); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got := dockerInfo(d); got != tc.want {
+				t.Errorf("dockerInfo() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPodmanInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want SysInfo
+	}{
+		{
+			name: "root",
+			json: `{"host":{"cpus":4,"memTotal":8000000000,"rootless":false,"cgroupVersion":"v1","cgroupManager":"systemd"}}`,
+			want: SysInfo{CPUs: 4, TotalMemory: 8000000000, CgroupVersion: 1, CgroupDriver: "systemd", CPULimit: true, MemoryLimit: true},
+		},
+		{
+			name: "rootless with delegated controllers",
+			json: `{"host":{"cpus":4,"memTotal":8000000000,"rootless":true,"cgroupVersion":"v2","cgroupManager":"systemd","cgroupControllers":["cpu","io","memory","pids"]}}`,
+			want: SysInfo{CPUs: 4, TotalMemory: 8000000000, Rootless: true, CgroupVersion: 2, CgroupDriver: "systemd", CPULimit: true, MemoryLimit: true},
+		},
+		{
+			name: "rootless with default controllers",
+			json: `{"host":{"cpus":4,"memTotal":8000000000,"rootless":true,"cgroupVersion":"v2","cgroupManager":"systemd","cgroupControllers":["memory","pids"]}}`,
+			want: SysInfo{CPUs: 4, TotalMemory: 8000000000, Rootless: true, CgroupVersion: 2, CgroupDriver: "systemd", MemoryLimit: true},
+		},
+		{
+			name: "rootless without reported controllers",
+			json: `{"host":{"cpus":4,"memTotal":8000000000,"rootless":true,"cgroupVersion":"v2","cgroupManager":"systemd"}}`,
+			want: SysInfo{CPUs: 4, TotalMemory: 8000000000, Rootless: true, CgroupVersion: 2, CgroupDriver: "systemd"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var p podmanSysInfo
+			if err := json.Unmarshal([]byte(tc.json), &p); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got := podmanInfo(p); got != tc.want {
+				t.Errorf("podmanInfo() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckRootless(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    SysInfo
+		version string
+		want    error
+	}{
+		{"root", SysInfo{CgroupVersion: 1, CgroupDriver: "cgroupfs"}, "v1.17.3", nil},
+		{"rootless", SysInfo{Rootless: true, CgroupVersion: 2, CgroupDriver: "systemd"}, "v1.22.0", nil},
+		{"rootless old kubernetes", SysInfo{Rootless: true, CgroupVersion: 2, CgroupDriver: "systemd"}, "v1.17.3", ErrRootlessKubernetesVersion},
+		{"rootless cgroup v1", SysInfo{Rootless: true, CgroupVersion: 1, CgroupDriver: "none"}, "v1.22.0", ErrRootlessCgroupV1},
+		{"rootless cgroupfs", SysInfo{Rootless: true, CgroupVersion: 2, CgroupDriver: "cgroupfs"}, "v1.22.0", ErrRootlessCgroupDriver},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CheckRootless(tc.info, tc.version); got != tc.want {
+				t.Errorf("CheckRootless(%+v, %s) = %v, want %v", tc.info, tc.version, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResourceArgs(t *testing.T) {
+	p := CreateParams{Name: "minikube", CPUs: "2", Memory: "2200mb", OCIBinary: Podman}
+	tests := []struct {
+		name string
+		info SysInfo
+		want []string
+	}{
+		{"both", SysInfo{CPULimit: true, MemoryLimit: true}, []string{"--cpus=2", "--memory=2200mb"}},
+		{"memory only", SysInfo{Rootless: true, MemoryLimit: true}, []string{"--memory=2200mb"}},
+		{"none", SysInfo{Rootless: true}, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resourceArgs(p, tc.info)
+			if len(got) != len(tc.want) {
+				t.Fatalf("resourceArgs() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("resourceArgs() = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDaemonInfoCached(t *testing.T) {
+	want := SysInfo{CPUs: 4, Rootless: true, CgroupVersion: 2, CgroupDriver: "systemd"}
+	cachedInfo["fake-oci"] = want
+	defer delete(cachedInfo, "fake-oci")
+
+	// there is no fake-oci binary to ask, so only the cached info may be returned
+	got, err := DaemonInfo("fake-oci")
+	if err != nil {
+		t.Fatalf("DaemonInfo: %v", err)
+	}
+	if got != want {
+		t.Errorf("DaemonInfo = %+v, want %+v", got, want)
+	}
+}
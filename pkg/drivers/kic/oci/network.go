@@ -17,6 +17,9 @@ limitations under the License.
 package oci
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os/exec"
@@ -35,7 +38,7 @@ func RoutableHostIPFromInside(ociBin string, containerName string) (net.IP, erro
 		return nil, fmt.Errorf("RoutableHostIPFromInside is currently only implemented for docker https://github.com/containers/libpod/issues/5205")
 	}
 	if runtime.GOOS == "linux" {
-		return dockerGatewayIP()
+		return dockerGatewayIP(containerName)
 	}
 	// for windows and mac, the gateway ip is not routable so we use dns trick.
 	return digDNS(ociBin, containerName, "host.docker.internal")
@@ -55,28 +58,134 @@ func digDNS(ociBin, containerName, dns string) (net.IP, error) {
 	return ip, nil
 }
 
-// dockerGatewayIP gets the default gateway ip for the docker bridge on the user's host machine
-// gets the ip from user's host docker
-func dockerGatewayIP() (net.IP, error) {
-	cmd := exec.Command(Docker, "network", "ls", "--filter", "name=bridge", "--format", "{{.ID}}")
+// dockerGatewayIP gets the default gateway ip of the network the container is attached to on the user's host machine,
+// which is the docker bridge for containers created before minikube used a network per profile
+func dockerGatewayIP(containerName string) (net.IP, error) {
+	cmd := exec.Command(Docker, "inspect", "--format", "{{range .NetworkSettings.Networks}}{{.Gateway}}{{end}}", containerName)
 	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspect gateway of %s. output: %s", containerName, string(out))
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(out)))
+	if ip == nil {
+		return nil, fmt.Errorf("no gateway for %s: %q", containerName, string(out))
+	}
+	glog.Infof("got host ip for mount in container by inspect docker network: %s", ip.String())
+	return ip, nil
+}
+
+// ErrNetworkSubnetTaken is returned when creating a network whose subnet overlaps with another network
+var ErrNetworkSubnetTaken = errors.New("subnet is taken")
+
+// CreateNetwork creates a bridge network with the given subnet and gateway, labelled with the profile it belongs to.
+// podman does not support labels on networks, so its networks are removed by name.
+func CreateNetwork(ociBin string, profile string, name string, subnet string, gateway string) error {
+	args := []string{"network", "create", "--driver=bridge", "--subnet=" + subnet, "--gateway=" + gateway}
+	if ociBin == Docker {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", CreatedByLabelKey, "true"), "--label", fmt.Sprintf("%s=%s", ProfileLabelKey, profile))
+	}
+	args = append(args, name)
+	out, err := exec.Command(ociBin, args...).CombinedOutput()
+	if err != nil {
+		o := string(out)
+		if strings.Contains(o, "overlaps") || strings.Contains(o, "is being used") || strings.Contains(o, "already used") {
+			return errors.Wrapf(ErrNetworkSubnetTaken, "%s: %s", subnet, strings.TrimSpace(o))
+		}
+		return errors.Wrapf(err, "create network %s: output %s", name, o)
+	}
+	return nil
+}
 
+// NetworkSubnet returns the subnet of an existing network
+func NetworkSubnet(ociBin string, name string) (string, error) {
+	if ociBin == Podman {
+		return podmanNetworkSubnet(name)
+	}
+	lines, err := inspect(Docker, name, "{{range .IPAM.Config}}{{.Subnet}} {{end}}")
 	if err != nil {
-		return nil, errors.Wrapf(err, "get network bridge. output: %s", string(out))
+		return "", errors.Wrapf(err, "inspect network %s: %s", name, strings.Join(lines, "\n"))
+	}
+	fields := strings.Fields(strings.Join(lines, " "))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("network %s has no subnet", name)
+	}
+	return fields[0], nil
+}
+
+// podmanNetworkSubnet returns the subnet from the CNI configuration podman reports for a network
+func podmanNetworkSubnet(name string) (string, error) {
+	out, err := exec.Command(Podman, "network", "inspect", name).Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "inspect network %s", name)
+	}
+	var confs []struct {
+		Plugins []struct {
+			IPAM struct {
+				Ranges [][]struct {
+					Subnet string `json:"subnet"`
+				} `json:"ranges"`
+			} `json:"ipam"`
+		} `json:"plugins"`
 	}
+	if err := json.Unmarshal(out, &confs); err != nil {
+		return "", errors.Wrapf(err, "parse network %s", name)
+	}
+	for _, c := range confs {
+		for _, p := range c.Plugins {
+			for _, r := range p.IPAM.Ranges {
+				for _, s := range r {
+					if s.Subnet != "" {
+						return s.Subnet, nil
+					}
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("network %s has no subnet", name)
+}
 
-	bridgeID := strings.TrimSpace(string(out))
-	cmd = exec.Command(Docker, "inspect",
-		"--format", "{{(index .IPAM.Config 0).Gateway}}", bridgeID)
-	out, err = cmd.CombinedOutput()
+// RemoveNetwork removes a network by name, which fails while containers are attached to it
+func RemoveNetwork(ociBin string, name string) error {
+	if out, err := exec.Command(ociBin, "network", "rm", name).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "remove network %s: output %s", name, out)
+	}
+	return nil
+}
 
+// DeleteAllNetworksByLabel deletes all networks that have a specific label
+// if there is no network to delete it will return nil
+func DeleteAllNetworksByLabel(ociBin string, label string) []error {
+	var deleteErrs []error
+	glog.Infof("trying to delete all %s networks with label %s", ociBin, label)
+
+	ns, err := allNetworksByLabel(ociBin, label)
 	if err != nil {
-		return nil, errors.Wrapf(err, "inspect IP gatway for bridge network: %q. output: %s", string(out), bridgeID)
+		return []error{fmt.Errorf("listing networks by label %q: %v", label, err)}
 	}
 
-	ip := net.ParseIP(strings.TrimSpace(string(out)))
-	glog.Infof("got host ip for mount in container by inspect docker network: %s", ip.String())
-	return ip, nil
+	for _, n := range ns {
+		if err := RemoveNetwork(ociBin, n); err != nil {
+			deleteErrs = append(deleteErrs, err)
+		}
+	}
+	return deleteErrs
+}
+
+// allNetworksByLabel returns the names of all networks with a specific label
+// will not return error if there is no network found.
+func allNetworksByLabel(ociBin string, label string) ([]string, error) {
+	cmd := exec.Command(ociBin, "network", "ls", "--filter", "label="+label, "--format", "{{.Name}}")
+	stdout, err := cmd.Output()
+	s := bufio.NewScanner(bytes.NewReader(stdout))
+	var nets []string
+	for s.Scan() {
+		n := strings.TrimSpace(s.Text())
+		if n != "" {
+			nets = append(nets, n)
+		}
+	}
+	return nets, err
 }
 
 // HostPortBinding will return port mapping for a container using cli.
@@ -124,6 +233,15 @@ func ContainerIPs(ociBinary string, name string) (string, string, error) {
 
 // podmanConttainerIP returns ipv4, ipv6 of container or error
 func podmanConttainerIP(name string) (string, string, error) {
+	// the address of a container on a user-defined network is only reported for that network,
+	// which versions of podman before 2.0 do not report at all
+	networks, err := exec.Command(Podman, "inspect",
+		"-f", "{{range .NetworkSettings.Networks}}{{.IPAddress}},{{end}}",
+		name).CombinedOutput()
+	if err != nil {
+		glog.Infof("podman inspect networks of %s: %v: %s", name, err, networks)
+		networks = nil
+	}
 	cmd := exec.Command(Podman, "inspect",
 		"-f", "{{.NetworkSettings.IPAddress}}",
 		name)
@@ -131,11 +249,21 @@ func podmanConttainerIP(name string) (string, string, error) {
 	if err != nil {
 		return "", "", errors.Wrapf(err, "podman inspect ip %s", name)
 	}
-	output := strings.TrimSpace(string(out))
-	if err == nil && output == "" { // podman returns empty for 127.0.0.1
-		return DefaultBindIPV4, "", nil
+	return podmanIP(string(networks), string(out)), "", nil
+}
+
+// podmanIP returns the address of a container from its addresses on networks, or else the address on the default network
+func podmanIP(networks string, defaultNetwork string) string {
+	for _, ip := range strings.Split(strings.TrimSpace(networks), ",") {
+		if ip != "" {
+			return ip
+		}
+	}
+	if ip := strings.TrimSpace(defaultNetwork); ip != "" {
+		return ip
 	}
-	return output, "", nil
+	// podman returns empty for 127.0.0.1
+	return DefaultBindIPV4
 }
 
 // dockerContainerIP returns ipv4, ipv6 of container or error
@@ -0,0 +1,40 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import "testing"
+
+func TestPodmanIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		networks       string
+		defaultNetwork string
+		want           string
+	}{
+		{"user-defined network", "192.168.49.3,\n", "\n", "192.168.49.3"},
+		{"default network", "", "10.88.0.5\n", "10.88.0.5"},
+		{"no networks reported", ",\n", "10.88.0.5\n", "10.88.0.5"},
+		{"rootless", "", "\n", DefaultBindIPV4},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := podmanIP(tc.networks, tc.defaultNetwork); got != tc.want {
+				t.Errorf("podmanIP(%q, %q) = %q, want %q", tc.networks, tc.defaultNetwork, got, tc.want)
+			}
+		})
+	}
+}
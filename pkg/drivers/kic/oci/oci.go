@@ -85,6 +85,13 @@ func DeleteContainer(ociBin string, name string) error {
 
 // CreateContainerNode creates a new container node
 func CreateContainerNode(p CreateParams) error {
+	info, err := DaemonInfo(p.OCIBinary)
+	if err != nil {
+		glog.Warningf("unable to get %s info, assuming it runs as root: %v", p.OCIBinary, err)
+		// resource limits were only ever set for docker
+		info = SysInfo{CgroupVersion: 1, CPULimit: p.OCIBinary == Docker, MemoryLimit: p.OCIBinary == Docker}
+	}
+
 	runArgs := []string{
 		"-d", // run the container detached
 		"-t", // allocate a tty for entrypoint logs
@@ -126,25 +133,26 @@ func CreateContainerNode(p CreateParams) error {
 		}
 		glog.Infof("Successfully created a docker volume %s", p.Name)
 		runArgs = append(runArgs, "--volume", fmt.Sprintf("%s:/var", p.Name))
-		// setting resource limit in privileged mode is only supported by docker
-		// podman error: "Error: invalid configuration, cannot set resources with rootless containers not using cgroups v2 unified mode"
-		runArgs = append(runArgs, fmt.Sprintf("--cpus=%s", p.CPUs), fmt.Sprintf("--memory=%s", p.Memory))
 	}
+	runArgs = append(runArgs, resourceArgs(p, info)...)
 
 	for key, val := range p.Envs {
 		runArgs = append(runArgs, "-e", fmt.Sprintf("%s=%s", key, val))
 	}
 
+	runArgs = append(runArgs, networkArgs(p)...)
+
 	// adds node specific args
 	runArgs = append(runArgs, p.ExtraArgs...)
 
-	if enabled := isUsernsRemapEnabled(p.OCIBinary); enabled {
+	// a rootless daemon already runs in a user namespace of its own, which "host" would refer to
+	if enabled := !info.Rootless && isUsernsRemapEnabled(p.OCIBinary); enabled {
 		// We need this argument in order to make this command work
 		// in systems that have userns-remap enabled on the docker daemon
 		runArgs = append(runArgs, "--userns=host")
 	}
 
-	if err := createContainer(p.OCIBinary, p.Image, withRunArgs(runArgs...), withMounts(p.Mounts), withPortMappings(p.PortMappings)); err != nil {
+	if err := createContainer(p.OCIBinary, p.Image, withRunArgs(runArgs...), withMounts(p.Mounts), withPortMappings(p.PortMappings), withRootless(info.Rootless)); err != nil {
 		return errors.Wrap(err, "create container")
 	}
 
@@ -167,6 +175,79 @@ func CreateContainerNode(p CreateParams) error {
 	return nil
 }
 
+// resourceArgs limits the cpus and memory of a node, as far as the daemon is able to.
+// A rootless daemon can only limit the resources whose cgroup controllers systemd delegated to the user.
+func resourceArgs(p CreateParams, si SysInfo) []string {
+	var args []string
+	if si.CPULimit {
+		args = append(args, fmt.Sprintf("--cpus=%s", p.CPUs))
+	} else {
+		glog.Warningf("%s is unable to limit cpus, %s will not be limited to %s cpus", p.OCIBinary, p.Name, p.CPUs)
+	}
+	if si.MemoryLimit {
+		args = append(args, fmt.Sprintf("--memory=%s", p.Memory))
+	} else {
+		glog.Warningf("%s is unable to limit memory, %s will not be limited to %s", p.OCIBinary, p.Name, p.Memory)
+	}
+	return args
+}
+
+// CreateContainer creates a detached container which, unlike a node, is neither privileged nor given a volume
+func CreateContainer(p CreateParams) error {
+	runArgs := []string{
+		"-d", // run the container detached
+		"--hostname", p.Name,
+		"--name", p.Name,
+		"--label", fmt.Sprintf("%s=%s", CreatedByLabelKey, "true"),
+		"--label", p.ClusterLabel,
+		"--label", fmt.Sprintf("%s=%s", nodeRoleLabelKey, p.Role),
+		"--label", p.NodeLabel,
+	}
+	runArgs = append(runArgs, networkArgs(p)...)
+	runArgs = append(runArgs, p.ExtraArgs...)
+
+	if err := createContainer(p.OCIBinary, p.Image, withRunArgs(runArgs...), withMounts(p.Mounts), withPortMappings(p.PortMappings)); err != nil {
+		return errors.Wrap(err, "create container")
+	}
+	return nil
+}
+
+// networkArgs attaches the container to its network, with its static IP
+func networkArgs(p CreateParams) []string {
+	if p.Network == "" {
+		return nil
+	}
+	args := []string{"--network", p.Network}
+	if p.IP != "" {
+		args = append(args, "--ip", p.IP)
+	}
+	return args
+}
+
+// StartContainer starts an existing stopped container
+func StartContainer(ociBin string, name string) error {
+	if out, err := exec.Command(ociBin, "start", name).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "start container %s: output %s", name, out)
+	}
+	return nil
+}
+
+// StopContainer stops a running container
+func StopContainer(ociBin string, name string) error {
+	if out, err := exec.Command(ociBin, "stop", name).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "stop container %s: output %s", name, out)
+	}
+	return nil
+}
+
+// SignalContainer sends a signal, such as HUP, to the main process of a container
+func SignalContainer(ociBin string, name string, signal string) error {
+	if out, err := exec.Command(ociBin, "kill", "--signal", signal, name).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "signal %s to container %s: output %s", signal, name, out)
+	}
+	return nil
+}
+
 // CreateContainer creates a container with "docker/podman run"
 func createContainer(ociBinary string, image string, opts ...createOpt) error {
 	o := &createOpts{}
@@ -185,7 +266,8 @@ func createContainer(ociBinary string, image string, opts ...createOpt) error {
 	args := []string{"run"}
 
 	// to run nested container from privileged container in podman https://bugzilla.redhat.com/show_bug.cgi?id=1687713
-	if ociBinary == Podman {
+	// a rootless podman has to leave cgroups to systemd, which delegates them to the user
+	if ociBinary == Podman && !o.Rootless {
 		args = append(args, "--cgroup-manager", "cgroupfs")
 	}
 
@@ -376,7 +458,14 @@ func generatePortMappings(portMappings ...PortMapping) []string {
 	for _, pm := range portMappings {
 		// let docker pick a host port by leaving it as ::
 		// example --publish=127.0.0.17::8443 will get a random host port for 8443
-		publish := fmt.Sprintf("--publish=%s::%d", pm.ListenAddress, pm.ContainerPort)
+		hostPort := ""
+		if pm.HostPort != 0 {
+			hostPort = fmt.Sprint(pm.HostPort)
+		}
+		publish := fmt.Sprintf("--publish=%s:%s:%d", pm.ListenAddress, hostPort, pm.ContainerPort)
+		if pm.Protocol != "" {
+			publish += "/" + pm.Protocol
+		}
 		result = append(result, publish)
 	}
 	return result
@@ -407,6 +496,14 @@ func withPortMappings(portMappings []PortMapping) createOpt {
 	}
 }
 
+// withRootless sets whether the container is created by a rootless daemon
+func withRootless(rootless bool) createOpt {
+	return func(r *createOpts) *createOpts {
+		r.Rootless = rootless
+		return r
+	}
+}
+
 // listContainersByLabel returns all the container names with a specified label
 func listContainersByLabel(ociBinary string, label string) ([]string, error) {
 
@@ -48,6 +48,8 @@ type CreateParams struct {
 	Envs          map[string]string // environment variables to pass to the container
 	ExtraArgs     []string          // a list of any extra option to pass to oci binary during creation time, for example --expose 8080...
 	OCIBinary     string            // docker or podman
+	Network       string            // network to attach the container to, the default bridge if empty
+	IP            string            // static IP of the container within Network
 }
 
 // createOpt is an option for Create
@@ -59,6 +61,7 @@ type createOpts struct {
 	ContainerArgs []string
 	Mounts        []Mount
 	PortMappings  []PortMapping
+	Rootless      bool
 }
 
 /*
@@ -99,12 +102,16 @@ type Mount struct {
 //  containerPort: 80
 //  hostPort: 8000
 //  listenAddress: 127.0.0.1
+//  protocol: udp
+// A zero hostPort lets docker pick a free port on the host.
 type PortMapping struct {
 	// Port within the container.
 	ContainerPort int32 `protobuf:"varint,1,opt,name=container_port,json=containerPort,proto3" json:"containerPort,omitempty"`
 	// Port on the host.
 	HostPort      int32  `protobuf:"varint,2,opt,name=host_path,json=hostPort,proto3" json:"hostPort,omitempty"`
 	ListenAddress string `protobuf:"bytes,3,opt,name=listenAddress,json=hostPort,proto3" json:"listenAddress,omitempty"`
+	// Protocol of the port, tcp if empty.
+	Protocol string `json:"protocol,omitempty"`
 }
 
 // MountPropagation represents an "enum" for mount propagation options,
@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+)
+
+// ParsePorts parses ports to publish, given as [hostIP:]hostPort:containerPort[/proto], rejecting any published twice.
+// Ports are published on oci.DefaultBindIPV4 unless a host IP is given.
+func ParsePorts(specs []string) ([]oci.PortMapping, error) {
+	pms := []oci.PortMapping{}
+	seen := map[string]string{}
+	for _, spec := range specs {
+		pm, err := parsePort(spec)
+		if err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s:%d/%s", pm.ListenAddress, pm.HostPort, pm.Protocol)
+		if other, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%q and %q publish the same host port", other, spec)
+		}
+		seen[key] = spec
+		pms = append(pms, pm)
+	}
+	return pms, nil
+}
+
+// parsePort parses a single [hostIP:]hostPort:containerPort[/proto]
+func parsePort(spec string) (oci.PortMapping, error) {
+	pm := oci.PortMapping{ListenAddress: oci.DefaultBindIPV4, Protocol: "tcp"}
+	ports := spec
+	if i := strings.LastIndex(spec, "/"); i >= 0 {
+		pm.Protocol = strings.ToLower(spec[i+1:])
+		ports = spec[:i]
+	}
+	if pm.Protocol != "tcp" && pm.Protocol != "udp" {
+		return pm, fmt.Errorf("invalid protocol in %q, must be tcp or udp", spec)
+	}
+
+	// The host IP may be an IPv6 address, which contains colons of its own
+	i := strings.LastIndex(ports, ":")
+	if i < 0 {
+		return pm, fmt.Errorf("invalid port %q, must be [hostIP:]hostPort:containerPort[/proto]", spec)
+	}
+	cport := ports[i+1:]
+	host := ports[:i]
+	hport := host
+	if j := strings.LastIndex(host, ":"); j >= 0 {
+		ip := strings.Trim(host[:j], "[]")
+		if net.ParseIP(ip) == nil {
+			return pm, fmt.Errorf("invalid host IP %q in %q", ip, spec)
+		}
+		pm.ListenAddress = ip
+		hport = host[j+1:]
+	}
+
+	hp, err := parsePortNumber(hport)
+	if err != nil {
+		return pm, errors.Wrapf(err, "host port in %q", spec)
+	}
+	cp, err := parsePortNumber(cport)
+	if err != nil {
+		return pm, errors.Wrapf(err, "container port in %q", spec)
+	}
+	pm.HostPort = hp
+	pm.ContainerPort = cp
+	return pm, nil
+}
+
+// parsePortNumber parses a port between 1 and 65535
+func parsePortNumber(s string) (int32, error) {
+	p, err := strconv.Atoi(s)
+	if err != nil || p < 1 || p > 65535 {
+		return 0, fmt.Errorf("invalid port %q", s)
+	}
+	return int32(p), nil
+}
+
+// CheckPortsAvailable returns an error if any of the host ports is already in use
+func CheckPortsAvailable(pms []oci.PortMapping) error {
+	for _, pm := range pms {
+		addr := net.JoinHostPort(pm.ListenAddress, strconv.Itoa(int(pm.HostPort)))
+		if pm.Protocol == "udp" {
+			c, err := net.ListenPacket("udp", addr)
+			if err != nil {
+				return errors.Wrapf(err, "host port %s/udp is not available", addr)
+			}
+			c.Close()
+			continue
+		}
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return errors.Wrapf(err, "host port %s/tcp is not available", addr)
+		}
+		l.Close()
+	}
+	return nil
+}
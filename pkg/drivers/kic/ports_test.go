@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+)
+
+func TestParsePorts(t *testing.T) {
+	var tests = []struct {
+		name  string
+		specs []string
+		want  []oci.PortMapping
+		err   bool
+	}{
+		{"none", nil, []oci.PortMapping{}, false},
+		{"host and container", []string{"8080:80"}, []oci.PortMapping{{ListenAddress: "127.0.0.1", HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}}, false},
+		{"host ip and protocol", []string{"0.0.0.0:30053:53/UDP"}, []oci.PortMapping{{ListenAddress: "0.0.0.0", HostPort: 30053, ContainerPort: 53, Protocol: "udp"}}, false},
+		{"ipv6 host ip", []string{"[::1]:8443:443"}, []oci.PortMapping{{ListenAddress: "::1", HostPort: 8443, ContainerPort: 443, Protocol: "tcp"}}, false},
+		{"same port, other protocols", []string{"53:53/tcp", "53:53/udp"}, []oci.PortMapping{
+			{ListenAddress: "127.0.0.1", HostPort: 53, ContainerPort: 53, Protocol: "tcp"},
+			{ListenAddress: "127.0.0.1", HostPort: 53, ContainerPort: 53, Protocol: "udp"},
+		}, false},
+		{"duplicate", []string{"8080:80", "127.0.0.1:8080:81"}, nil, true},
+		{"container port only", []string{"80"}, nil, true},
+		{"bad port", []string{"8080:http"}, nil, true},
+		{"out of range", []string{"70000:80"}, nil, true},
+		{"bad host ip", []string{"localhost:8080:80"}, nil, true},
+		{"bad protocol", []string{"8080:80/sctp"}, nil, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePorts(tc.specs)
+			if (err != nil) != tc.err {
+				t.Fatalf("ParsePorts(%v) error = %v, expected error: %v", tc.specs, err, tc.err)
+			}
+			if !tc.err && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParsePorts(%v) = %+v, want %+v", tc.specs, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckPortsAvailable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer l.Close()
+	taken := int32(l.Addr().(*net.TCPAddr).Port)
+
+	if err := CheckPortsAvailable([]oci.PortMapping{{ListenAddress: "127.0.0.1", HostPort: taken, ContainerPort: 80, Protocol: "tcp"}}); err == nil {
+		t.Errorf("expected port %d to be reported as taken", taken)
+	}
+	// The port is only taken for tcp
+	if err := CheckPortsAvailable([]oci.PortMapping{{ListenAddress: "127.0.0.1", HostPort: taken, ContainerPort: 80, Protocol: "udp"}}); err != nil {
+		t.Errorf("expected udp port %d to be available: %v", taken, err)
+	}
+}
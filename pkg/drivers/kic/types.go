@@ -20,10 +20,12 @@ import (
 	"fmt"
 
 	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/constants"
 )
 
 const (
-	// DefaultNetwork is the Docker default bridge network named "bridge"
+	// DefaultNetwork is the Docker default bridge network named "bridge", which clusters created
+	// before each profile had a network of its own remain attached to
 	// (https://docs.docker.com/network/bridge/#use-the-default-bridge-network)
 	DefaultNetwork = "bridge"
 	// DefaultPodCIDR is The CIDR to be used for pods inside the node.
@@ -37,6 +39,9 @@ const (
 	// OverlayImage is the cni plugin used for overlay image, created by kind.
 	// CNI plugin image used for kic drivers created by kind.
 	OverlayImage = "kindest/kindnetd:0.5.3"
+
+	// LoadBalancerImage is the haproxy image fronting the apiservers of HA clusters
+	LoadBalancerImage = "haproxy:2.1.4"
 )
 
 var (
@@ -58,4 +63,20 @@ type Config struct {
 	Envs              map[string]string // key,value of environment variables passed to the node
 	KubernetesVersion string            // kubernetes version to install
 	ContainerRuntime  string            // container runtime kic is running
+	Network           string            // network of the profile, the default bridge if empty
+	IP                string            // static IP of the node within Network
+}
+
+// ExtraPortMappings returns the ports to publish besides the apiserver, ssh and docker ports, such as the auto-pause
+// proxy port and the ports given with --ports
+func ExtraPortMappings(autoPause bool, ports []string) ([]oci.PortMapping, error) {
+	var pm []oci.PortMapping
+	if autoPause {
+		pm = append(pm, oci.PortMapping{ListenAddress: oci.DefaultBindIPV4, ContainerPort: constants.AutoPauseProxyPort})
+	}
+	user, err := ParsePorts(ports)
+	if err != nil {
+		return nil, err
+	}
+	return append(pm, user...), nil
 }
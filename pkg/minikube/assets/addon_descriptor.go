@@ -0,0 +1,339 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+const (
+	// AddonDescriptorFile is the name of the file describing the addons within an addon directory
+	AddonDescriptorFile = "addon.yaml"
+
+	// bundledAddonsDir is the directory of the addons bundled into the minikube binary
+	bundledAddonsDir = "deploy/addons"
+
+	// defaultAssetPermissions are the permissions of an asset which does not specify any
+	defaultAssetPermissions = "0640"
+
+	// defaultReadinessNamespace is the namespace of a readiness check which does not specify any
+	defaultReadinessNamespace = "kube-system"
+)
+
+var (
+	// validAddonName matches the names addons may have
+	validAddonName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+	// validImageSlotName matches the names image slots may have, so that templates can refer to them
+	validImageSlotName = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// AddonDescriptor describes an addon and its assets
+type AddonDescriptor struct {
+	// Name is the name of the addon, as used by 'minikube addons enable'
+	Name string `yaml:"name"`
+	// Enabled is whether the addon is enabled by default
+	Enabled bool `yaml:"enabled"`
+	// StorageClass is the storage class to mark as default when the addon is enabled
+	StorageClass string `yaml:"storageClass,omitempty"`
+	// Validations are the names of the checks to run before the addon is enabled
+	Validations []string `yaml:"validations,omitempty"`
+	// Requires are the names of the addons which must be enabled before this addon
+	Requires []string `yaml:"requires,omitempty"`
+	// Conflicts are the names of the addons which may not be enabled at the same time as this addon
+	Conflicts []string `yaml:"conflicts,omitempty"`
+	// Readiness are the checks which pass once the addon is usable
+	Readiness []ReadinessCheck `yaml:"readiness,omitempty"`
+	// Images are the named image slots which asset templates refer to as {{.Images.Name}}
+	Images map[string]ImageSlot `yaml:"images,omitempty"`
+	// Assets are the files to deploy, in order
+	Assets []AssetDescriptor `yaml:"assets"`
+}
+
+// AssetDescriptor describes a single file of an addon
+type AssetDescriptor struct {
+	// Source is the path of the file, relative to the descriptor
+	Source string `yaml:"source"`
+	// TargetDir is the directory to deploy the file to, defaulting to the guest addons directory
+	TargetDir string `yaml:"targetDir,omitempty"`
+	// TargetName is the name to deploy the file as, defaulting to the source name without any .tmpl suffix
+	TargetName string `yaml:"targetName,omitempty"`
+	// Permissions are the octal permissions of the deployed file, defaulting to 0640
+	Permissions string `yaml:"permissions,omitempty"`
+	// Template is whether the file is a template to evaluate before deploying
+	Template bool `yaml:"template,omitempty"`
+}
+
+// ReadinessCheck describes the pods or deployment to wait for before an addon is considered ready
+type ReadinessCheck struct {
+	// Namespace is the namespace of the pods or deployment, defaulting to kube-system
+	Namespace string `yaml:"namespace,omitempty"`
+	// Selector is a label selector matching pods which must all be running
+	Selector string `yaml:"selector,omitempty"`
+	// Deployment is the name of a deployment which must have stabilized
+	Deployment string `yaml:"deployment,omitempty"`
+}
+
+// ImageSlot describes a named image of an addon, which users may override when enabling it
+type ImageSlot struct {
+	// Image is the image without its registry, which may refer to {{.Arch}} and {{.ExoticArch}}
+	Image string `yaml:"image"`
+	// Registry is the registry the image is pulled from, if any
+	Registry string `yaml:"registry,omitempty"`
+	// ImageRepository is whether the --image-repository flag replaces the registry
+	ImageRepository bool `yaml:"imageRepository,omitempty"`
+}
+
+// UserAddonsDir returns the directory of user-provided addon definitions
+func UserAddonsDir() string {
+	return localpath.MakeMiniPath("addon-definitions")
+}
+
+// ParseAddonDescriptors parses the addon descriptors within a file, which may contain multiple YAML documents
+func ParseAddonDescriptors(data []byte) ([]AddonDescriptor, error) {
+	ds := []AddonDescriptor{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var d AddonDescriptor
+		err := dec.Decode(&d)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding addon descriptor")
+		}
+		// Skip documents containing only comments
+		if d.Name == "" && len(d.Assets) == 0 {
+			continue
+		}
+		if err := d.validate(); err != nil {
+			return nil, err
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+// validate checks that a descriptor is complete and does not refer outside of its directory
+func (d AddonDescriptor) validate() error {
+	if !validAddonName.MatchString(d.Name) {
+		return fmt.Errorf("invalid addon name %q", d.Name)
+	}
+	if len(d.Assets) == 0 {
+		return fmt.Errorf("addon %q has no assets", d.Name)
+	}
+	for _, r := range append(append([]string{}, d.Requires...), d.Conflicts...) {
+		if !validAddonName.MatchString(r) {
+			return fmt.Errorf("addon %q refers to invalid addon name %q", d.Name, r)
+		}
+		if r == d.Name {
+			return fmt.Errorf("addon %q may not require or conflict with itself", d.Name)
+		}
+	}
+	for _, rc := range d.Readiness {
+		if (rc.Selector == "") == (rc.Deployment == "") {
+			return fmt.Errorf("addon %q readiness checks must have exactly one of selector or deployment", d.Name)
+		}
+		if rc.Selector != "" {
+			if _, err := labels.Parse(rc.Selector); err != nil {
+				return fmt.Errorf("addon %q has invalid readiness selector %q: %v", d.Name, rc.Selector, err)
+			}
+		}
+	}
+	for name, slot := range d.Images {
+		if !validImageSlotName.MatchString(name) {
+			return fmt.Errorf("addon %q has invalid image name %q", d.Name, name)
+		}
+		if slot.Image == "" {
+			return fmt.Errorf("addon %q image %q has no image", d.Name, name)
+		}
+		if _, err := slot.DefaultImage(); err != nil {
+			return fmt.Errorf("addon %q image %q is invalid: %v", d.Name, name, err)
+		}
+	}
+	for _, a := range d.Assets {
+		if a.Source == "" {
+			return fmt.Errorf("addon %q has an asset without a source", d.Name)
+		}
+		if path.IsAbs(a.Source) || strings.HasPrefix(path.Clean(a.Source), "..") {
+			return fmt.Errorf("addon %q asset %q must be within the addon directory", d.Name, a.Source)
+		}
+		if a.TargetDir != "" && !path.IsAbs(a.TargetDir) {
+			return fmt.Errorf("addon %q asset %q target directory must be absolute: %q", d.Name, a.Source, a.TargetDir)
+		}
+		if a.Permissions != "" {
+			if _, err := strconv.ParseUint(a.Permissions, 8, 32); err != nil {
+				return fmt.Errorf("addon %q asset %q has invalid permissions %q", d.Name, a.Source, a.Permissions)
+			}
+		}
+	}
+	return nil
+}
+
+// newAddonFromDescriptor creates an Addon, reading asset sources relative to dir with the read function
+func newAddonFromDescriptor(d AddonDescriptor, dir string, read func(string) ([]byte, error)) (*Addon, error) {
+	bas := []*BinAsset{}
+	for _, ad := range d.Assets {
+		src := path.Join(dir, ad.Source)
+		contents, err := read(src)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading addon %q asset", d.Name)
+		}
+
+		targetDir := ad.TargetDir
+		if targetDir == "" {
+			targetDir = vmpath.GuestAddonsDir
+		}
+		targetName := ad.TargetName
+		if targetName == "" {
+			targetName = strings.TrimSuffix(path.Base(ad.Source), ".tmpl")
+		}
+		perms := ad.Permissions
+		if perms == "" {
+			perms = defaultAssetPermissions
+		}
+
+		ba, err := NewBinAssetFromContents(contents, src, targetDir, targetName, perms, ad.Template)
+		if err != nil {
+			return nil, errors.Wrapf(err, "addon %q asset", d.Name)
+		}
+		bas = append(bas, ba)
+	}
+
+	a := NewAddon(bas, d.Enabled, d.Name)
+	a.Validations = d.Validations
+	a.StorageClass = d.StorageClass
+	a.Requires = d.Requires
+	a.Conflicts = d.Conflicts
+	for _, rc := range d.Readiness {
+		if rc.Namespace == "" {
+			rc.Namespace = defaultReadinessNamespace
+		}
+		a.Readiness = append(a.Readiness, rc)
+	}
+	a.Images = d.Images
+	return a, nil
+}
+
+// bundledAddons returns the addons described within the minikube binary
+func bundledAddons() (map[string]*Addon, error) {
+	names := []string{}
+	for _, n := range AssetNames() {
+		if strings.HasPrefix(n, bundledAddonsDir+"/") && path.Base(n) == AddonDescriptorFile {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	addons := map[string]*Addon{}
+	for _, n := range names {
+		data, err := Asset(n)
+		if err != nil {
+			return nil, err
+		}
+		if err := addDescribedAddons(addons, n, data, Asset); err != nil {
+			return nil, err
+		}
+	}
+	return addons, nil
+}
+
+// userAddons returns the addons described within subdirectories of dir, skipping invalid descriptors
+func userAddons(dir string) (map[string]*Addon, error) {
+	addons := map[string]*Addon{}
+	names, err := filepath.Glob(filepath.Join(dir, "*", AddonDescriptorFile))
+	if err != nil {
+		return addons, err
+	}
+
+	read := func(p string) ([]byte, error) {
+		return ioutil.ReadFile(filepath.FromSlash(p))
+	}
+	for _, n := range names {
+		data, err := ioutil.ReadFile(n)
+		if err != nil {
+			glog.Warningf("skipping %s: %v", n, err)
+			continue
+		}
+		if err := addDescribedAddons(addons, filepath.ToSlash(n), data, read); err != nil {
+			glog.Warningf("skipping %s: %v", n, err)
+		}
+	}
+	return addons, nil
+}
+
+// addDescribedAddons parses a descriptor file and adds the addons it describes
+func addDescribedAddons(addons map[string]*Addon, descriptor string, data []byte, read func(string) ([]byte, error)) error {
+	ds, err := ParseAddonDescriptors(data)
+	if err != nil {
+		return errors.Wrap(err, descriptor)
+	}
+	for _, d := range ds {
+		if _, ok := addons[d.Name]; ok {
+			return fmt.Errorf("%s: addon %q is already defined", descriptor, d.Name)
+		}
+		a, err := newAddonFromDescriptor(d, path.Dir(descriptor), read)
+		if err != nil {
+			return errors.Wrap(err, descriptor)
+		}
+		glog.V(1).Infof("loaded addon %q from %s", d.Name, descriptor)
+		addons[d.Name] = a
+	}
+	return nil
+}
+
+// loadAddons loads the bundled addons, followed by any user-provided addons
+func loadAddons() map[string]*Addon {
+	addons, err := bundledAddons()
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load bundled addons: %v", err))
+	}
+
+	dir := UserAddonsDir()
+	if _, err := os.Stat(dir); err != nil {
+		return addons
+	}
+	user, err := userAddons(dir)
+	if err != nil {
+		glog.Warningf("unable to load addons from %s: %v", dir, err)
+	}
+	for name, a := range user {
+		if _, ok := addons[name]; ok {
+			glog.Warningf("addon %q from %s overrides the bundled addon", name, dir)
+		}
+		addons[name] = a
+	}
+	return addons
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+func TestParseAddonDescriptors(t *testing.T) {
+	var tests = []struct {
+		description string
+		data        string
+		names       []string
+		err         bool
+	}{
+		{
+			description: "single",
+			data:        "name: registry\nassets:\n- source: registry-rc.yaml.tmpl\n",
+			names:       []string{"registry"},
+		},
+		{
+			description: "multiple documents with leading comment",
+			data:        "# comment\n---\nname: a\nassets:\n- source: a.yaml\n---\nname: b\nassets:\n- source: b.yaml\n",
+			names:       []string{"a", "b"},
+		},
+		{
+			description: "invalid name",
+			data:        "name: Registry\nassets:\n- source: registry-rc.yaml\n",
+			err:         true,
+		},
+		{
+			description: "no assets",
+			data:        "name: registry\n",
+			err:         true,
+		},
+		{
+			description: "source outside of directory",
+			data:        "name: registry\nassets:\n- source: ../dashboard/dashboard-ns.yaml\n",
+			err:         true,
+		},
+		{
+			description: "relative target directory",
+			data:        "name: registry\nassets:\n- source: registry-rc.yaml\n  targetDir: etc\n",
+			err:         true,
+		},
+		{
+			description: "readiness checks",
+			data:        "name: registry\nreadiness:\n- selector: kubernetes.io/minikube-addons=registry\n- namespace: default\n  deployment: registry\nassets:\n- source: registry-rc.yaml\n",
+			names:       []string{"registry"},
+		},
+		{
+			description: "readiness check with selector and deployment",
+			data:        "name: registry\nreadiness:\n- selector: app=registry\n  deployment: registry\nassets:\n- source: registry-rc.yaml\n",
+			err:         true,
+		},
+		{
+			description: "invalid readiness selector",
+			data:        "name: registry\nreadiness:\n- selector: app==registry,,\nassets:\n- source: registry-rc.yaml\n",
+			err:         true,
+		},
+		{
+			description: "images",
+			data:        "name: registry\nimages:\n  Registry:\n    registry: docker.io\n    image: \"registry-{{.Arch}}:2.7.1\"\nassets:\n- source: registry-rc.yaml\n",
+			names:       []string{"registry"},
+		},
+		{
+			description: "invalid image name",
+			data:        "name: registry\nimages:\n  registry:\n    image: registry:2.7.1\nassets:\n- source: registry-rc.yaml\n",
+			err:         true,
+		},
+		{
+			description: "invalid image template",
+			data:        "name: registry\nimages:\n  Registry:\n    image: \"registry-{{.Unknown}}:2.7.1\"\nassets:\n- source: registry-rc.yaml\n",
+			err:         true,
+		},
+		{
+			description: "invalid permissions",
+			data:        "name: registry\nassets:\n- source: registry-rc.yaml\n  permissions: rw\n",
+			err:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ds, err := ParseAddonDescriptors([]byte(test.data))
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", ds)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAddonDescriptors: %v", err)
+			}
+			names := []string{}
+			for _, d := range ds {
+				names = append(names, d.Name)
+			}
+			if len(names) != len(test.names) {
+				t.Fatalf("names = %v, want %v", names, test.names)
+			}
+			for i := range names {
+				if names[i] != test.names[i] {
+					t.Errorf("names = %v, want %v", names, test.names)
+				}
+			}
+		})
+	}
+}
+
+func TestBundledAddons(t *testing.T) {
+	addons, err := bundledAddons()
+	if err != nil {
+		t.Fatalf("bundledAddons: %v", err)
+	}
+
+	for _, name := range []string{"dashboard", "default-storageclass", "gvisor", "ingress", "nvidia-driver-installer", "nvidia-gpu-device-plugin", "storage-provisioner"} {
+		if _, ok := addons[name]; !ok {
+			t.Errorf("bundled addon %q not found", name)
+		}
+	}
+	if !addons["storage-provisioner"].enabled {
+		t.Errorf("expected storage-provisioner to be enabled by default")
+	}
+	if rc := addons["ingress"].Readiness; len(rc) != 1 || rc[0].Namespace != "kube-system" || rc[0].Deployment != "nginx-ingress-controller" {
+		t.Errorf("unexpected ingress readiness checks: %+v", rc)
+	}
+	if addons["default-storageclass"].StorageClass != "standard" {
+		t.Errorf("default-storageclass storage class = %q, want standard", addons["default-storageclass"].StorageClass)
+	}
+
+	dns := addons["dashboard"].Assets[0]
+	if dns.GetAssetName() != "deploy/addons/dashboard/dashboard-ns.yaml" || dns.GetTargetDir() != vmpath.GuestAddonsDir || dns.GetPermissions() != "0640" {
+		t.Errorf("unexpected first dashboard asset: %+v", dns.BaseAsset)
+	}
+}
+
+func TestUserAddons(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addon-definitions")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"greeter/addon.yaml":           "name: greeter\nenabled: true\nassets:\n- source: greeter-dp.yaml.tmpl\n  template: true\n- source: greeter.conf\n  targetDir: /etc/greeter\n  permissions: \"0644\"\n",
+		"greeter/greeter-dp.yaml.tmpl": "arch: {{.Arch}}\n",
+		"greeter/greeter.conf":         "hello\n",
+		"broken/addon.yaml":            "name: broken\nassets:\n- source: missing.yaml\n",
+	}
+	for name, contents := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	addons, err := userAddons(dir)
+	if err != nil {
+		t.Fatalf("userAddons: %v", err)
+	}
+	if _, ok := addons["broken"]; ok {
+		t.Errorf("expected addon with missing asset to be skipped")
+	}
+	a, ok := addons["greeter"]
+	if !ok {
+		t.Fatalf("greeter addon not loaded: %v", addons)
+	}
+	if !a.enabled {
+		t.Errorf("expected greeter to be enabled by default")
+	}
+	if len(a.Assets) != 2 {
+		t.Fatalf("got %d assets, want 2", len(a.Assets))
+	}
+
+	dp := a.Assets[0]
+	if !dp.IsTemplate() || dp.GetTargetName() != "greeter-dp.yaml" || dp.GetTargetDir() != vmpath.GuestAddonsDir {
+		t.Errorf("unexpected template asset: %+v", dp.BaseAsset)
+	}
+	conf := a.Assets[1]
+	if conf.IsTemplate() || conf.GetTargetDir() != "/etc/greeter" || conf.GetPermissions() != "0644" {
+		t.Errorf("unexpected config asset: %+v", conf.BaseAsset)
+	}
+}
@@ -17,13 +17,15 @@ limitations under the License.
 package assets
 
 import (
+	"bytes"
 	"runtime"
+	"sort"
+	"strings"
+	"text/template"
 
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/minikube/config"
-	"k8s.io/minikube/pkg/minikube/constants"
-	"k8s.io/minikube/pkg/minikube/vmpath"
 )
 
 // Addon is a named list of assets, that can be enabled
@@ -31,6 +33,19 @@ type Addon struct {
 	Assets    []*BinAsset
 	enabled   bool
 	addonName string
+
+	// Validations are the names of the checks to run before the addon is enabled
+	Validations []string
+	// StorageClass is the storage class to mark as default when the addon is enabled
+	StorageClass string
+	// Requires are the names of the addons which must be enabled before this addon
+	Requires []string
+	// Conflicts are the names of the addons which may not be enabled at the same time as this addon
+	Conflicts []string
+	// Readiness are the checks which pass once the addon is usable
+	Readiness []ReadinessCheck
+	// Images are the named image slots which asset templates refer to
+	Images map[string]ImageSlot
 }
 
 // NewAddon creates a new Addon
@@ -66,293 +81,98 @@ func (a *Addon) IsEnabled(profile string) (bool, error) {
 	return a.enabled, nil
 }
 
-// Addons is the list of addons
-// TODO: Make dynamically loadable: move this data to a .yaml file within each addon directory
-var Addons = map[string]*Addon{
-	"dashboard": NewAddon([]*BinAsset{
-		// We want to create the kubernetes-dashboard ns first so that every subsequent object can be created
-		MustBinAsset("deploy/addons/dashboard/dashboard-ns.yaml", vmpath.GuestAddonsDir, "dashboard-ns.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-clusterrole.yaml", vmpath.GuestAddonsDir, "dashboard-clusterrole.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-clusterrolebinding.yaml", vmpath.GuestAddonsDir, "dashboard-clusterrolebinding.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-configmap.yaml", vmpath.GuestAddonsDir, "dashboard-configmap.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-dp.yaml", vmpath.GuestAddonsDir, "dashboard-dp.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-role.yaml", vmpath.GuestAddonsDir, "dashboard-role.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-rolebinding.yaml", vmpath.GuestAddonsDir, "dashboard-rolebinding.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-sa.yaml", vmpath.GuestAddonsDir, "dashboard-sa.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-secret.yaml", vmpath.GuestAddonsDir, "dashboard-secret.yaml", "0640", false),
-		MustBinAsset("deploy/addons/dashboard/dashboard-svc.yaml", vmpath.GuestAddonsDir, "dashboard-svc.yaml", "0640", false),
-	}, false, "dashboard"),
-	"default-storageclass": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/storageclass/storageclass.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"storageclass.yaml",
-			"0640",
-			false),
-	}, true, "default-storageclass"),
-	"storage-provisioner": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/storage-provisioner/storage-provisioner.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"storage-provisioner.yaml",
-			"0640",
-			true),
-	}, true, "storage-provisioner"),
-	"storage-provisioner-gluster": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/storage-provisioner-gluster/storage-gluster-ns.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"storage-gluster-ns.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/storage-provisioner-gluster/glusterfs-daemonset.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"glusterfs-daemonset.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/storage-provisioner-gluster/heketi-deployment.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"heketi-deployment.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/storage-provisioner-gluster/storage-provisioner-glusterfile.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"storage-privisioner-glusterfile.yaml",
-			"0640",
-			false),
-	}, false, "storage-provisioner-gluster"),
-	"efk": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/efk/elasticsearch-rc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"elasticsearch-rc.yaml",
-			"0640",
-			true),
-		MustBinAsset(
-			"deploy/addons/efk/elasticsearch-svc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"elasticsearch-svc.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/efk/fluentd-es-rc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"fluentd-es-rc.yaml",
-			"0640",
-			true),
-		MustBinAsset(
-			"deploy/addons/efk/fluentd-es-configmap.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"fluentd-es-configmap.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/efk/kibana-rc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"kibana-rc.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/efk/kibana-svc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"kibana-svc.yaml",
-			"0640",
-			false),
-	}, false, "efk"),
-	"ingress": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/ingress/ingress-configmap.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"ingress-configmap.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/ingress/ingress-rbac.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"ingress-rbac.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/ingress/ingress-dp.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"ingress-dp.yaml",
-			"0640",
-			true),
-	}, false, "ingress"),
-	"istio-provisioner": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/istio-provisioner/istio-operator.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"istio-operator.yaml",
-			"0640",
-			true),
-	}, false, "istio-provisioner"),
-	"istio": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/istio/istio-default-profile.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"istio-default-profile.yaml",
-			"0640",
-			false),
-	}, false, "istio"),
-	"metrics-server": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/metrics-server/metrics-apiservice.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"metrics-apiservice.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/metrics-server/metrics-server-deployment.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"metrics-server-deployment.yaml",
-			"0640",
-			true),
-		MustBinAsset(
-			"deploy/addons/metrics-server/metrics-server-service.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"metrics-server-service.yaml",
-			"0640",
-			false),
-	}, false, "metrics-server"),
-	"registry": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/registry/registry-rc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"registry-rc.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/registry/registry-svc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"registry-svc.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/registry/registry-proxy.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"registry-proxy.yaml",
-			"0640",
-			false),
-	}, false, "registry"),
-	"registry-creds": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/registry-creds/registry-creds-rc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"registry-creds-rc.yaml",
-			"0640",
-			false),
-	}, false, "registry-creds"),
-	"freshpod": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/freshpod/freshpod-rc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"freshpod-rc.yaml",
-			"0640",
-			true),
-	}, false, "freshpod"),
-	"nvidia-driver-installer": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/gpu/nvidia-driver-installer.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"nvidia-driver-installer.yaml",
-			"0640",
-			true),
-	}, false, "nvidia-driver-installer"),
-	"nvidia-gpu-device-plugin": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/gpu/nvidia-gpu-device-plugin.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"nvidia-gpu-device-plugin.yaml",
-			"0640",
-			true),
-	}, false, "nvidia-gpu-device-plugin"),
-	"logviewer": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/logviewer/logviewer-dp-and-svc.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"logviewer-dp-and-svc.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/logviewer/logviewer-rbac.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"logviewer-rbac.yaml",
-			"0640",
-			false),
-	}, false, "logviewer"),
-	"gvisor": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/gvisor/gvisor-pod.yaml.tmpl",
-			vmpath.GuestAddonsDir,
-			"gvisor-pod.yaml",
-			"0640",
-			true),
-		MustBinAsset(
-			"deploy/addons/gvisor/gvisor-runtimeclass.yaml",
-			vmpath.GuestAddonsDir,
-			"gvisor-runtimeclass.yaml",
-			"0640",
-			false),
-		MustBinAsset(
-			"deploy/addons/gvisor/gvisor-config.toml",
-			vmpath.GuestGvisorDir,
-			constants.GvisorConfigTomlTargetName,
-			"0640",
-			true),
-	}, false, "gvisor"),
-	"helm-tiller": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/helm-tiller/helm-tiller-dp.tmpl",
-			vmpath.GuestAddonsDir,
-			"helm-tiller-dp.yaml",
-			"0640",
-			true),
-		MustBinAsset(
-			"deploy/addons/helm-tiller/helm-tiller-rbac.tmpl",
-			vmpath.GuestAddonsDir,
-			"helm-tiller-rbac.yaml",
-			"0640",
-			true),
-		MustBinAsset(
-			"deploy/addons/helm-tiller/helm-tiller-svc.tmpl",
-			vmpath.GuestAddonsDir,
-			"helm-tiller-svc.yaml",
-			"0640",
-			true),
-	}, false, "helm-tiller"),
-	"ingress-dns": NewAddon([]*BinAsset{
-		MustBinAsset(
-			"deploy/addons/ingress-dns/ingress-dns-pod.yaml",
-			vmpath.GuestAddonsDir,
-			"ingress-dns-pod.yaml",
-			"0640",
-			false),
-	}, false, "ingress-dns"),
-}
+// Addons is the list of addons, loaded from the bundled and user-provided addon descriptors
+var Addons = loadAddons()
 
-// GenerateTemplateData generates template data for template assets
-func GenerateTemplateData(cfg config.KubernetesConfig) interface{} {
+// archData is the architecture specific data available to templates and image slots
+type archData struct {
+	Arch       string
+	ExoticArch string
+}
 
-	a := runtime.GOARCH
+// currentArch returns the architecture specific data for the architecture minikube runs on
+func currentArch() archData {
 	// Some legacy docker images still need the -arch suffix
 	// for  less common architectures blank suffix for amd64
 	ea := ""
 	if runtime.GOARCH != "amd64" {
 		ea = "-" + runtime.GOARCH
 	}
+	return archData{Arch: runtime.GOARCH, ExoticArch: ea}
+}
+
+// DefaultImage returns the image of the slot for the current architecture, without its registry
+func (s ImageSlot) DefaultImage() (string, error) {
+	tpl, err := template.New("image").Option("missingkey=error").Parse(s.Image)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, currentArch()); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ImageNames returns the sorted names of the addon's image slots
+func (a *Addon) ImageNames() []string {
+	names := []string{}
+	for name := range a.Images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveImages returns the full reference of each image slot, applying any image and registry overrides
+func resolveImages(slots map[string]ImageSlot, images map[string]string, registries map[string]string, imageRepository string) (map[string]string, error) {
+	refs := map[string]string{}
+	for name, slot := range slots {
+		image, ok := images[name]
+		if !ok {
+			var err error
+			image, err = slot.DefaultImage()
+			if err != nil {
+				return nil, errors.Wrapf(err, "image %s", name)
+			}
+		}
+
+		registry := slot.Registry
+		if slot.ImageRepository && imageRepository != "" {
+			registry = imageRepository
+		}
+		if r, ok := registries[name]; ok {
+			registry = r
+		}
+
+		if registry == "" {
+			refs[name] = image
+			continue
+		}
+		refs[name] = strings.TrimSuffix(registry, "/") + "/" + image
+	}
+	return refs, nil
+}
+
+// GenerateTemplateData generates template data for the template assets of an addon
+func GenerateTemplateData(addon *Addon, cc config.ClusterConfig) (interface{}, error) {
+	images, err := resolveImages(addon.Images, cc.CustomAddonImages[addon.Name()], cc.CustomAddonRegistries[addon.Name()], cc.KubernetesConfig.ImageRepository)
+	if err != nil {
+		return nil, errors.Wrapf(err, "addon %s", addon.Name())
+	}
+
+	arch := currentArch()
 	opts := struct {
 		Arch            string
 		ExoticArch      string
 		ImageRepository string
+		Images          map[string]string
 	}{
-		Arch:            a,
-		ExoticArch:      ea,
-		ImageRepository: cfg.ImageRepository,
+		Arch:            arch.Arch,
+		ExoticArch:      arch.ExoticArch,
+		ImageRepository: cc.KubernetesConfig.ImageRepository,
+		Images:          images,
 	}
 
-	return opts
+	return opts, nil
 }
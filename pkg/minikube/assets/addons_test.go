@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestResolveImages(t *testing.T) {
+	slots := map[string]ImageSlot{
+		"Dashboard":          {Image: "kubernetesui/dashboard:v2.0.0-beta8"},
+		"StorageProvisioner": {Registry: "gcr.io/k8s-minikube", Image: "storage-provisioner:v1.8.1", ImageRepository: true},
+		"Kibana":             {Registry: "docker.elastic.co", Image: "kibana/kibana:5.6.2"},
+	}
+
+	var tests = []struct {
+		description     string
+		images          map[string]string
+		registries      map[string]string
+		imageRepository string
+		expected        map[string]string
+	}{
+		{
+			description: "defaults",
+			expected: map[string]string{
+				"Dashboard":          "kubernetesui/dashboard:v2.0.0-beta8",
+				"StorageProvisioner": "gcr.io/k8s-minikube/storage-provisioner:v1.8.1",
+				"Kibana":             "docker.elastic.co/kibana/kibana:5.6.2",
+			},
+		},
+		{
+			description:     "image repository",
+			imageRepository: "mirror.example.com/k8s",
+			expected: map[string]string{
+				"Dashboard":          "kubernetesui/dashboard:v2.0.0-beta8",
+				"StorageProvisioner": "mirror.example.com/k8s/storage-provisioner:v1.8.1",
+				"Kibana":             "docker.elastic.co/kibana/kibana:5.6.2",
+			},
+		},
+		{
+			description:     "overrides",
+			images:          map[string]string{"Dashboard": "my-org/dashboard:dev", "Other": "ignored:1"},
+			registries:      map[string]string{"Dashboard": "mirror.example.com/", "StorageProvisioner": "registry.local:5000"},
+			imageRepository: "mirror.example.com/k8s",
+			expected: map[string]string{
+				"Dashboard":          "mirror.example.com/my-org/dashboard:dev",
+				"StorageProvisioner": "registry.local:5000/storage-provisioner:v1.8.1",
+				"Kibana":             "docker.elastic.co/kibana/kibana:5.6.2",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got, err := resolveImages(slots, test.images, test.registries, test.imageRepository)
+			if err != nil {
+				t.Fatalf("resolveImages: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("images = %v, want %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestBundledTemplates(t *testing.T) {
+	cc := config.ClusterConfig{
+		CustomAddonImages:     map[string]map[string]string{"dashboard": {"Dashboard": "my-org/dashboard:dev"}},
+		CustomAddonRegistries: map[string]map[string]string{"dashboard": {"Dashboard": "mirror.example.com"}},
+	}
+	for name, a := range Addons {
+		data, err := GenerateTemplateData(a, cc)
+		if err != nil {
+			t.Fatalf("%s: GenerateTemplateData: %v", name, err)
+		}
+		for _, f := range a.Assets {
+			if !f.IsTemplate() {
+				if b, _ := ioutil.ReadAll(f); strings.Contains(string(b), "{{.Images.") {
+					t.Errorf("%s: %s refers to images but is not a template", name, f.GetAssetName())
+				}
+				if _, err := f.Seek(0, 0); err != nil {
+					t.Fatalf("seek: %v", err)
+				}
+				continue
+			}
+			m, err := f.Evaluate(data)
+			if err != nil {
+				t.Errorf("%s: evaluating %s: %v", name, f.GetAssetName(), err)
+				continue
+			}
+			b, err := ioutil.ReadAll(m)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if name == "dashboard" && strings.HasSuffix(f.GetAssetName(), "dashboard-dp.yaml") && !strings.Contains(string(b), "image: mirror.example.com/my-org/dashboard:dev") {
+				t.Errorf("dashboard image override was not applied:\n%s", b)
+			}
+		}
+	}
+}
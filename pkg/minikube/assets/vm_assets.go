@@ -41,6 +41,16 @@ type CopyableFile interface {
 	Seek(int64, int) (int64, error)
 }
 
+// ReadableFile is a file that can be read, such as one streamed back from a guest
+type ReadableFile interface {
+	io.Reader
+	io.Closer
+	GetLength() int
+	GetSourcePath() string
+	GetPermissions() string
+	GetModTime() (time.Time, error)
+}
+
 // BaseAsset is the base asset class
 type BaseAsset struct {
 	AssetName   string
@@ -199,7 +209,26 @@ func NewBinAsset(name, targetDir, targetName, permissions string, isTemplate boo
 		},
 		template: nil,
 	}
-	err := m.loadData(isTemplate)
+	contents, err := Asset(m.AssetName)
+	if err != nil {
+		return m, err
+	}
+	err = m.loadData(contents, isTemplate)
+	return m, err
+}
+
+// NewBinAssetFromContents creates a new BinAsset from contents which are not bundled, such as user-provided addons
+func NewBinAssetFromContents(contents []byte, name, targetDir, targetName, permissions string, isTemplate bool) (*BinAsset, error) {
+	m := &BinAsset{
+		BaseAsset: BaseAsset{
+			AssetName:   name,
+			TargetDir:   targetDir,
+			TargetName:  targetName,
+			Permissions: permissions,
+		},
+		template: nil,
+	}
+	err := m.loadData(contents, isTemplate)
 	return m, err
 }
 
@@ -214,14 +243,9 @@ func defaultValue(defValue string, val interface{}) string {
 	return strVal
 }
 
-func (m *BinAsset) loadData(isTemplate bool) error {
-	contents, err := Asset(m.AssetName)
-	if err != nil {
-		return err
-	}
-
+func (m *BinAsset) loadData(contents []byte, isTemplate bool) error {
 	if isTemplate {
-		tpl, err := template.New(m.AssetName).Funcs(template.FuncMap{"default": defaultValue}).Parse(string(contents))
+		tpl, err := template.New(m.AssetName).Option("missingkey=error").Funcs(template.FuncMap{"default": defaultValue}).Parse(string(contents))
 		if err != nil {
 			return err
 		}
@@ -255,7 +279,10 @@ func (m *BinAsset) Evaluate(data interface{}) (*MemoryAsset, error) {
 		return nil, err
 	}
 
-	return NewMemoryAsset(buf.Bytes(), m.GetTargetDir(), m.GetTargetName(), m.GetPermissions()), nil
+	// the rendered asset keeps the name of its template, which identifies it when it is copied
+	ma := NewMemoryAsset(buf.Bytes(), m.GetTargetDir(), m.GetTargetName(), m.GetPermissions())
+	ma.AssetName = m.AssetName
+	return ma, nil
 }
 
 // GetLength returns length
@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package autopause pauses idle clusters, using a proxy in front of the apiserver which runs inside the node
+package autopause
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/docker/machine/libmachine/state"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+const (
+	serviceName = "auto-pause"
+	servicePath = "/etc/systemd/system/auto-pause.service"
+	// StatePath exists in the node while auto-pause has paused the cluster. /run does not survive restarts, as paused containers do not.
+	StatePath = "/run/minikube-auto-pause/paused"
+)
+
+// BinaryPath is where the auto-pause binary is installed in the node
+var BinaryPath = path.Join(vmpath.GuestPersistentDir, "binaries", "auto-pause")
+
+var serviceTmpl = template.Must(template.New("auto-pause").Parse(`[Unit]
+Description=minikube auto-pause
+After=kubelet.service
+
+[Service]
+ExecStart={{.Binary}} --logtostderr --listen=:{{.ProxyPort}} --target=127.0.0.1:{{.APIServerPort}} --interval={{.Interval}} --container-runtime={{.ContainerRuntime}}
+Restart=always
+
+[Install]
+WantedBy=multi-user.target
+`))
+
+// Run serves the auto-pause proxy on the listen address, forwarding connections to the apiserver at the target address
+func Run(listen string, target string, interval time.Duration, runtime string) error {
+	r := command.NewExecRunner()
+	cr, err := cruntime.New(cruntime.Config{Type: runtime, Runner: r})
+	if err != nil {
+		return errors.Wrap(err, "runtime")
+	}
+
+	_, err = os.Stat(StatePath)
+	paused := err == nil
+	pause := func() error {
+		ids, err := cluster.Pause(cr, r, cluster.DefaultNamespaces)
+		if err != nil {
+			return err
+		}
+		glog.Infof("paused kubelet and %d containers", len(ids))
+		if err := os.MkdirAll(filepath.Dir(StatePath), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(StatePath, nil, 0644)
+	}
+	unpause := func() error {
+		ids, err := cluster.Unpause(cr, r, cluster.DefaultNamespaces)
+		if err != nil {
+			return err
+		}
+		glog.Infof("unpaused kubelet and %d containers", len(ids))
+		if err := os.Remove(StatePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	l, err := net.Listen("tcp", listen)
+	if err != nil {
+		return errors.Wrap(err, "listen")
+	}
+	glog.Infof("forwarding %s to %s, pausing after %s without connections (paused=%v)", listen, target, interval, paused)
+	p := NewProxy(target, interval, paused, pause, unpause)
+	go p.Watch(nil)
+	return p.Serve(l)
+}
+
+// serviceUnit returns the systemd unit running the auto-pause proxy in front of the apiserver of the node
+func serviceUnit(cc config.ClusterConfig, n config.Node) ([]byte, error) {
+	port := n.Port
+	if port <= 0 {
+		port = constants.APIServerPort
+	}
+	opts := struct {
+		Binary           string
+		ProxyPort        int
+		APIServerPort    int
+		Interval         time.Duration
+		ContainerRuntime string
+	}{
+		Binary:           BinaryPath,
+		ProxyPort:        constants.AutoPauseProxyPort,
+		APIServerPort:    port,
+		Interval:         cc.AutoPauseInterval,
+		ContainerRuntime: cc.KubernetesConfig.ContainerRuntime,
+	}
+	var b bytes.Buffer
+	if err := serviceTmpl.Execute(&b, opts); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Enable installs the auto-pause binary in the node, and starts the proxy in front of its apiserver
+func Enable(r command.Runner, cc config.ClusterConfig, n config.Node, binary string) error {
+	if err := machine.CopyBinary(r, binary, BinaryPath); err != nil {
+		return errors.Wrap(err, "copy binary")
+	}
+	unit, err := serviceUnit(cc, n)
+	if err != nil {
+		return errors.Wrap(err, "service unit")
+	}
+	if err := r.Copy(assets.NewMemoryAssetTarget(unit, servicePath, "0644")); err != nil {
+		return errors.Wrap(err, "copy service unit")
+	}
+	c := exec.Command("/bin/bash", "-c", "sudo systemctl daemon-reload && sudo systemctl enable auto-pause && sudo systemctl restart auto-pause")
+	if _, err := r.RunCmd(c); err != nil {
+		return errors.Wrap(err, "start auto-pause")
+	}
+	return nil
+}
+
+// Disable stops the auto-pause proxy in the node, and unpauses the cluster if the proxy had paused it
+func Disable(r command.Runner, cr cruntime.Manager) error {
+	if _, err := r.RunCmd(exec.Command("sudo", "test", "-f", servicePath)); err != nil {
+		glog.Infof("auto-pause is not installed")
+		return nil
+	}
+	if _, err := r.RunCmd(exec.Command("sudo", "systemctl", "disable", "--now", serviceName)); err != nil {
+		return errors.Wrap(err, "stop auto-pause")
+	}
+	if !Paused(r) {
+		return nil
+	}
+	if _, err := cluster.Unpause(cr, r, cluster.DefaultNamespaces); err != nil {
+		return errors.Wrap(err, "unpause")
+	}
+	if _, err := r.RunCmd(exec.Command("sudo", "rm", "-f", StatePath)); err != nil {
+		return errors.Wrap(err, "remove state")
+	}
+	return nil
+}
+
+// Paused returns whether the auto-pause proxy has paused the cluster
+func Paused(r command.Runner) bool {
+	_, err := r.RunCmd(exec.Command("sudo", "test", "-f", StatePath))
+	return err == nil
+}
+
+// Status returns the state of the auto-pause proxy
+func Status(r command.Runner) state.State {
+	rr, err := r.RunCmd(exec.Command("sudo", "systemctl", "is-active", serviceName))
+	if err != nil {
+		// Do not return now, as is-active also fails for stopped services
+		glog.Infof("%s returned error: %v", rr.Command(), err)
+	}
+	switch strings.TrimSpace(rr.Stdout.String()) {
+	case "active":
+		return state.Running
+	case "activating":
+		return state.Starting
+	case "inactive", "failed":
+		return state.Stopped
+	}
+	return state.Error
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopause
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestServiceUnit(t *testing.T) {
+	cc := config.ClusterConfig{
+		AutoPauseInterval: 90 * time.Second,
+		KubernetesConfig:  config.KubernetesConfig{ContainerRuntime: "containerd"},
+	}
+	got, err := serviceUnit(cc, config.Node{Port: 8444})
+	if err != nil {
+		t.Fatalf("serviceUnit: %v", err)
+	}
+	want := `[Unit]
+Description=minikube auto-pause
+After=kubelet.service
+
+[Service]
+ExecStart=/var/lib/minikube/binaries/auto-pause --logtostderr --listen=:32443 --target=127.0.0.1:8444 --interval=1m30s --container-runtime=containerd
+Restart=always
+
+[Install]
+WantedBy=multi-user.target
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("serviceUnit mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopause
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// dialTimeout is how long to wait for the apiserver to accept a proxied connection
+	dialTimeout = 30 * time.Second
+	// maxCheckInterval bounds how late the cluster is paused after the idle interval has passed
+	maxCheckInterval = 10 * time.Second
+)
+
+// Proxy forwards connections to the apiserver, pausing the cluster once it has been idle for an interval,
+// and unpausing it when the next connection arrives
+type Proxy struct {
+	target   string
+	interval time.Duration
+	pause    func() error
+	unpause  func() error
+
+	mu     sync.Mutex
+	active int
+	last   time.Time
+	paused bool
+}
+
+// NewProxy returns a proxy to the target address, calling pause and unpause to change the state of the cluster
+func NewProxy(target string, interval time.Duration, paused bool, pause func() error, unpause func() error) *Proxy {
+	return &Proxy{
+		target:   target,
+		interval: interval,
+		pause:    pause,
+		unpause:  unpause,
+		last:     time.Now(),
+		paused:   paused,
+	}
+}
+
+// Serve accepts connections on the listener until it fails, forwarding each of them to the target
+func (p *Proxy) Serve(l net.Listener) error {
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			return errors.Wrap(err, "accept")
+		}
+		go p.forward(c)
+	}
+}
+
+// Watch pauses the cluster once it has been idle for the interval, until stop is closed
+func (p *Proxy) Watch(stop <-chan struct{}) {
+	tick := p.interval / 4
+	if tick > maxCheckInterval {
+		tick = maxCheckInterval
+	}
+	t := time.NewTicker(tick)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-t.C:
+			p.check(now)
+		}
+	}
+}
+
+// check pauses the cluster if no connection has been open since the interval before now
+func (p *Proxy) check(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.paused || p.active > 0 || now.Sub(p.last) < p.interval {
+		return
+	}
+	glog.Infof("no apiserver connections for %s, pausing", now.Sub(p.last))
+	if err := p.pause(); err != nil {
+		glog.Errorf("pause: %v", err)
+		// retry once another interval has passed
+		p.last = now
+		return
+	}
+	p.paused = true
+}
+
+// open records a new connection, unpausing the cluster first if needed
+func (p *Proxy) open() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.paused {
+		glog.Infof("apiserver connection while paused, unpausing")
+		if err := p.unpause(); err != nil {
+			return errors.Wrap(err, "unpause")
+		}
+		p.paused = false
+	}
+	p.active++
+	p.last = time.Now()
+	return nil
+}
+
+// close records the end of a connection
+func (p *Proxy) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active--
+	p.last = time.Now()
+}
+
+// forward copies data between the connection and the target until either side closes
+func (p *Proxy) forward(c net.Conn) {
+	defer c.Close()
+	if err := p.open(); err != nil {
+		glog.Errorf("unable to forward connection from %s: %v", c.RemoteAddr(), err)
+		return
+	}
+	defer p.close()
+
+	t, err := net.DialTimeout("tcp", p.target, dialTimeout)
+	if err != nil {
+		glog.Errorf("dial %s: %v", p.target, err)
+		return
+	}
+	defer t.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(t, c)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(c, t)
+		done <- struct{}{}
+	}()
+	<-done
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopause
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeCluster counts the calls made by the proxy
+type fakeCluster struct {
+	pauses   int
+	unpauses int
+	err      error
+}
+
+func (f *fakeCluster) pause() error {
+	f.pauses++
+	return f.err
+}
+
+func (f *fakeCluster) unpause() error {
+	f.unpauses++
+	return f.err
+}
+
+func TestCheck(t *testing.T) {
+	f := &fakeCluster{}
+	p := NewProxy("", time.Minute, false, f.pause, f.unpause)
+	start := p.last
+
+	p.check(start.Add(30 * time.Second))
+	if f.pauses != 0 {
+		t.Errorf("paused after 30s, want no pause before 1m")
+	}
+
+	if err := p.open(); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	p.check(p.last.Add(2 * time.Minute))
+	if f.pauses != 0 {
+		t.Errorf("paused with an open connection")
+	}
+	p.close()
+
+	p.check(p.last.Add(time.Minute))
+	if f.pauses != 1 || !p.paused {
+		t.Errorf("pauses = %d, paused = %v after 1m idle, want 1 and true", f.pauses, p.paused)
+	}
+	p.check(p.last.Add(5 * time.Minute))
+	if f.pauses != 1 {
+		t.Errorf("pauses = %d after pausing again, want 1", f.pauses)
+	}
+
+	if err := p.open(); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	p.close()
+	if f.unpauses != 1 || p.paused {
+		t.Errorf("unpauses = %d, paused = %v after a connection, want 1 and false", f.unpauses, p.paused)
+	}
+}
+
+func TestCheckFailure(t *testing.T) {
+	f := &fakeCluster{err: fmt.Errorf("broken")}
+	p := NewProxy("", time.Minute, false, f.pause, f.unpause)
+
+	failed := p.last.Add(time.Minute)
+	p.check(failed)
+	if f.pauses != 1 || p.paused {
+		t.Fatalf("pauses = %d, paused = %v after failure, want 1 and false", f.pauses, p.paused)
+	}
+	p.check(failed.Add(30 * time.Second))
+	if f.pauses != 1 {
+		t.Errorf("retried pause after 30s, want a retry after another 1m")
+	}
+	p.check(failed.Add(time.Minute))
+	if f.pauses != 2 {
+		t.Errorf("pauses = %d, want a retry after another 1m", f.pauses)
+	}
+
+	p = NewProxy("", time.Minute, true, f.pause, f.unpause)
+	if err := p.open(); err == nil {
+		t.Errorf("open succeeded while unpause fails")
+	}
+	if p.active != 0 || !p.paused {
+		t.Errorf("active = %d, paused = %v after unpause failure, want 0 and true", p.active, p.paused)
+	}
+}
+
+func TestServe(t *testing.T) {
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer target.Close()
+	go func() {
+		for {
+			c, err := target.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				line, err := bufio.NewReader(c).ReadString('\n')
+				if err != nil {
+					return
+				}
+				fmt.Fprintf(c, "echo: %s", line)
+			}()
+		}
+	}()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer l.Close()
+	f := &fakeCluster{}
+	p := NewProxy(target.Addr().String(), time.Minute, true, f.pause, f.unpause)
+	go func() {
+		_ = p.Serve(l)
+	}()
+
+	c, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer c.Close()
+	fmt.Fprintf(c, "hello\n")
+	got, err := bufio.NewReader(c).ReadString('\n')
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if got != "echo: hello\n" {
+		t.Errorf("proxied reply = %q, want %q", got, "echo: hello\n")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if f.unpauses != 1 || p.paused {
+		t.Errorf("unpauses = %d, paused = %v after a connection, want 1 and false", f.unpauses, p.paused)
+	}
+}
@@ -36,7 +36,10 @@ type LogOptions struct {
 // Bootstrapper contains all the methods needed to bootstrap a kubernetes cluster
 type Bootstrapper interface {
 	StartCluster(config.ClusterConfig) error
-	UpdateCluster(config.ClusterConfig) error
+	UpdateCluster(config.ClusterConfig, config.Node) error
+	// GenerateToken returns the command joining a node to the cluster, as a control plane if requested.
+	GenerateToken(cc config.ClusterConfig, controlPlane bool) (string, error)
+	JoinCluster(config.ClusterConfig, config.Node, string) error
 	DeleteCluster(config.KubernetesConfig) error
 	WaitForCluster(config.ClusterConfig, time.Duration) error
 	// LogCommands returns a map of log type to a command which will display that log.
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/golang/glog"
@@ -82,6 +83,9 @@ func GenerateKubeadmYAML(mc config.ClusterConfig, r cruntime.Manager, n config.N
 		NoTaintMaster       bool
 		NodeIP              string
 		ControlPlaneAddress string
+		// Rootless nodes may not set the conntrack sysctls of the host, and need a kubelet aware of its user namespace
+		Rootless               bool
+		KubeletInUserNamespace bool
 	}{
 		CertDir:           vmpath.GuestKubernetesCertsDir,
 		ServiceCIDR:       constants.DefaultServiceCIDR,
@@ -101,13 +105,20 @@ func GenerateKubeadmYAML(mc config.ClusterConfig, r cruntime.Manager, n config.N
 		NodeIP:            n.IP,
 		// NOTE: If set to an specific VM IP, things may break if the IP changes on host restart
 		// For multi-node, we may need to figure out an alternate strategy, like DNS or hosts files
-		ControlPlaneAddress: "localhost",
+		ControlPlaneAddress:    "localhost",
+		Rootless:               mc.Rootless,
+		KubeletInUserNamespace: mc.Rootless && version.GTE(semver.MustParse(strings.TrimPrefix(constants.RootlessKubernetesVersion, "v"))),
 	}
 
 	if k8s.ServiceCIDR != "" {
 		opts.ServiceCIDR = k8s.ServiceCIDR
 	}
 
+	// HA clusters are reached through the load balancer fronting every apiserver
+	if mc.HA && k8s.APIServerHAVIP != "" {
+		opts.ControlPlaneAddress = k8s.APIServerHAVIP
+	}
+
 	opts.NoTaintMaster = true
 	b := bytes.Buffer{}
 	configTmpl := ktmpl.V1Alpha1
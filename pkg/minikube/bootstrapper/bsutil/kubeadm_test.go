@@ -161,6 +161,72 @@ func TestGenerateKubeadmYAMLDNS(t *testing.T) {
 	}
 }
 
+func TestGenerateKubeadmYAMLHA(t *testing.T) {
+	runtime, err := cruntime.New(cruntime.Config{Type: "docker"})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+	cfg := config.ClusterConfig{
+		HA: true,
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: "v1.18.0",
+			ClusterName:       "kubernetes",
+			APIServerHAVIP:    "1.1.1.254",
+		},
+		Nodes: []config.Node{
+			{IP: "1.1.1.1", Name: "m01", ControlPlane: true},
+			{IP: "1.1.1.2", Name: "m02", ControlPlane: true},
+		},
+	}
+
+	got, err := GenerateKubeadmYAML(cfg, runtime, cfg.Nodes[1])
+	if err != nil {
+		t.Fatalf("got unexpected error generating config: %v", err)
+	}
+	if !strings.Contains(string(got), "controlPlaneEndpoint: 1.1.1.254:8443") {
+		t.Errorf("expected the load balancer as control plane endpoint, got:\n%s", got)
+	}
+}
+
+func TestGenerateKubeadmYAMLRootless(t *testing.T) {
+	runtime, err := cruntime.New(cruntime.Config{Type: "containerd"})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+	tests := []struct {
+		version   string
+		rootless  bool
+		conntrack bool
+		userns    bool
+	}{
+		{"v1.18.0", true, true, false},
+		{"v1.22.0", true, true, true},
+		{"v1.22.0", false, false, false},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s_%v", tc.version, tc.rootless), func(t *testing.T) {
+			cfg := config.ClusterConfig{
+				Rootless: tc.rootless,
+				KubernetesConfig: config.KubernetesConfig{
+					KubernetesVersion: tc.version,
+					ClusterName:       "kubernetes",
+				},
+				Nodes: []config.Node{{IP: "1.1.1.1", Name: "mk", ControlPlane: true}},
+			}
+			got, err := GenerateKubeadmYAML(cfg, runtime, cfg.Nodes[0])
+			if err != nil {
+				t.Fatalf("got unexpected error generating config: %v", err)
+			}
+			if c := strings.Contains(string(got), "  maxPerCore: 0\n"); c != tc.conntrack {
+				t.Errorf("conntrack maxPerCore set = %v, want %v:\n%s", c, tc.conntrack, got)
+			}
+			if u := strings.Contains(string(got), "  KubeletInUserNamespace: true\n"); u != tc.userns {
+				t.Errorf("KubeletInUserNamespace set = %v, want %v:\n%s", u, tc.userns, got)
+			}
+		})
+	}
+}
+
 func TestGenerateKubeadmYAML(t *testing.T) {
 	extraOpts := getExtraOpts()
 	extraOptsPodCidr := getExtraOptsPodCidr()
@@ -59,6 +59,9 @@ func NewKubeletConfig(mc config.ClusterConfig, nc config.Node, r cruntime.Manage
 	}
 	if _, ok := extraOpts["node-ip"]; !ok {
 		extraOpts["node-ip"] = cp.IP
+		if nc.IP != "" {
+			extraOpts["node-ip"] = nc.IP
+		}
 	}
 	if nc.Name != "" {
 		extraOpts["hostname-override"] = nc.Name
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"bytes"
+	"path"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/ktmpl"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// KubeVIPImage is the image advertising the virtual IP of HA VM clusters
+const KubeVIPImage = "ghcr.io/kube-vip/kube-vip:v0.4.0"
+
+// KubeVIPManifestPath is the static pod manifest of kube-vip
+var KubeVIPManifestPath = path.Join(vmpath.GuestManifestsDir, "kube-vip.yaml")
+
+// NewKubeVIPConfig generates the kube-vip static pod advertising the virtual IP of an HA cluster on the given interface
+func NewKubeVIPConfig(cc config.ClusterConfig, iface string) ([]byte, error) {
+	if cc.KubernetesConfig.APIServerHAVIP == "" {
+		return nil, errors.New("no virtual IP assigned to the HA cluster")
+	}
+	cp, err := config.PrimaryControlPlane(cc)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting control plane")
+	}
+	port := cp.Port
+	if port <= 0 {
+		port = constants.APIServerPort
+	}
+
+	b := bytes.Buffer{}
+	opts := struct {
+		Image     string
+		Port      int
+		Interface string
+		VIP       string
+	}{
+		Image:     KubeVIPImage,
+		Port:      port,
+		Interface: iface,
+		VIP:       cc.KubernetesConfig.APIServerHAVIP,
+	}
+	if err := ktmpl.KubeVIPTemplate.Execute(&b, opts); err != nil {
+		return nil, errors.Wrap(err, "kube-vip template")
+	}
+	return b.Bytes(), nil
+}
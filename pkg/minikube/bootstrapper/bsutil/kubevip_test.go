@@ -0,0 +1,46 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestNewKubeVIPConfig(t *testing.T) {
+	cc := config.ClusterConfig{
+		HA:               true,
+		KubernetesConfig: config.KubernetesConfig{APIServerHAVIP: "192.168.39.254"},
+		Nodes:            []config.Node{{Name: "m01", IP: "192.168.39.10", Port: 8443, ControlPlane: true}},
+	}
+	got, err := NewKubeVIPConfig(cc, "eth1")
+	if err != nil {
+		t.Fatalf("NewKubeVIPConfig: %v", err)
+	}
+	for _, want := range []string{"value: 192.168.39.254", "value: eth1", `value: "8443"`, "image: " + KubeVIPImage} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("expected manifest to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	cc.KubernetesConfig.APIServerHAVIP = ""
+	if _, err := NewKubeVIPConfig(cc, "eth1"); err == nil {
+		t.Errorf("expected an error without a virtual IP")
+	}
+}
@@ -169,6 +169,9 @@ func generateCerts(k8s config.KubernetesConfig, n config.Node) error {
 	apiServerIPs := append(
 		k8s.APIServerIPs,
 		[]net.IP{net.ParseIP(n.IP), serviceIP, net.ParseIP(oci.DefaultBindIPV4), net.ParseIP("10.0.0.1")}...)
+	if k8s.APIServerHAVIP != "" {
+		apiServerIPs = append(apiServerIPs, net.ParseIP(k8s.APIServerHAVIP))
+	}
 	apiServerNames := append(k8s.APIServerNames, k8s.APIServerName)
 	apiServerAlternateNames := append(
 		apiServerNames,
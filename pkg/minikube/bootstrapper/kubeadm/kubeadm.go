@@ -41,6 +41,7 @@ import (
 	"k8s.io/minikube/pkg/drivers/kic"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/bootstrapper"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
@@ -385,7 +386,7 @@ func (k *Bootstrapper) SetupCerts(k8s config.KubernetesConfig, n config.Node) er
 }
 
 // UpdateCluster updates the cluster
-func (k *Bootstrapper) UpdateCluster(cfg config.ClusterConfig) error {
+func (k *Bootstrapper) UpdateCluster(cfg config.ClusterConfig, n config.Node) error {
 	images, err := images.Kubeadm(cfg.KubernetesConfig.ImageRepository, cfg.KubernetesConfig.KubernetesVersion)
 	if err != nil {
 		return errors.Wrap(err, "kubeadm images")
@@ -402,14 +403,12 @@ func (k *Bootstrapper) UpdateCluster(cfg config.ClusterConfig) error {
 		return errors.Wrap(err, "runtime")
 	}
 
-	// TODO: multiple nodes
-	kubeadmCfg, err := bsutil.GenerateKubeadmYAML(cfg, r, cfg.Nodes[0])
+	kubeadmCfg, err := bsutil.GenerateKubeadmYAML(cfg, r, n)
 	if err != nil {
 		return errors.Wrap(err, "generating kubeadm cfg")
 	}
 
-	// TODO: multiple nodes
-	kubeletCfg, err := bsutil.NewKubeletConfig(cfg, cfg.Nodes[0], r)
+	kubeletCfg, err := bsutil.NewKubeletConfig(cfg, n, r)
 	if err != nil {
 		return errors.Wrap(err, "generating kubelet config")
 	}
@@ -437,6 +436,15 @@ func (k *Bootstrapper) UpdateCluster(cfg config.ClusterConfig) error {
 	}
 	files := bsutil.ConfigFileAssets(cfg.KubernetesConfig, kubeadmCfg, kubeletCfg, kubeletService, cniFile)
 
+	// VM control planes of HA clusters advertise the virtual IP fronting their apiservers
+	if cfg.HA && n.ControlPlane && !driver.IsKIC(cfg.Driver) {
+		vip, err := k.kubeVIPConfig(cfg, n)
+		if err != nil {
+			return errors.Wrap(err, "generating kube-vip config")
+		}
+		files = append(files, assets.NewMemoryAssetTarget(vip, bsutil.KubeVIPManifestPath, "0600"))
+	}
+
 	// Combine mkdir request into a single call to reduce load
 	dirs := []string{}
 	for _, f := range files {
@@ -459,6 +467,108 @@ func (k *Bootstrapper) UpdateCluster(cfg config.ClusterConfig) error {
 	return nil
 }
 
+// kubeVIPConfig returns the kube-vip static pod advertising the virtual IP on the interface holding the node IP
+func (k *Bootstrapper) kubeVIPConfig(cfg config.ClusterConfig, n config.Node) ([]byte, error) {
+	rr, err := k.c.RunCmd(exec.Command("ip", "-o", "-4", "addr", "show", "to", n.IP))
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding interface for %s", n.IP)
+	}
+	// example: 2: eth1    inet 192.168.39.10/24 brd 192.168.39.255 scope global dynamic eth1
+	fields := strings.Fields(rr.Stdout.String())
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("no interface has address %s: %q", n.IP, rr.Stdout.String())
+	}
+	return bsutil.NewKubeVIPConfig(cfg, fields[1])
+}
+
+// GenerateToken creates a token and returns the kubeadm join command to run on a new node
+func (k *Bootstrapper) GenerateToken(cc config.ClusterConfig, controlPlane bool) (string, error) {
+	version := cc.KubernetesConfig.KubernetesVersion
+	tokenCmd := exec.Command("/bin/bash", "-c", fmt.Sprintf("%s token create --print-join-command", bsutil.InvokeKubeadm(version)))
+	r, err := k.c.RunCmd(tokenCmd)
+	if err != nil {
+		return "", errors.Wrap(err, "generating join command")
+	}
+
+	// example: kubeadm join localhost:8443 --token abcdef.0123456789abcdef --discovery-token-ca-cert-hash sha256:...
+	fields := strings.Fields(strings.TrimSpace(r.Stdout.String()))
+	if len(fields) < 3 || fields[1] != "join" {
+		return "", fmt.Errorf("unexpected join command: %q", r.Stdout.String())
+	}
+	cp, err := config.PrimaryControlPlane(cc)
+	if err != nil {
+		return "", errors.Wrap(err, "getting control plane")
+	}
+	endpoint := cp.IP
+	if cc.HA && cc.KubernetesConfig.APIServerHAVIP != "" {
+		endpoint = cc.KubernetesConfig.APIServerHAVIP
+	}
+	fields[0] = bsutil.InvokeKubeadm(version)
+	fields[2] = net.JoinHostPort(endpoint, strconv.Itoa(cp.Port))
+
+	if controlPlane {
+		key, err := k.uploadCerts(cc)
+		if err != nil {
+			return "", errors.Wrap(err, "uploading certificates")
+		}
+		fields = append(fields, "--control-plane", fmt.Sprintf("--certificate-key=%s", key))
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// uploadCerts shares the control plane certificates through the cluster, returning the key decrypting them
+func (k *Bootstrapper) uploadCerts(cc config.ClusterConfig) (string, error) {
+	c := exec.Command("/bin/bash", "-c", fmt.Sprintf("%s init phase upload-certs --upload-certs --config %s", bsutil.InvokeKubeadm(cc.KubernetesConfig.KubernetesVersion), bsutil.KubeadmYamlPath))
+	rr, err := k.c.RunCmd(c)
+	if err != nil {
+		return "", errors.Wrapf(err, "upload certs. output: %q", rr.Output())
+	}
+	// the key is printed last, following "[upload-certs] Using certificate key:"
+	lines := strings.Split(strings.TrimSpace(rr.Stdout.String()), "\n")
+	key := strings.TrimSpace(lines[len(lines)-1])
+	if key == "" || strings.Contains(key, " ") {
+		return "", fmt.Errorf("unexpected upload-certs output: %q", rr.Stdout.String())
+	}
+	return key, nil
+}
+
+// JoinCluster adds a node to an existing cluster using the join command of the primary control plane
+func (k *Bootstrapper) JoinCluster(cc config.ClusterConfig, n config.Node, joinCmd string) error {
+	start := time.Now()
+	glog.Infof("JoinCluster: %+v", cc)
+	defer func() {
+		glog.Infof("JoinCluster complete in %s", time.Since(start))
+	}()
+
+	// a node which already joined keeps the configuration kubeadm wrote for its kubelet
+	if _, err := k.c.RunCmd(exec.Command("sudo", "test", "-f", "/var/lib/kubelet/config.yaml")); err == nil {
+		glog.Infof("%s has already joined the cluster", n.Name)
+		return nil
+	}
+
+	r, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: k.c, Socket: cc.KubernetesConfig.CRISocket})
+	if err != nil {
+		return errors.Wrap(err, "runtime")
+	}
+
+	joinCmd = fmt.Sprintf("%s --ignore-preflight-errors=all --node-name=%s", joinCmd, n.Name)
+	if n.ControlPlane {
+		joinCmd = fmt.Sprintf("%s --apiserver-advertise-address=%s --apiserver-bind-port=%d", joinCmd, n.IP, n.Port)
+	}
+	if cc.KubernetesConfig.ContainerRuntime != "docker" {
+		joinCmd = fmt.Sprintf("%s --cri-socket %s", joinCmd, r.SocketPath())
+	}
+
+	if rr, err := k.c.RunCmd(exec.Command("/bin/bash", "-c", joinCmd)); err != nil {
+		return errors.Wrapf(err, "join failed. output: %q", rr.Output())
+	}
+
+	if _, err := k.c.RunCmd(exec.Command("/bin/bash", "-c", "sudo systemctl daemon-reload && sudo systemctl enable kubelet && sudo systemctl start kubelet")); err != nil {
+		return errors.Wrap(err, "starting kubelet")
+	}
+	return nil
+}
+
 // applyKicOverlay applies the CNI plugin needed to make kic work
 func (k *Bootstrapper) applyKicOverlay(cfg config.ClusterConfig) error {
 	// Allow no more than 5 seconds for apply kic overlay
@@ -20,6 +20,7 @@ import (
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/kubelet"
 )
@@ -75,3 +76,58 @@ func Unpause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]stri
 	}
 	return ids, nil
 }
+
+// MarkPaused records in the cluster config that the containers of namespaces are paused, or those of all namespaces when namespaces is nil
+func MarkPaused(cc *config.ClusterConfig, namespaces []string) {
+	switch {
+	case namespaces == nil || (cc.Paused && cc.PausedNamespaces == nil):
+		cc.PausedNamespaces = nil
+	case cc.Paused:
+		for _, ns := range namespaces {
+			if !containsNamespace(cc.PausedNamespaces, ns) {
+				cc.PausedNamespaces = append(cc.PausedNamespaces, ns)
+			}
+		}
+	default:
+		cc.PausedNamespaces = append([]string{}, namespaces...)
+	}
+	cc.Paused = true
+}
+
+// MarkUnpaused records in the cluster config that the containers of namespaces, or those of all namespaces when namespaces is nil, were unpaused
+func MarkUnpaused(cc *config.ClusterConfig, namespaces []string) {
+	// Unpause restarts the kubelet, so a cluster which was paused as a whole no longer is
+	if namespaces == nil || cc.PausedNamespaces == nil {
+		cc.Paused = false
+		cc.PausedNamespaces = nil
+		return
+	}
+	remaining := []string{}
+	for _, ns := range cc.PausedNamespaces {
+		if !containsNamespace(namespaces, ns) {
+			remaining = append(remaining, ns)
+		}
+	}
+	cc.Paused = len(remaining) > 0
+	cc.PausedNamespaces = nil
+	if cc.Paused {
+		cc.PausedNamespaces = remaining
+	}
+}
+
+// IsPaused returns whether minikube pause has paused the containers of namespace
+func IsPaused(cc config.ClusterConfig, namespace string) bool {
+	if !cc.Paused {
+		return false
+	}
+	return cc.PausedNamespaces == nil || containsNamespace(cc.PausedNamespaces, namespace)
+}
+
+func containsNamespace(namespaces []string, ns string) bool {
+	for _, n := range namespaces {
+		if n == ns {
+			return true
+		}
+	}
+	return false
+}
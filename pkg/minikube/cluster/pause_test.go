@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestMarkPaused(t *testing.T) {
+	var tests = []struct {
+		name       string
+		cc         config.ClusterConfig
+		unpause    bool
+		namespace  []string
+		paused     bool
+		namespaces []string
+	}{
+		{
+			name:       "pause",
+			namespace:  []string{"kube-system"},
+			paused:     true,
+			namespaces: []string{"kube-system"},
+		},
+		{
+			name:   "pause all",
+			paused: true,
+		},
+		{
+			name:       "pause more",
+			cc:         config.ClusterConfig{Paused: true, PausedNamespaces: []string{"kube-system"}},
+			namespace:  []string{"default", "kube-system"},
+			paused:     true,
+			namespaces: []string{"kube-system", "default"},
+		},
+		{
+			name:      "pause some of all",
+			cc:        config.ClusterConfig{Paused: true},
+			namespace: []string{"default"},
+			paused:    true,
+		},
+		{
+			name:       "unpause some",
+			cc:         config.ClusterConfig{Paused: true, PausedNamespaces: []string{"kube-system", "default"}},
+			unpause:    true,
+			namespace:  []string{"default"},
+			paused:     true,
+			namespaces: []string{"kube-system"},
+		},
+		{
+			name:      "unpause every paused",
+			cc:        config.ClusterConfig{Paused: true, PausedNamespaces: []string{"kube-system"}},
+			unpause:   true,
+			namespace: []string{"kube-system", "default"},
+		},
+		{
+			name:    "unpause all",
+			cc:      config.ClusterConfig{Paused: true, PausedNamespaces: []string{"kube-system"}},
+			unpause: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cc := tc.cc
+			if tc.unpause {
+				MarkUnpaused(&cc, tc.namespace)
+			} else {
+				MarkPaused(&cc, tc.namespace)
+			}
+			if cc.Paused != tc.paused {
+				t.Errorf("Paused = %v, want %v", cc.Paused, tc.paused)
+			}
+			if diff := cmp.Diff(tc.namespaces, cc.PausedNamespaces); diff != "" {
+				t.Errorf("PausedNamespaces mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsPaused(t *testing.T) {
+	var tests = []struct {
+		name      string
+		cc        config.ClusterConfig
+		namespace string
+		want      bool
+	}{
+		{"running", config.ClusterConfig{}, "kube-system", false},
+		{"all namespaces", config.ClusterConfig{Paused: true}, "kube-system", true},
+		{"paused namespace", config.ClusterConfig{Paused: true, PausedNamespaces: DefaultNamespaces}, "kube-system", true},
+		{"other namespace", config.ClusterConfig{Paused: true, PausedNamespaces: DefaultNamespaces}, "default", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsPaused(tc.cc, tc.namespace); got != tc.want {
+				t.Errorf("IsPaused(%q) = %v, want %v", tc.namespace, got, tc.want)
+			}
+		})
+	}
+}
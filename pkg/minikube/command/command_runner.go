@@ -19,10 +19,15 @@ package command
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/minikube/assets"
 )
 
@@ -53,12 +58,113 @@ type Runner interface {
 
 	// Remove is a convenience method that runs a command to remove a file
 	Remove(assets.CopyableFile) error
+
+	// ReadableFile opens a file on the runner for reading. The caller must Close it.
+	ReadableFile(sourcePath string) (assets.ReadableFile, error)
+
+	// CopyFrom copies a file from the runner to a local path, preserving its permissions
+	CopyFrom(sourcePath string, targetPath string) error
 }
 
 func getDeleteFileCommand(f assets.CopyableFile) string {
 	return fmt.Sprintf("sudo rm %s", path.Join(f.GetTargetDir(), f.GetTargetName()))
 }
 
+// readableFile is a ReadableFile backed by an io.ReadCloser
+type readableFile struct {
+	rc          io.ReadCloser
+	sourcePath  string
+	length      int
+	permissions string
+	modTime     time.Time
+}
+
+// GetLength returns the length of the file
+func (r *readableFile) GetLength() int {
+	return r.length
+}
+
+// GetSourcePath returns the path of the file on the runner
+func (r *readableFile) GetSourcePath() string {
+	return r.sourcePath
+}
+
+// GetPermissions returns the permissions of the file, in octal
+func (r *readableFile) GetPermissions() string {
+	return r.permissions
+}
+
+// GetModTime returns the modification time of the file
+func (r *readableFile) GetModTime() (time.Time, error) {
+	return r.modTime, nil
+}
+
+// Read reads from the file
+func (r *readableFile) Read(p []byte) (int, error) {
+	return r.rc.Read(p)
+}
+
+// Close closes the file
+func (r *readableFile) Close() error {
+	return r.rc.Close()
+}
+
+// newLocalReadableFile opens a file on the local filesystem as a ReadableFile
+func newLocalReadableFile(p string) (*readableFile, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open %s", p)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "stat %s", p)
+	}
+	if fi.IsDir() {
+		f.Close()
+		return nil, fmt.Errorf("%s is a directory", p)
+	}
+	return &readableFile{
+		rc:          f,
+		sourcePath:  p,
+		length:      int(fi.Size()),
+		permissions: fmt.Sprintf("%#o", fi.Mode().Perm()),
+		modTime:     fi.ModTime(),
+	}, nil
+}
+
+// writeLocalFile writes the contents of a ReadableFile to a local path, preserving permissions and modtime
+func writeLocalFile(rf assets.ReadableFile, targetPath string) error {
+	perms, err := strconv.ParseInt(rf.GetPermissions(), 8, 0)
+	if err != nil {
+		return errors.Wrapf(err, "converting permissions %s to integer", rf.GetPermissions())
+	}
+	target, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(perms))
+	if err != nil {
+		return errors.Wrapf(err, "creating %s", targetPath)
+	}
+	copied, err := io.Copy(target, rf)
+	if err != nil {
+		target.Close()
+		return errors.Wrapf(err, "copying %s to %s", rf.GetSourcePath(), targetPath)
+	}
+	if err := target.Close(); err != nil {
+		return errors.Wrapf(err, "closing %s", targetPath)
+	}
+	if copied != int64(rf.GetLength()) {
+		return fmt.Errorf("%s: expected to copy %d bytes, but copied %d instead", rf.GetSourcePath(), rf.GetLength(), copied)
+	}
+	// os.OpenFile honors the umask, so set the permissions explicitly
+	if err := os.Chmod(targetPath, os.FileMode(perms)); err != nil {
+		return errors.Wrapf(err, "chmod %s", targetPath)
+	}
+	mtime, err := rf.GetModTime()
+	if err != nil || mtime.IsZero() {
+		return nil
+	}
+	return os.Chtimes(targetPath, mtime, mtime)
+}
+
 // Command returns a human readable command string that does not induce eye fatigue
 func (rr RunResult) Command() string {
 	var sb strings.Builder
@@ -116,3 +116,22 @@ func (*execRunner) Remove(f assets.CopyableFile) error {
 	targetPath := filepath.Join(f.GetTargetDir(), f.GetTargetName())
 	return os.Remove(targetPath)
 }
+
+// ReadableFile opens a local file for reading
+func (*execRunner) ReadableFile(sourcePath string) (assets.ReadableFile, error) {
+	rf, err := newLocalReadableFile(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// CopyFrom copies a local file and its permissions
+func (*execRunner) CopyFrom(sourcePath string, targetPath string) error {
+	rf, err := newLocalReadableFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer rf.Close()
+	return writeLocalFile(rf, targetPath)
+}
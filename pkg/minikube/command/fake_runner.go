@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os/exec"
 	"strings"
 	"time"
@@ -107,6 +108,30 @@ func (f *FakeCommandRunner) Remove(file assets.CopyableFile) error {
 	return nil
 }
 
+// ReadableFile returns the stored contents of the filename as a ReadableFile
+func (f *FakeCommandRunner) ReadableFile(sourcePath string) (assets.ReadableFile, error) {
+	contents, err := f.GetFileToContents(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	return &readableFile{
+		rc:          ioutil.NopCloser(strings.NewReader(contents)),
+		sourcePath:  sourcePath,
+		length:      len(contents),
+		permissions: "0644",
+	}, nil
+}
+
+// CopyFrom writes the stored contents of the filename to a local path
+func (f *FakeCommandRunner) CopyFrom(sourcePath string, targetPath string) error {
+	rf, err := f.ReadableFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer rf.Close()
+	return writeLocalFile(rf, targetPath)
+}
+
 // SetFileToContents stores the file to contents map for the FakeCommandRunner
 func (f *FakeCommandRunner) SetFileToContents(fileToContents map[string]string) {
 	for k, v := range fileToContents {
@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -182,6 +183,51 @@ func copyToDocker(src string, dest string) error {
 	return nil
 }
 
+// ReadableFile copies a file out of the container so that it can be read
+func (k *kicRunner) ReadableFile(sourcePath string) (assets.ReadableFile, error) {
+	tmpDir, err := ioutil.TempDir("", "minikube-kic-read")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temporary directory")
+	}
+	dst := filepath.Join(tmpDir, path.Base(sourcePath))
+	if err := k.CopyFrom(sourcePath, dst); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	rf, err := newLocalReadableFile(dst)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	rf.sourcePath = sourcePath
+	rf.rc = &removeOnClose{ReadCloser: rf.rc, dir: tmpDir}
+	return rf, nil
+}
+
+// removeOnClose removes a temporary directory once the file within it is closed
+type removeOnClose struct {
+	io.ReadCloser
+	dir string
+}
+
+// Close closes the file and removes the temporary directory
+func (r *removeOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	if rerr := os.RemoveAll(r.dir); rerr != nil {
+		glog.Warningf("unable to remove %s: %v", r.dir, rerr)
+	}
+	return err
+}
+
+// CopyFrom copies a file out of the container, preserving its permissions
+func (k *kicRunner) CopyFrom(sourcePath string, targetPath string) error {
+	src := fmt.Sprintf("%s:%s", k.nameOrID, sourcePath)
+	if out, err := exec.Command(k.ociBin, "cp", src, targetPath).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "%s copy %s to %s, output: %s", k.ociBin, src, targetPath, string(out))
+	}
+	return nil
+}
+
 // Remove removes a file
 func (k *kicRunner) Remove(f assets.CopyableFile) error {
 	fp := path.Join(f.GetTargetDir(), f.GetTargetName())
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os/exec"
 	"path"
 	"strconv"
@@ -209,6 +210,150 @@ func (s *SSHRunner) Copy(f assets.CopyableFile) error {
 	return g.Wait()
 }
 
+// ReadableFile streams a file from the remote over SSH, using the scp source protocol.
+func (s *SSHRunner) ReadableFile(sourcePath string) (assets.ReadableFile, error) {
+	sess, err := s.c.NewSession()
+	if err != nil {
+		return nil, errors.Wrap(err, "NewSession")
+	}
+	w, err := sess.StdinPipe()
+	if err != nil {
+		sess.Close()
+		return nil, errors.Wrap(err, "StdinPipe")
+	}
+	r, err := sess.StdoutPipe()
+	if err != nil {
+		sess.Close()
+		return nil, errors.Wrap(err, "StdoutPipe")
+	}
+
+	scp := fmt.Sprintf("sudo scp -p -f %s", shellquote.Join(sourcePath))
+	glog.Infof("Run: %s", scp)
+	if err := sess.Start(scp); err != nil {
+		sess.Close()
+		return nil, errors.Wrap(err, scp)
+	}
+
+	br := bufio.NewReader(r)
+	// Signal the remote scp that we are ready to receive
+	if _, err := w.Write([]byte{0}); err != nil {
+		sess.Close()
+		return nil, errors.Wrap(err, "scp ack")
+	}
+	rf, err := readSCPHeader(br, w)
+	if err != nil {
+		sess.Close()
+		return nil, errors.Wrapf(err, "%s", scp)
+	}
+	rf.sourcePath = sourcePath
+	rf.rc = &scpReader{
+		Reader: io.LimitReader(br, int64(rf.length)),
+		br:     br,
+		w:      w,
+		sess:   sess,
+	}
+	return rf, nil
+}
+
+// CopyFrom copies a file from the remote over SSH, preserving its permissions.
+func (s *SSHRunner) CopyFrom(sourcePath string, targetPath string) error {
+	rf, err := s.ReadableFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	glog.Infof("Transferring %d bytes from %s to %s", rf.GetLength(), sourcePath, targetPath)
+	if err := writeLocalFile(rf, targetPath); err != nil {
+		rf.Close()
+		return err
+	}
+	return rf.Close()
+}
+
+// readSCPHeader reads the scp control messages preceding a file, acknowledging each one.
+func readSCPHeader(br *bufio.Reader, w io.Writer) (*readableFile, error) {
+	rf := &readableFile{}
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, errors.Wrap(err, "reading scp header")
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return nil, errors.New("empty scp header")
+		}
+
+		switch line[0] {
+		case 0x01, 0x02:
+			return nil, errors.New(strings.TrimSpace(line[1:]))
+		case 'T':
+			// T<mtime> 0 <atime> 0
+			fields := strings.Fields(line[1:])
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("malformed scp time header: %q", line)
+			}
+			secs, err := strconv.ParseInt(fields[0], 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing mtime in %q", line)
+			}
+			rf.modTime = time.Unix(secs, 0)
+		case 'C':
+			// C<perms> <length> <name>
+			fields := strings.SplitN(line[1:], " ", 3)
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("malformed scp file header: %q", line)
+			}
+			length, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing length in %q", line)
+			}
+			rf.permissions = fields[0]
+			rf.length = length
+		case 'D':
+			return nil, fmt.Errorf("%s is a directory", line[strings.LastIndex(line, " ")+1:])
+		default:
+			return nil, fmt.Errorf("unexpected scp header: %q", line)
+		}
+
+		if _, err := w.Write([]byte{0}); err != nil {
+			return nil, errors.Wrap(err, "scp ack")
+		}
+		if line[0] == 'C' {
+			return rf, nil
+		}
+	}
+}
+
+// scpReader reads a single file sent by a remote scp, completing the protocol on Close.
+type scpReader struct {
+	io.Reader
+	br   *bufio.Reader
+	w    io.WriteCloser
+	sess *ssh.Session
+}
+
+// Close drains the file, acknowledges it, and waits for the remote scp to exit.
+func (r *scpReader) Close() error {
+	defer r.sess.Close()
+	if _, err := io.Copy(ioutil.Discard, r.Reader); err != nil {
+		return errors.Wrap(err, "draining scp")
+	}
+	status, err := r.br.ReadByte()
+	if err != nil {
+		return errors.Wrap(err, "reading scp status")
+	}
+	if status != 0 {
+		msg, _ := r.br.ReadString('\n')
+		return fmt.Errorf("scp: %s", strings.TrimSpace(msg))
+	}
+	if _, err := r.w.Write([]byte{0}); err != nil {
+		return errors.Wrap(err, "scp ack")
+	}
+	if err := r.w.Close(); err != nil {
+		return errors.Wrap(err, "closing stdin")
+	}
+	return r.sess.Wait()
+}
+
 func (s *SSHRunner) sameFileExists(f assets.CopyableFile, dst string) (bool, error) {
 	// get file size and modtime of the source
 	srcSize := f.GetLength()
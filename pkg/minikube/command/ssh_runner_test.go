@@ -17,6 +17,7 @@ limitations under the License.
 package command
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"strings"
@@ -61,3 +62,48 @@ func TestTeePrefix(t *testing.T) {
 		t.Errorf("log=%q, want: %q", gotLog, wantLog)
 	}
 }
+
+func TestReadSCPHeader(t *testing.T) {
+	var tests = []struct {
+		description string
+		header      string
+		perms       string
+		length      int
+		mtime       int64
+		acks        int
+		err         bool
+	}{
+		{"file", "C0644 12 kubeadm.yaml\n", "0644", 12, 0, 1, false},
+		{"file with times", "T1585000000 0 1585000001 0\nC0600 5 apiserver.key\n", "0600", 5, 1585000000, 2, false},
+		{"missing file", "\x01scp: /var/tmp/nope: No such file or directory\n", "", 0, 0, 0, true},
+		{"directory", "D0755 0 certs\n", "", 0, 0, 0, true},
+		{"malformed", "C0644 twelve kubeadm.yaml\n", "", 0, 0, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var acks bytes.Buffer
+			rf, err := readSCPHeader(bufio.NewReader(strings.NewReader(test.header)), &acks)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", rf)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readSCPHeader: %v", err)
+			}
+			if rf.GetPermissions() != test.perms {
+				t.Errorf("permissions = %q, want %q", rf.GetPermissions(), test.perms)
+			}
+			if rf.GetLength() != test.length {
+				t.Errorf("length = %d, want %d", rf.GetLength(), test.length)
+			}
+			if test.mtime != 0 && rf.modTime.Unix() != test.mtime {
+				t.Errorf("mtime = %d, want %d", rf.modTime.Unix(), test.mtime)
+			}
+			if acks.Len() != test.acks {
+				t.Errorf("acks = %d, want %d", acks.Len(), test.acks)
+			}
+		})
+	}
+}
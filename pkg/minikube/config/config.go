@@ -47,6 +47,8 @@ const (
 	ShowDriverDeprecationNotification = "ShowDriverDeprecationNotification"
 	// ShowBootstrapperDeprecationNotification is the key for ShowBootstrapperDeprecationNotification
 	ShowBootstrapperDeprecationNotification = "ShowBootstrapperDeprecationNotification"
+	// ArtifactMirror is the key for the mirror which artifacts are downloaded from
+	ArtifactMirror = "artifact-mirror"
 )
 
 var (
@@ -62,6 +62,26 @@ func PrimaryControlPlane(cc ClusterConfig) (Node, error) {
 	return Node{}, errors.New("could not find master node")
 }
 
+// IsPrimaryControlPlane returns whether the node is the control plane which initialized the cluster
+func IsPrimaryControlPlane(cc ClusterConfig, n Node) bool {
+	cp, err := PrimaryControlPlane(cc)
+	if err != nil {
+		return false
+	}
+	return cp.Name == n.Name
+}
+
+// ControlPlanes returns the control plane nodes of a cluster
+func ControlPlanes(cc ClusterConfig) []Node {
+	cps := []Node{}
+	for _, n := range cc.Nodes {
+		if n.ControlPlane {
+			cps = append(cps, n)
+		}
+	}
+	return cps
+}
+
 // ProfileNameInReservedKeywords checks if the profile is an internal keywords
 func ProfileNameInReservedKeywords(name string) bool {
 	for _, v := range keywords {
@@ -18,6 +18,7 @@ package config
 
 import (
 	"net"
+	"time"
 
 	"github.com/blang/semver"
 )
@@ -64,7 +65,26 @@ type ClusterConfig struct {
 	NatNicType              string // Only used by virtualbox
 	KubernetesConfig        KubernetesConfig
 	Nodes                   []Node
+	HA                      bool // Whether the cluster has multiple control planes, fronted by a load balancer
 	Addons                  map[string]bool
+	CustomAddonImages       map[string]map[string]string // Image overrides of addon image slots, keyed by addon and then slot name
+	CustomAddonRegistries   map[string]map[string]string // Registry overrides of addon image slots, keyed by addon and then slot name
+	Paused                  bool                         // Whether minikube pause has stopped the kubelet and paused containers
+	PausedNamespaces        []string                     // Namespaces paused by minikube pause, or nil when all of them are
+	AutoPauseInterval       time.Duration                // Idle time after which the cluster is paused automatically, 0 disables auto-pause
+	ScheduledStop           *ScheduledStopConfig         // Stop scheduled with minikube stop --schedule, nil when none is pending
+	Network                 string                       // Docker/podman network of kic clusters, empty for clusters on the default bridge
+	Subnet                  string                       // Subnet of Network, within which nodes are given static IPs
+	ExposedPorts            []string                     // Ports published by the primary node of kic clusters, as [hostIP:]hostPort:containerPort[/proto]
+	Mount                   bool                         // Whether MountString is mounted into the nodes, by a bind mount with kic and by minikube mount otherwise
+	MountString             string                       // The directory to mount, as <host directory>:<node directory>[:options]
+	Rootless                bool                         // Whether the kic nodes are run by a rootless docker or podman daemon
+}
+
+// ScheduledStopConfig records a stop scheduled with minikube stop --schedule
+type ScheduledStopConfig struct {
+	Deadline time.Time // When the cluster is stopped
+	Pid      int       // The background minikube process which stops the cluster
 }
 
 // KubernetesConfig contains the parameters used to configure the VM Kubernetes.
@@ -74,6 +94,7 @@ type KubernetesConfig struct {
 	APIServerName     string
 	APIServerNames    []string
 	APIServerIPs      []net.IP
+	APIServerHAVIP    string // The address of the load balancer fronting the apiservers of an HA cluster
 	DNSDomain         string
 	ContainerRuntime  string
 	CRISocket         string
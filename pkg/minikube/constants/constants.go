@@ -31,6 +31,8 @@ const (
 	NewestKubernetesVersion = "v1.17.3"
 	// OldestKubernetesVersion is the oldest Kubernetes version to test against
 	OldestKubernetesVersion = "v1.11.10"
+	// RootlessKubernetesVersion is the oldest Kubernetes version whose kubelet runs in the user namespace of a rootless node
+	RootlessKubernetesVersion = "v1.22.0"
 	// DefaultClusterName is the default nane for the k8s cluster
 	DefaultClusterName = "minikube"
 	// DockerDaemonPort is the port Docker daemon listening inside a minikube node (vm or container).
@@ -39,6 +41,8 @@ const (
 	APIServerPort = 8443
 	// SSHPort is the SSH serviceport on the node vm and container
 	SSHPort = 22
+	// AutoPauseProxyPort is the port the auto-pause proxy in front of the apiserver listens on inside the node
+	AutoPauseProxyPort = 32443
 
 	// APIServerName is the default API server name
 	APIServerName = "minikubeCA"
@@ -26,6 +26,7 @@ import (
 	"text/template"
 
 	"github.com/golang/glog"
+	ociname "github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
 	"k8s.io/minikube/pkg/minikube/out"
@@ -241,6 +242,91 @@ func (r *Containerd) LoadImage(path string) error {
 	return nil
 }
 
+// ListImages returns the names of the images within the runtime
+func (r *Containerd) ListImages() ([]string, error) {
+	return listCRIImages(r.Runner)
+}
+
+// PullImage pulls an image into the runtime
+func (r *Containerd) PullImage(name string) error {
+	glog.Infof("Pulling image: %s", name)
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", getCrictlPath(r.Runner), "pull", name)); err != nil {
+		return errors.Wrap(err, "crictl pull")
+	}
+	return nil
+}
+
+// RemoveImage removes an image from the runtime
+func (r *Containerd) RemoveImage(name string) error {
+	return removeCRIImage(r.Runner, name)
+}
+
+// SaveImage saves an image to an archive on the host
+func (r *Containerd) SaveImage(name string, path string) error {
+	glog.Infof("Saving image %s: %s", name, path)
+	c := exec.Command("sudo", "ctr", "-n=k8s.io", "images", "export", path, fullImageName(name))
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "ctr images export")
+	}
+	return nil
+}
+
+// BuildImage builds the context directory on the host, using the named Dockerfile within it, into an image with the given name, using buildkit
+func (r *Containerd) BuildImage(dir string, file string, name string) error {
+	glog.Infof("Building image %s: %s", name, dir)
+	if err := r.enableBuildkit(); err != nil {
+		return err
+	}
+	dockerfileDir := dir
+	args := []string{"buildctl", "build", "--frontend", "dockerfile.v0", "--local", fmt.Sprintf("context=%s", dir)}
+	if file != "" {
+		dockerfileDir = path.Dir(path.Join(dir, file))
+		args = append(args, "--opt", fmt.Sprintf("filename=%s", path.Base(file)))
+	}
+	// buildkitd uses the containerd worker in the k8s.io namespace, so the unpacked image is visible to the kubelet
+	args = append(args,
+		"--local", fmt.Sprintf("dockerfile=%s", dockerfileDir),
+		"--output", fmt.Sprintf("type=image,name=%s,unpack=true", fullImageName(name)))
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", args...)); err != nil {
+		return errors.Wrap(err, "buildctl build")
+	}
+	return nil
+}
+
+// enableBuildkit starts buildkitd, which builds images for containerd, unless it is already running
+func (r *Containerd) enableBuildkit() error {
+	if _, err := r.Runner.RunCmd(exec.Command("systemctl", "is-active", "--quiet", "service", "buildkit")); err == nil {
+		return nil
+	}
+	// older base images of the docker and podman drivers do not include buildkit
+	if _, err := r.Runner.RunCmd(exec.Command("which", "buildctl", "buildkitd")); err != nil {
+		return errors.New("building images with containerd is not supported on this node, which lacks buildkit: recreate the cluster with 'minikube delete' and 'minikube start' to use a newer base image, or use the docker runtime")
+	}
+	glog.Infof("Starting buildkit ...")
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", "systemctl", "start", "buildkit")); err != nil {
+		return errors.Wrap(err, "starting buildkit")
+	}
+	return nil
+}
+
+// fullImageName returns the fully qualified name which containerd knows an image by, such as docker.io/library/busybox:latest
+func fullImageName(name string) string {
+	ref, err := ociname.ParseReference(name, ociname.WeakValidation)
+	if err != nil {
+		glog.Warningf("unable to parse image name %q: %v", name, err)
+		return name
+	}
+	registry := ref.Context().RegistryStr()
+	if registry == ociname.DefaultRegistry {
+		registry = "docker.io"
+	}
+	separator := ":"
+	if _, ok := ref.(ociname.Digest); ok {
+		separator = "@"
+	}
+	return fmt.Sprintf("%s/%s%s%s", registry, ref.Context().RepositoryStr(), separator, ref.Identifier())
+}
+
 // CGroupDriver returns cgroup driver ("cgroupfs" or "systemd")
 func (r *Containerd) CGroupDriver() (string, error) {
 	info, err := getCRIInfo(r.Runner)
@@ -281,12 +367,27 @@ func (r *Containerd) ListContainers(o ListOptions) ([]string, error) {
 
 // PauseContainers pauses a running container based on ID
 func (r *Containerd) PauseContainers(ids []string) error {
-	return pauseCRIContainers(r.Runner, containerdNamespaceRoot, ids)
+	return r.tasks("pause", ids)
 }
 
 // UnpauseContainers unpauses a running container based on ID
 func (r *Containerd) UnpauseContainers(ids []string) error {
-	return unpauseCRIContainers(r.Runner, containerdNamespaceRoot, ids)
+	return r.tasks("resume", ids)
+}
+
+// tasks runs a ctr task action on each container, so that containerd keeps track of the state runc is asked for
+func (r *Containerd) tasks(action string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	glog.Infof("ctr task %s containers: %s", action, ids)
+	for _, id := range ids {
+		c := exec.Command("sudo", "ctr", "-n=k8s.io", "task", action, id)
+		if _, err := r.Runner.RunCmd(c); err != nil {
+			return errors.Wrapf(err, "ctr task %s %s", action, id)
+		}
+	}
+	return nil
 }
 
 // KillContainers removes containers based on ID
@@ -309,7 +410,13 @@ func (r *Containerd) SystemLogCmd(len int) string {
 	return fmt.Sprintf("sudo journalctl -u containerd -n %d", len)
 }
 
-// Preload preloads the container runtime with k8s images
+// Preload preloads containerd with k8s images, extracting them to /var/lib/containerd
 func (r *Containerd) Preload(k8sVersion string) error {
-	return fmt.Errorf("not yet implemented for %s", r.Name())
+	if err := extractPreloadedTarball(r.Runner, k8sVersion, "containerd"); err != nil {
+		return err
+	}
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", "systemctl", "restart", "containerd")); err != nil {
+		return errors.Wrap(err, "restart containerd")
+	}
+	return nil
 }
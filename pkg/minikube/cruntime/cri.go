@@ -106,8 +106,10 @@ func listCRIContainers(cr CommandRunner, root string, o ListOptions) ([]string,
 		return nil, err
 	}
 
+	// Exited containers are listed by ps, but have no runc state
 	if len(cs) == 0 {
-		return nil, fmt.Errorf("list returned 0 containers, but ps returned %d", len(ids))
+		glog.Infof("runc listed 0 containers, but ps returned %d: none of them are %s", len(ids), o.State)
+		return nil, nil
 	}
 
 	glog.Infof("list returned %d containers", len(cs))
@@ -127,21 +129,9 @@ func listCRIContainers(cr CommandRunner, root string, o ListOptions) ([]string,
 	return fids, nil
 }
 
-// pauseContainers pauses a list of containers
+// pauseCRIContainers pauses a list of containers using runc
 func pauseCRIContainers(cr CommandRunner, root string, ids []string) error {
-	args := []string{"runc"}
-	if root != "" {
-		args = append(args, "--root", root)
-	}
-	args = append(args, "pause")
-
-	for _, id := range ids {
-		cargs := append(args, id)
-		if _, err := cr.RunCmd(exec.Command("sudo", cargs...)); err != nil {
-			return errors.Wrap(err, "runc")
-		}
-	}
-	return nil
+	return runcContainers(cr, root, "pause", ids)
 }
 
 // getCrictlPath returns the absolute path of crictl
@@ -154,18 +144,26 @@ func getCrictlPath(cr CommandRunner) string {
 	return strings.Split(rr.Stdout.String(), "\n")[0]
 }
 
-// unpauseCRIContainers pauses a list of containers
+// unpauseCRIContainers unpauses a list of containers using runc
 func unpauseCRIContainers(cr CommandRunner, root string, ids []string) error {
-	args := []string{"runc"}
-	if root != "" {
-		args = append(args, "--root", root)
+	return runcContainers(cr, root, "resume", ids)
+}
+
+// runcContainers runs a runc action, such as pause or resume, on each container
+func runcContainers(cr CommandRunner, root string, action string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
 	}
-	args = append(args, "resume")
+	glog.Infof("runc %s containers: %s", action, ids)
 
+	base := []string{"runc"}
+	if root != "" {
+		base = append(base, "--root", root)
+	}
 	for _, id := range ids {
-		cargs := append(args, id)
-		if _, err := cr.RunCmd(exec.Command("sudo", cargs...)); err != nil {
-			return errors.Wrap(err, "runc")
+		args := append(append([]string{}, base...), action, id)
+		if _, err := cr.RunCmd(exec.Command("sudo", args...)); err != nil {
+			return errors.Wrapf(err, "runc %s %s", action, id)
 		}
 	}
 	return nil
@@ -259,3 +257,33 @@ func criContainerLogCmd(cr CommandRunner, id string, len int, follow bool) strin
 	cmd.WriteString(id)
 	return cmd.String()
 }
+
+// listCRIImages returns the names of the images known to crictl
+func listCRIImages(cr CommandRunner) ([]string, error) {
+	rr, err := cr.RunCmd(exec.Command("sudo", getCrictlPath(cr), "images", "--output", "json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "crictl images")
+	}
+	var list struct {
+		Images []struct {
+			RepoTags []string `json:"repoTags"`
+		} `json:"images"`
+	}
+	if err := json.Unmarshal(rr.Stdout.Bytes(), &list); err != nil {
+		return nil, errors.Wrap(err, "parsing crictl images")
+	}
+	images := []string{}
+	for _, img := range list.Images {
+		images = append(images, img.RepoTags...)
+	}
+	return images, nil
+}
+
+// removeCRIImage removes an image using crictl
+func removeCRIImage(cr CommandRunner, name string) error {
+	glog.Infof("Removing image: %s", name)
+	if _, err := cr.RunCmd(exec.Command("sudo", getCrictlPath(cr), "rmi", name)); err != nil {
+		return errors.Wrap(err, "crictl rmi")
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ package cruntime
 import (
 	"fmt"
 	"os/exec"
+	"path"
 	"strings"
 
 	"github.com/golang/glog"
@@ -161,6 +162,53 @@ func (r *CRIO) LoadImage(path string) error {
 	return nil
 }
 
+// ListImages returns the names of the images within the runtime
+func (r *CRIO) ListImages() ([]string, error) {
+	return listCRIImages(r.Runner)
+}
+
+// PullImage pulls an image into the runtime
+func (r *CRIO) PullImage(name string) error {
+	glog.Infof("Pulling image: %s", name)
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", "podman", "pull", name)); err != nil {
+		return errors.Wrap(err, "crio pull image")
+	}
+	return nil
+}
+
+// RemoveImage removes an image from the runtime
+func (r *CRIO) RemoveImage(name string) error {
+	glog.Infof("Removing image: %s", name)
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", "podman", "rmi", name)); err != nil {
+		return errors.Wrap(err, "crio remove image")
+	}
+	return nil
+}
+
+// SaveImage saves an image to an archive on the host
+func (r *CRIO) SaveImage(name string, path string) error {
+	glog.Infof("Saving image %s: %s", name, path)
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", "podman", "save", "-o", path, name)); err != nil {
+		return errors.Wrap(err, "crio save image")
+	}
+	return nil
+}
+
+// BuildImage builds the context directory on the host, using the named Dockerfile within it, into an image with the given name
+func (r *CRIO) BuildImage(dir string, file string, name string) error {
+	glog.Infof("Building image %s: %s", name, dir)
+	// podman would name "my-app:dev" localhost/my-app:dev, which pods asking for my-app:dev do not find
+	args := []string{"podman", "build", "-t", fullImageName(name)}
+	if file != "" {
+		args = append(args, "-f", path.Join(dir, file))
+	}
+	args = append(args, dir)
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", args...)); err != nil {
+		return errors.Wrap(err, "crio build image")
+	}
+	return nil
+}
+
 // CGroupDriver returns cgroup driver ("cgroupfs" or "systemd")
 func (r *CRIO) CGroupDriver() (string, error) {
 	c := exec.Command("crio", "config")
@@ -226,7 +274,13 @@ func (r *CRIO) SystemLogCmd(len int) string {
 	return fmt.Sprintf("sudo journalctl -u crio -n %d", len)
 }
 
-// Preload preloads the container runtime with k8s images
+// Preload preloads CRI-O with k8s images, extracting them to /var/lib/containers
 func (r *CRIO) Preload(k8sVersion string) error {
-	return fmt.Errorf("not yet implemented for %s", r.Name())
+	if err := extractPreloadedTarball(r.Runner, k8sVersion, "cri-o"); err != nil {
+		return err
+	}
+	if _, err := r.Runner.RunCmd(exec.Command("sudo", "systemctl", "restart", "crio")); err != nil {
+		return errors.Wrap(err, "restart crio")
+	}
+	return nil
 }
@@ -20,11 +20,14 @@ package cruntime
 import (
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/out"
 )
 
@@ -84,6 +87,16 @@ type Manager interface {
 
 	// ImageExists takes image name and image sha checks if an it exists
 	ImageExists(string, string) bool
+	// ListImages returns the names of the images within the runtime
+	ListImages() ([]string, error)
+	// PullImage pulls an image into the runtime
+	PullImage(string) error
+	// RemoveImage removes an image from the runtime
+	RemoveImage(string) error
+	// SaveImage saves an image to an archive on the host
+	SaveImage(string, string) error
+	// BuildImage builds the context directory on the host, using the named Dockerfile within it, into an image with the given name
+	BuildImage(string, string, string) error
 
 	// ListContainers returns a list of managed by this container runtime
 	ListContainers(ListOptions) ([]string, error)
@@ -193,3 +206,39 @@ func enableIPForwarding(cr CommandRunner) error {
 	}
 	return nil
 }
+
+// extractPreloadedTarball extracts the preloaded tarball of a runtime to /var on the host:
+// 1. Copy over the preloaded tarball into the VM
+// 2. Extract the preloaded tarball to the correct directory, such as /var/lib/containerd
+// 3. Remove the tarball within the VM
+func extractPreloadedTarball(cr CommandRunner, k8sVersion, containerRuntime string) error {
+	tarballPath := download.TarballPath(k8sVersion, containerRuntime)
+	dest := "/preloaded.tar.lz4"
+
+	c := exec.Command("which", "lz4")
+	if _, err := cr.RunCmd(c); err != nil {
+		return errors.Wrapf(err, "check lz4 available.")
+	}
+
+	// Copy over tarball into host
+	fa, err := assets.NewFileAsset(tarballPath, filepath.Dir(dest), filepath.Base(dest), "0644")
+	if err != nil {
+		return errors.Wrap(err, "getting file asset")
+	}
+	t := time.Now()
+	if err := cr.Copy(fa); err != nil {
+		return errors.Wrap(err, "copying file")
+	}
+	glog.Infof("Took %f seconds to copy over tarball", time.Since(t).Seconds())
+
+	// extract the tarball to /var in the VM
+	if rr, err := cr.RunCmd(exec.Command("sudo", "tar", "-I", "lz4", "-C", "/var", "-xvf", dest)); err != nil {
+		return errors.Wrapf(err, "extracting tarball: %s", rr.Output())
+	}
+
+	//  remove the tarball in the VM
+	if err := cr.Remove(fa); err != nil {
+		glog.Infof("error removing tarball: %v", err)
+	}
+	return nil
+}
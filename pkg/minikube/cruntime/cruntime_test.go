@@ -18,6 +18,7 @@ package cruntime
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -82,6 +83,103 @@ func TestImageExists(t *testing.T) {
 	}
 }
 
+func TestListImages(t *testing.T) {
+	var tests = []struct {
+		runtime string
+		want    []string
+	}{
+		{"docker", []string{"k8s.gcr.io/pause:3.2", "busybox:latest"}},
+		{"crio", []string{"k8s.gcr.io/pause:3.2", "docker.io/library/busybox:latest"}},
+		{"containerd", []string{"k8s.gcr.io/pause:3.2", "docker.io/library/busybox:latest"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.runtime, func(t *testing.T) {
+			r, err := New(Config{Type: tc.runtime, Runner: NewFakeRunner(t)})
+			if err != nil {
+				t.Fatalf("New(%s): %v", tc.runtime, err)
+			}
+
+			got, err := r.ListImages()
+			if err != nil {
+				t.Fatalf("ListImages(%s): %v", tc.runtime, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ListImages(%s) returned diff (-want +got):\n%s", tc.runtime, diff)
+			}
+		})
+	}
+}
+
+func TestFullImageName(t *testing.T) {
+	var tests = []struct {
+		name string
+		want string
+	}{
+		{"busybox", "docker.io/library/busybox:latest"},
+		{"kindest/node:v1.18.2", "docker.io/kindest/node:v1.18.2"},
+		{"gcr.io/k8s-minikube/kicbase:v0.0.10", "gcr.io/k8s-minikube/kicbase:v0.0.10"},
+		{"busybox@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "docker.io/library/busybox@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := fullImageName(tc.name)
+			if got != tc.want {
+				t.Errorf("fullImageName(%s) = %q, want: %q", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildImage(t *testing.T) {
+	var tests = []struct {
+		runtime string
+		want    string
+	}{
+		{"docker", "docker build -t my-app:dev -f /build/my-app/build/Dockerfile /build/my-app"},
+		{"crio", "sudo podman build -t docker.io/library/my-app:dev -f /build/my-app/build/Dockerfile /build/my-app"},
+		{"containerd", "sudo buildctl build --frontend dockerfile.v0 --local context=/build/my-app --opt filename=Dockerfile --local dockerfile=/build/my-app/build --output type=image,name=docker.io/library/my-app:dev,unpack=true"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.runtime, func(t *testing.T) {
+			runner := NewFakeRunner(t)
+			runner.services["buildkit"] = SvcExited
+			r, err := New(Config{Type: tc.runtime, Runner: runner})
+			if err != nil {
+				t.Fatalf("New(%s): %v", tc.runtime, err)
+			}
+
+			if err := r.BuildImage("/build/my-app", "build/Dockerfile", "my-app:dev"); err != nil {
+				t.Fatalf("BuildImage(%s): %v", tc.runtime, err)
+			}
+			got := strings.Join(runner.cmds, " ")
+			if !strings.Contains(got, tc.want) {
+				t.Errorf("BuildImage(%s) ran %q, want: %q", tc.runtime, got, tc.want)
+			}
+			if tc.runtime == "containerd" && runner.services["buildkit"] != SvcRunning {
+				t.Errorf("BuildImage(%s) did not start buildkit", tc.runtime)
+			}
+		})
+	}
+}
+
+func TestBuildImageWithoutBuildkit(t *testing.T) {
+	runner := NewFakeRunner(t)
+	runner.services["buildkit"] = SvcExited
+	runner.missing["buildkitd"] = true
+	r, err := New(Config{Type: "containerd", Runner: runner})
+	if err != nil {
+		t.Fatalf("New(containerd): %v", err)
+	}
+
+	err = r.BuildImage("/build/my-app", "", "my-app:dev")
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("BuildImage without buildkit = %v, want an error saying it is not supported", err)
+	}
+	if strings.Contains(strings.Join(runner.cmds, " "), "dockerfile.v0") {
+		t.Errorf("BuildImage without buildkit ran buildctl: %v", runner.cmds)
+	}
+}
+
 func TestCGroupDriver(t *testing.T) {
 	var tests = []struct {
 		runtime string
@@ -156,7 +254,10 @@ type FakeRunner struct {
 	cmds       []string
 	services   map[string]serviceState
 	containers map[string]string
-	t          *testing.T
+	paused     map[string]bool
+	// missing are the commands which are not installed
+	missing map[string]bool
+	t       *testing.T
 }
 
 // NewFakeRunner returns a CommandRunner which emulates a systemd host
@@ -166,6 +267,8 @@ func NewFakeRunner(t *testing.T) *FakeRunner {
 		cmds:       []string{},
 		t:          t,
 		containers: map[string]string{},
+		paused:     map[string]bool{},
+		missing:    map[string]bool{},
 	}
 }
 
@@ -210,6 +313,10 @@ func (f *FakeRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
 		return buffer(f.crio(args, root))
 	case "containerd":
 		return buffer(f.containerd(args, root))
+	case "runc":
+		return buffer(f.runc(args, root))
+	case "ctr":
+		return buffer(f.ctr(args, root))
 	default:
 		rr := &command.RunResult{}
 		return rr, nil
@@ -224,6 +331,14 @@ func (f *FakeRunner) Remove(assets.CopyableFile) error {
 	return nil
 }
 
+func (f *FakeRunner) ReadableFile(string) (assets.ReadableFile, error) {
+	return nil, nil
+}
+
+func (f *FakeRunner) CopyFrom(string, string) error {
+	return nil
+}
+
 // docker is a fake implementation of docker
 func (f *FakeRunner) docker(args []string, _ bool) (string, error) {
 	switch cmd := args[0]; cmd {
@@ -242,6 +357,26 @@ func (f *FakeRunner) docker(args []string, _ bool) (string, error) {
 			f.t.Logf("fake docker: Found containers: %v", ids)
 			return strings.Join(ids, "\n"), nil
 		}
+		// ps --filter status=paused --filter=name=k8s_apiserver --format={{.ID}}
+		if args[1] == "--filter" && strings.HasPrefix(args[2], "status=") {
+			paused := args[2] == "status=paused"
+			fname := strings.Split(args[3], "=")[2]
+			ids := []string{}
+			for id, cname := range f.containers {
+				if strings.Contains(cname, fname) && f.paused[id] == paused {
+					ids = append(ids, id)
+				}
+			}
+			f.t.Logf("fake docker: Found %s containers: %v", args[2], ids)
+			return strings.Join(ids, "\n"), nil
+		}
+	case "pause", "unpause":
+		for _, id := range args[1:] {
+			if f.containers[id] == "" {
+				return "", fmt.Errorf("no such container")
+			}
+			f.paused[id] = cmd == "pause"
+		}
 	case "stop":
 		ids := strings.Split(args[1], " ")
 		for _, id := range ids {
@@ -281,6 +416,9 @@ func (f *FakeRunner) docker(args []string, _ bool) (string, error) {
 		if args[1] == "--format" && args[2] == "{{.CgroupDriver}}" {
 			return "cgroupfs", nil
 		}
+
+	case "images":
+		return "k8s.gcr.io/pause:3.2\n<none>:<none>\nbusybox:latest\n", nil
 	}
 	return "", nil
 }
@@ -339,6 +477,14 @@ func (f *FakeRunner) crictl(args []string, _ bool) (string, error) {
 		  },
 		  "golang": "go1.11.13"
 		}`, nil
+	case "images":
+		return `{
+		  "images": [
+		    {"repoTags": ["k8s.gcr.io/pause:3.2"]},
+		    {"repoTags": []},
+		    {"repoTags": ["docker.io/library/busybox:latest"]}
+		  ]
+		}`, nil
 	case "ps":
 		fmt.Printf("args %d: %v\n", len(args), args)
 		if len(args) != 4 {
@@ -387,6 +533,51 @@ func (f *FakeRunner) crictl(args []string, _ bool) (string, error) {
 	return "", nil
 }
 
+// runc is a fake implementation of runc
+func (f *FakeRunner) runc(args []string, _ bool) (string, error) {
+	if args[0] == "--root" {
+		args = args[2:]
+	}
+	switch cmd := args[0]; cmd {
+	case "list":
+		cs := []container{}
+		for id := range f.containers {
+			status := "running"
+			if f.paused[id] {
+				status = "paused"
+			}
+			cs = append(cs, container{ID: id, Status: status})
+		}
+		b, err := json.Marshal(cs)
+		return string(b), err
+	case "pause", "resume":
+		id := args[1]
+		if f.containers[id] == "" {
+			return "", fmt.Errorf("container %q does not exist", id)
+		}
+		f.paused[id] = cmd == "pause"
+	}
+	return "", nil
+}
+
+// ctr is a fake implementation of ctr
+func (f *FakeRunner) ctr(args []string, _ bool) (string, error) {
+	// ctr -n=k8s.io task pause <id>
+	if len(args) == 4 && args[1] == "task" {
+		id := args[3]
+		if f.containers[id] == "" {
+			return "", fmt.Errorf("no running task found: task %s not found", id)
+		}
+		switch args[2] {
+		case "pause":
+			f.paused[id] = true
+		case "resume":
+			f.paused[id] = false
+		}
+	}
+	return "", nil
+}
+
 // systemctl is a fake implementation of systemctl
 func (f *FakeRunner) systemctl(args []string, root bool) (string, error) { // nolint result 0 (string) is always ""
 	action := args[0]
@@ -441,9 +632,14 @@ func (f *FakeRunner) systemctl(args []string, root bool) (string, error) { // no
 
 // which is a fake implementation of which
 func (f *FakeRunner) which(args []string, root bool) (string, error) { // nolint result 0 (string) is always ""
-	command := args[0]
-	path := fmt.Sprintf("/usr/bin/%s", command)
-	return path, nil
+	var paths []string
+	for _, command := range args {
+		if f.missing[command] {
+			return "", &exec.ExitError{Stderr: []byte{}}
+		}
+		paths = append(paths, fmt.Sprintf("/usr/bin/%s", command))
+	}
+	return strings.Join(paths, "\n"), nil
 }
 
 func TestVersion(t *testing.T) {
@@ -633,3 +829,85 @@ func TestContainerFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestPauseContainers(t *testing.T) {
+	var tests = []struct {
+		runtime string
+		pause   string
+	}{
+		{"docker", "docker pause abc0"},
+		{"crio", "sudo runc pause abc0"},
+		{"containerd", "sudo ctr -n=k8s.io task pause abc0"},
+	}
+
+	sortSlices := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	for _, tc := range tests {
+		t.Run(tc.runtime, func(t *testing.T) {
+			runner := NewFakeRunner(t)
+			prefix := ""
+			if tc.runtime == "docker" {
+				prefix = "k8s_"
+			}
+			runner.containers = map[string]string{
+				"abc0": prefix + "apiserver",
+				"fgh1": prefix + "coredns",
+				"xyz2": prefix + "storage",
+			}
+			cr, err := New(Config{Type: tc.runtime, Runner: runner})
+			if err != nil {
+				t.Fatalf("New(%s): %v", tc.runtime, err)
+			}
+
+			got, err := cr.ListContainers(ListOptions{State: Running, Name: "apiserver"})
+			if err != nil {
+				t.Fatalf("ListContainers: %v", err)
+			}
+			if diff := cmp.Diff(got, []string{"abc0"}); diff != "" {
+				t.Errorf("ListContainers(running apiserver) unexpected results, diff (-got + want): %s", diff)
+			}
+			if err := cr.PauseContainers(got); err != nil {
+				t.Fatalf("PauseContainers: %v", err)
+			}
+			if !strings.Contains(strings.Join(runner.cmds, " "), tc.pause) {
+				t.Errorf("PauseContainers did not run %q: %v", tc.pause, runner.cmds)
+			}
+
+			got, err = cr.ListContainers(ListOptions{State: Paused})
+			if err != nil {
+				t.Fatalf("ListContainers: %v", err)
+			}
+			if diff := cmp.Diff(got, []string{"abc0"}); diff != "" {
+				t.Errorf("ListContainers(paused) unexpected results, diff (-got + want): %s", diff)
+			}
+			got, err = cr.ListContainers(ListOptions{State: Running})
+			if err != nil {
+				t.Fatalf("ListContainers: %v", err)
+			}
+			if diff := cmp.Diff(got, []string{"fgh1", "xyz2"}, sortSlices); diff != "" {
+				t.Errorf("ListContainers(running) unexpected results, diff (-got + want): %s", diff)
+			}
+
+			if err := cr.UnpauseContainers([]string{"abc0"}); err != nil {
+				t.Fatalf("UnpauseContainers: %v", err)
+			}
+			got, err = cr.ListContainers(ListOptions{State: Paused})
+			if err != nil {
+				t.Fatalf("ListContainers: %v", err)
+			}
+			if len(got) > 0 {
+				t.Errorf("ListContainers(paused) = %v, want 0 items", got)
+			}
+			got, err = cr.ListContainers(ListOptions{State: Running})
+			if err != nil {
+				t.Fatalf("ListContainers: %v", err)
+			}
+			if diff := cmp.Diff(got, []string{"abc0", "fgh1", "xyz2"}, sortSlices); diff != "" {
+				t.Errorf("ListContainers(running) unexpected results, diff (-got + want): %s", diff)
+			}
+
+			if err := cr.PauseContainers([]string{"missing"}); err == nil {
+				t.Errorf("PauseContainers(missing) succeeded, want error")
+			}
+		})
+	}
+}
@@ -19,14 +19,11 @@ package cruntime
 import (
 	"fmt"
 	"os/exec"
-	"path/filepath"
+	"path"
 	"strings"
-	"time"
 
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
-	"k8s.io/minikube/pkg/minikube/assets"
-	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/out"
 )
 
@@ -140,6 +137,63 @@ func (r *Docker) LoadImage(path string) error {
 
 }
 
+// ListImages returns the names of the images within the runtime
+func (r *Docker) ListImages() ([]string, error) {
+	rr, err := r.Runner.RunCmd(exec.Command("docker", "images", "--format", "{{.Repository}}:{{.Tag}}"))
+	if err != nil {
+		return nil, errors.Wrap(err, "docker images")
+	}
+	images := []string{}
+	for _, img := range strings.Split(rr.Stdout.String(), "\n") {
+		if img == "" || strings.Contains(img, "<none>") {
+			continue
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// PullImage pulls an image into the runtime
+func (r *Docker) PullImage(name string) error {
+	glog.Infof("Pulling image: %s", name)
+	if _, err := r.Runner.RunCmd(exec.Command("docker", "pull", name)); err != nil {
+		return errors.Wrap(err, "pull image docker.")
+	}
+	return nil
+}
+
+// RemoveImage removes an image from the runtime
+func (r *Docker) RemoveImage(name string) error {
+	glog.Infof("Removing image: %s", name)
+	if _, err := r.Runner.RunCmd(exec.Command("docker", "rmi", name)); err != nil {
+		return errors.Wrap(err, "remove image docker.")
+	}
+	return nil
+}
+
+// SaveImage saves an image to an archive on the host
+func (r *Docker) SaveImage(name string, path string) error {
+	glog.Infof("Saving image %s: %s", name, path)
+	if _, err := r.Runner.RunCmd(exec.Command("docker", "save", "-o", path, name)); err != nil {
+		return errors.Wrap(err, "save image docker.")
+	}
+	return nil
+}
+
+// BuildImage builds the context directory on the host, using the named Dockerfile within it, into an image with the given name
+func (r *Docker) BuildImage(dir string, file string, name string) error {
+	glog.Infof("Building image %s: %s", name, dir)
+	args := []string{"build", "-t", name}
+	if file != "" {
+		args = append(args, "-f", path.Join(dir, file))
+	}
+	args = append(args, dir)
+	if _, err := r.Runner.RunCmd(exec.Command("docker", args...)); err != nil {
+		return errors.Wrap(err, "build image docker.")
+	}
+	return nil
+}
+
 // CGroupDriver returns cgroup driver ("cgroupfs" or "systemd")
 func (r *Docker) CGroupDriver() (string, error) {
 	// Note: the server daemon has to be running, for this call to return successfully
@@ -266,38 +320,10 @@ func (r *Docker) SystemLogCmd(len int) string {
 	return fmt.Sprintf("sudo journalctl -u docker -n %d", len)
 }
 
-// Preload preloads docker with k8s images:
-// 1. Copy over the preloaded tarball into the VM
-// 2. Extract the preloaded tarball to the correct directory
-// 3. Remove the tarball within the VM
+// Preload preloads docker with k8s images, extracting them to /var/lib/docker
 func (r *Docker) Preload(k8sVersion string) error {
-	tarballPath := download.TarballPath(k8sVersion)
-	dest := "/preloaded.tar.lz4"
-
-	c := exec.Command("which", "lz4")
-	if _, err := r.Runner.RunCmd(c); err != nil {
-		return errors.Wrapf(err, "check lz4 available.")
-	}
-
-	// Copy over tarball into host
-	fa, err := assets.NewFileAsset(tarballPath, filepath.Dir(dest), filepath.Base(dest), "0644")
-	if err != nil {
-		return errors.Wrap(err, "getting file asset")
-	}
-	t := time.Now()
-	if err := r.Runner.Copy(fa); err != nil {
-		return errors.Wrap(err, "copying file")
-	}
-	glog.Infof("Took %f seconds to copy over tarball", time.Since(t).Seconds())
-
-	// extract the tarball to /var in the VM
-	if rr, err := r.Runner.RunCmd(exec.Command("sudo", "tar", "-I", "lz4", "-C", "/var", "-xvf", dest)); err != nil {
-		return errors.Wrapf(err, "extracting tarball: %s", rr.Output())
-	}
-
-	//  remove the tarball in the VM
-	if err := r.Runner.Remove(fa); err != nil {
-		glog.Infof("error removing tarball: %v", err)
+	if err := extractPreloadedTarball(r.Runner, k8sVersion, "docker"); err != nil {
+		return err
 	}
 	return r.Restart()
 }
@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package download
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blang/semver"
+	"github.com/golang/glog"
+	"github.com/hashicorp/go-getter"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// autoPauseWithChecksumURL gets the location of the auto-pause binary released with a minikube version
+func autoPauseWithChecksumURL(v semver.Version, archName string) string {
+	base := mirrored(fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/auto-pause-linux-%s", v, archName))
+	return fmt.Sprintf("%s?checksum=file:%s.sha256", base, base)
+}
+
+// AutoPausePath returns where the auto-pause binary of a minikube version is cached
+func AutoPausePath(v semver.Version, archName string) string {
+	return localpath.MakeMiniPath("cache", "linux", "auto-pause", fmt.Sprintf("v%s", v), archName, "auto-pause")
+}
+
+// AutoPause downloads the auto-pause binary run inside nodes, returning its path on the host
+func AutoPause(v semver.Version, archName string) (string, error) {
+	dst := AutoPausePath(v, archName)
+	if _, err := os.Stat(dst); err == nil {
+		glog.Infof("Found %s in cache, skipping download", dst)
+		return dst, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+		return "", errors.Wrapf(err, "mkdir %s", filepath.Dir(dst))
+	}
+
+	url := autoPauseWithChecksumURL(v, archName)
+	client := &getter.Client{
+		Src:     url,
+		Dst:     dst,
+		Mode:    getter.ClientModeFile,
+		Options: []getter.ClientOption{getter.WithProgress(DefaultProgressBar)},
+	}
+	glog.Infof("Downloading: %+v", client)
+	if err := client.Get(); err != nil {
+		return "", errors.Wrapf(err, "download failed: %s", url)
+	}
+	return dst, nil
+}
@@ -28,7 +28,7 @@ import (
 )
 
 func driverWithChecksumURL(name string, v semver.Version) string {
-	base := fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/%s", v, name)
+	base := mirrored(fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/%s", v, name))
 	return fmt.Sprintf("%s?checksum=file:%s.sha256", base, base)
 }
 
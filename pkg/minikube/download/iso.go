@@ -63,6 +63,18 @@ func LocalISOResource(isoURL string) string {
 	return fileURI(localISOPath(u))
 }
 
+// LocalISOPath returns where an ISO is stored locally
+func LocalISOPath(isoURL string) string {
+	u, err := url.Parse(isoURL)
+	if err != nil {
+		return isoURL
+	}
+	if u.Scheme == fileScheme {
+		return filepath.FromSlash(u.Path)
+	}
+	return localISOPath(u)
+}
+
 // fileURI returns a file:// URI for a path
 func fileURI(path string) string {
 	return "file://" + filepath.ToSlash(path)
@@ -77,9 +89,32 @@ func localISOPath(u *url.URL) string {
 	return filepath.Join(localpath.MiniPath(), "cache", "iso", path.Base(u.Path))
 }
 
+// mirroredISOURLs replaces the default ISO locations with the artifact mirror, if there is one
+func mirroredISOURLs(urls []string) []string {
+	if !MirrorEnabled() {
+		return urls
+	}
+	defaults := map[string]bool{}
+	for _, u := range DefaultISOURLs() {
+		defaults[u] = true
+	}
+
+	mirroredURLs := []string{}
+	for _, u := range urls {
+		if !defaults[u] {
+			mirroredURLs = append(mirroredURLs, u)
+		}
+	}
+	if len(mirroredURLs) == len(urls) {
+		return urls
+	}
+	return append(mirroredURLs, mirrored(DefaultISOURLs()[0]))
+}
+
 // ISO downloads and returns the path to the downloaded ISO
 func ISO(urls []string, skipChecksum bool) (string, error) {
 	errs := map[string]string{}
+	urls = mirroredISOURLs(urls)
 
 	for _, url := range urls {
 		err := downloadISO(url, skipChecksum)
@@ -124,6 +159,7 @@ func downloadISO(isoURL string, skipChecksum bool) error {
 	defer releaser.Release()
 
 	if _, err := os.Stat(dst); err == nil {
+		localpath.MarkUsed(dst)
 		return nil
 	}
 
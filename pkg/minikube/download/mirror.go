@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package download
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/hashicorp/go-getter"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// mirror is the base URL, or local directory, which artifacts are downloaded from instead of their default locations
+var mirror = ""
+
+// SetMirror sets the base URL, or local directory, which artifacts are downloaded from.
+// The mirror holds each artifact, along with its checksum file, at the path of its default location:
+// ISOs at /minikube/iso/, preloaded tarballs at /minikube-preloaded-volume-tarballs/,
+// Kubernetes binaries at /kubernetes-release/release/ and drivers at /kubernetes/minikube/releases/download/.
+// Images such as the kic base image are held as 'docker save' archives, for example /k8s-minikube/kicbase/v0.0.7.tar
+func SetMirror(m string) {
+	mirror = m
+}
+
+// MirrorEnabled returns whether artifacts are downloaded from a mirror
+func MirrorEnabled() bool {
+	return mirror != ""
+}
+
+// mirrored returns the location of an artifact within the mirror, or its default location if there is no mirror
+func mirrored(location string) string {
+	if mirror == "" {
+		return location
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		glog.Warningf("unable to mirror %s: %v", location, err)
+		return location
+	}
+	return mirrorBase() + u.Path
+}
+
+// mirrorBase returns the mirror as a URL, with local directories as file:// URLs
+func mirrorBase() string {
+	base := mirror
+	if !strings.Contains(base, "://") {
+		if abs, err := filepath.Abs(base); err == nil {
+			base = abs
+		}
+		base = fileURI(base)
+	}
+	return strings.TrimSuffix(base, "/")
+}
+
+// remoteExists returns whether an artifact exists at a remote location, which may be a file:// URL
+func remoteExists(location string) bool {
+	u, err := url.Parse(location)
+	if err != nil {
+		glog.Warningf("%s parse error: %v", location, err)
+		return false
+	}
+	if u.Scheme == fileScheme {
+		_, err := os.Stat(filepath.FromSlash(u.Path))
+		return err == nil
+	}
+
+	resp, err := http.Head(location)
+	if err != nil {
+		glog.Warningf("%s fetch error: %v", location, err)
+		return false
+	}
+	defer resp.Body.Close()
+
+	// note: err won't be set if it's a 404
+	if resp.StatusCode != 200 {
+		glog.Warningf("%s status code: %d", location, resp.StatusCode)
+		return false
+	}
+	return true
+}
+
+// fetch returns the contents of a small remote file, such as a checksum, which may be a file:// URL
+func fetch(location string) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "url.parse %q", location)
+	}
+	if u.Scheme == fileScheme {
+		return ioutil.ReadFile(filepath.FromSlash(u.Path))
+	}
+
+	resp, err := http.Get(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get %s", location)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("get %s: status code %d", location, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// imageArchivePath returns the path of an image archive within the mirror, such as /k8s-minikube/kicbase/v0.0.7.tar
+func imageArchivePath(img string) (string, error) {
+	tag, err := name.NewTag(strings.Split(img, "@")[0])
+	if err != nil {
+		return "", errors.Wrap(err, "parsing tag")
+	}
+	repo := strings.TrimPrefix(tag.RepositoryStr(), "library/")
+	return fmt.Sprintf("/%s/%s.tar", repo, tag.TagStr()), nil
+}
+
+// ImageArchiveCachePath returns where the archive of an image downloaded from the mirror is cached
+func ImageArchiveCachePath(img string) (string, error) {
+	p, err := imageArchivePath(img)
+	if err != nil {
+		return "", err
+	}
+	return imageArchiveCachePath(p), nil
+}
+
+// imageArchiveCachePath returns where an image archive at a path within the mirror is cached
+func imageArchiveCachePath(p string) string {
+	return localpath.MakeMiniPath("cache", "images-archives", filepath.FromSlash(strings.TrimPrefix(p, "/")))
+}
+
+// ImageArchive downloads the 'docker save' archive of an image from the mirror, returning its local path
+func ImageArchive(img string) (string, error) {
+	if mirror == "" {
+		return "", fmt.Errorf("no artifact mirror is configured")
+	}
+	p, err := imageArchivePath(img)
+	if err != nil {
+		return "", err
+	}
+
+	dst := imageArchiveCachePath(p)
+	if _, err := os.Stat(dst); err == nil {
+		glog.Infof("Found %s in cache, skipping download", dst)
+		localpath.MarkUsed(dst)
+		return dst, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", errors.Wrapf(err, "mkdir %s", filepath.Dir(dst))
+	}
+
+	out.T(out.FileDownload, "Downloading {{.image}} from the artifact mirror ...", out.V{"image": img})
+	src := mirrorBase() + p
+	client := &getter.Client{
+		Src:     fmt.Sprintf("%s?checksum=file:%s.sha256", src, src),
+		Dst:     dst,
+		Mode:    getter.ClientModeFile,
+		Options: []getter.ClientOption{getter.WithProgress(DefaultProgressBar)},
+	}
+
+	glog.Infof("Downloading: %+v", client)
+	if err := client.Get(); err != nil {
+		return "", errors.Wrapf(err, "download failed: %s", src)
+	}
+	return dst, nil
+}
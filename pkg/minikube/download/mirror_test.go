@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package download
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMirrored(t *testing.T) {
+	defer SetMirror("")
+
+	var tests = []struct {
+		description string
+		mirror      string
+		location    string
+		expected    string
+	}{
+		{
+			description: "no mirror",
+			location:    "https://storage.googleapis.com/minikube/iso/minikube-v1.9.0.iso",
+			expected:    "https://storage.googleapis.com/minikube/iso/minikube-v1.9.0.iso",
+		},
+		{
+			description: "http mirror",
+			mirror:      "http://mirror.local:8080/artifacts/",
+			location:    "https://storage.googleapis.com/minikube/iso/minikube-v1.9.0.iso",
+			expected:    "http://mirror.local:8080/artifacts/minikube/iso/minikube-v1.9.0.iso",
+		},
+		{
+			description: "directory mirror",
+			mirror:      "/srv/mirror",
+			location:    "https://github.com/kubernetes/minikube/releases/download/v1.9.0/docker-machine-driver-kvm2",
+			expected:    "file:///srv/mirror/kubernetes/minikube/releases/download/v1.9.0/docker-machine-driver-kvm2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			SetMirror(test.mirror)
+			got := mirrored(test.location)
+			if got != test.expected {
+				t.Errorf("mirrored(%q) = %q, want %q", test.location, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestMirroredBinaryURL(t *testing.T) {
+	SetMirror("http://mirror.local")
+	defer SetMirror("")
+
+	got, err := binaryWithChecksumURL("kubelet", "v1.18.0", "linux", "amd64")
+	if err != nil {
+		t.Fatalf("binaryWithChecksumURL: %v", err)
+	}
+	base := "http://mirror.local/kubernetes-release/release/v1.18.0/bin/linux/amd64/kubelet"
+	if got != base+"?checksum=file:"+base+".sha256" {
+		t.Errorf("unexpected url: %s", got)
+	}
+}
+
+func TestMirroredISOURLs(t *testing.T) {
+	SetMirror("http://mirror.local")
+	defer SetMirror("")
+
+	got := mirroredISOURLs(DefaultISOURLs())
+	expected := []string{mirrored(DefaultISOURLs()[0])}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("default urls = %v, want %v", got, expected)
+	}
+
+	custom := []string{"https://example.com/custom.iso"}
+	if got := mirroredISOURLs(custom); !reflect.DeepEqual(got, custom) {
+		t.Errorf("custom urls = %v, want %v", got, custom)
+	}
+}
+
+func TestImageArchivePath(t *testing.T) {
+	var tests = []struct {
+		img      string
+		expected string
+	}{
+		{"gcr.io/k8s-minikube/kicbase:v0.0.7@sha256:a6f288de0e5863cdeab711fa6bafa38ee7d8d285ca14216ecf84fcfb07c7d176", "/k8s-minikube/kicbase/v0.0.7.tar"},
+		{"haproxy:2.1.4", "/haproxy/2.1.4.tar"},
+	}
+	for _, test := range tests {
+		got, err := imageArchivePath(test.img)
+		if err != nil {
+			t.Fatalf("imageArchivePath(%q): %v", test.img, err)
+		}
+		if got != test.expected {
+			t.Errorf("imageArchivePath(%q) = %q, want %q", test.img, got, test.expected)
+		}
+	}
+}
+
+func TestMirroredPreloadChecksum(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	SetMirror(dir)
+	defer SetMirror("")
+
+	bucket := filepath.Join(dir, PreloadBucket)
+	if err := os.MkdirAll(bucket, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	tarball := filepath.Join(bucket, TarballName("v1.18.0", "docker"))
+	if err := ioutil.WriteFile(tarball, []byte("images"), 0644); err != nil {
+		t.Fatalf("write tarball: %v", err)
+	}
+	sum := md5.Sum([]byte("images"))
+	if err := ioutil.WriteFile(tarball+".checksum", []byte(fmt.Sprintf("%x  %s\n", sum, filepath.Base(tarball))), 0644); err != nil {
+		t.Fatalf("write checksum: %v", err)
+	}
+
+	if !remoteExists(remoteTarballURL("v1.18.0", "docker")) {
+		t.Errorf("expected the tarball to exist in the mirror")
+	}
+	got, err := remoteChecksum("v1.18.0", "docker")
+	if err != nil {
+		t.Fatalf("remoteChecksum: %v", err)
+	}
+	if !bytes.Equal(got, sum[:]) {
+		t.Errorf("checksum = %x, want %x", got, sum)
+	}
+}
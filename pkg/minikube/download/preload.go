@@ -17,16 +17,15 @@ limitations under the License.
 package download
 
 import (
-	"context"
 	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"path"
-
-	"cloud.google.com/go/storage"
-	"google.golang.org/api/option"
+	"strings"
 
 	"github.com/golang/glog"
 	"github.com/hashicorp/go-getter"
@@ -42,14 +41,53 @@ const (
 	PreloadBucket = "minikube-preloaded-volume-tarballs"
 )
 
-// returns name of the tarball
-func tarballName(k8sVersion string) string {
-	return fmt.Sprintf("preloaded-images-k8s-%s-%s-docker-overlay2.tar.lz4", PreloadVersion, k8sVersion)
+// preloadRuntime returns the name of a container runtime within preloaded tarball names, and its storage driver
+func preloadRuntime(containerRuntime string) (string, string, bool) {
+	switch containerRuntime {
+	case "docker":
+		return "docker", "overlay2", true
+	case "containerd":
+		return "containerd", "overlay2", true
+	case "crio", "cri-o":
+		return "cri-o", "overlay", true
+	}
+	return "", "", false
+}
+
+// TarballName returns name of the tarball for a kubernetes version and container runtime
+func TarballName(k8sVersion, containerRuntime string) string {
+	name, storageDriver, ok := preloadRuntime(containerRuntime)
+	if !ok {
+		name, storageDriver = containerRuntime, "unsupported"
+	}
+	return fmt.Sprintf("preloaded-images-k8s-%s-%s-%s-%s.tar.lz4", PreloadVersion, k8sVersion, name, storageDriver)
+}
+
+// PreloadStorageDriver returns the storage driver of the images in the preloaded tarball of a container runtime
+func PreloadStorageDriver(containerRuntime string) string {
+	_, storageDriver, _ := preloadRuntime(containerRuntime)
+	return storageDriver
+}
+
+// PreloadImageDirs returns the directories within /var holding the images of a container runtime,
+// which are the contents of its preloaded tarball along with the kubernetes binaries
+func PreloadImageDirs(containerRuntime string) []string {
+	name, storageDriver, _ := preloadRuntime(containerRuntime)
+	switch name {
+	case "containerd":
+		return []string{"./lib/containerd"}
+	case "cri-o":
+		return []string{"./lib/containers"}
+	}
+	return []string{
+		fmt.Sprintf("./lib/docker/%s", storageDriver),
+		"./lib/docker/image",
+	}
 }
 
 // returns the name of the checksum file
-func checksumName(k8sVersion string) string {
-	return fmt.Sprintf("%s.checksum", tarballName(k8sVersion))
+func checksumName(k8sVersion, containerRuntime string) string {
+	return fmt.Sprintf("%s.checksum", TarballName(k8sVersion, containerRuntime))
 }
 
 // returns target dir for all cached items related to preloading
@@ -58,45 +96,37 @@ func targetDir() string {
 }
 
 // PreloadChecksumPath returns path to checksum file
-func PreloadChecksumPath(k8sVersion string) string {
-	return path.Join(targetDir(), checksumName(k8sVersion))
+func PreloadChecksumPath(k8sVersion, containerRuntime string) string {
+	return path.Join(targetDir(), checksumName(k8sVersion, containerRuntime))
 }
 
 // TarballPath returns the path to the preloaded tarball
-func TarballPath(k8sVersion string) string {
-	return path.Join(targetDir(), tarballName(k8sVersion))
+func TarballPath(k8sVersion, containerRuntime string) string {
+	return path.Join(targetDir(), TarballName(k8sVersion, containerRuntime))
 }
 
 // remoteTarballURL returns the URL for the remote tarball in GCS
-func remoteTarballURL(k8sVersion string) string {
-	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", PreloadBucket, tarballName(k8sVersion))
+func remoteTarballURL(k8sVersion, containerRuntime string) string {
+	return mirrored(fmt.Sprintf("https://storage.googleapis.com/%s/%s", PreloadBucket, TarballName(k8sVersion, containerRuntime)))
 }
 
 // PreloadExists returns true if there is a preloaded tarball that can be used
 func PreloadExists(k8sVersion, containerRuntime string) bool {
-	if containerRuntime != "docker" {
+	if _, _, ok := preloadRuntime(containerRuntime); !ok {
 		return false
 	}
 
 	// Omit remote check if tarball exists locally
-	targetPath := TarballPath(k8sVersion)
+	targetPath := TarballPath(k8sVersion, containerRuntime)
 	if _, err := os.Stat(targetPath); err == nil {
-		if err := verifyChecksum(k8sVersion); err == nil {
+		if err := verifyChecksum(k8sVersion, containerRuntime); err == nil {
 			glog.Infof("Found %s in cache, no need to check remotely", targetPath)
 			return true
 		}
 	}
 
-	url := remoteTarballURL(k8sVersion)
-	resp, err := http.Head(url)
-	if err != nil {
-		glog.Warningf("%s fetch error: %v", url, err)
-		return false
-	}
-
-	// note: err won't be set if it's a 404
-	if resp.StatusCode != 200 {
-		glog.Warningf("%s status code: %d", url, resp.StatusCode)
+	url := remoteTarballURL(k8sVersion, containerRuntime)
+	if !remoteExists(url) {
 		return false
 	}
 
@@ -106,14 +136,15 @@ func PreloadExists(k8sVersion, containerRuntime string) bool {
 
 // Preload caches the preloaded images tarball on the host machine
 func Preload(k8sVersion, containerRuntime string) error {
-	if containerRuntime != "docker" {
+	if _, _, ok := preloadRuntime(containerRuntime); !ok {
 		return nil
 	}
-	targetPath := TarballPath(k8sVersion)
+	targetPath := TarballPath(k8sVersion, containerRuntime)
 
 	if _, err := os.Stat(targetPath); err == nil {
-		if err := verifyChecksum(k8sVersion); err == nil {
+		if err := verifyChecksum(k8sVersion, containerRuntime); err == nil {
 			glog.Infof("Found %s in cache, skipping downloading", targetPath)
+			localpath.MarkUsed(targetPath)
 			return nil
 		}
 	}
@@ -124,8 +155,8 @@ func Preload(k8sVersion, containerRuntime string) error {
 		return nil
 	}
 
-	out.T(out.FileDownload, "Downloading preloaded images tarball for k8s {{.version}} ...", out.V{"version": k8sVersion})
-	url := remoteTarballURL(k8sVersion)
+	out.T(out.FileDownload, "Downloading preloaded images tarball for k8s {{.version}} on {{.runtime}} ...", out.V{"version": k8sVersion, "runtime": containerRuntime})
+	url := remoteTarballURL(k8sVersion, containerRuntime)
 	client := &getter.Client{
 		Src:     url,
 		Dst:     targetPath,
@@ -142,44 +173,77 @@ func Preload(k8sVersion, containerRuntime string) error {
 		return err
 	}
 	// Save checksum file locally
-	if err := saveChecksumFile(k8sVersion); err != nil {
+	if err := saveChecksumFile(k8sVersion, containerRuntime); err != nil {
 		return errors.Wrap(err, "saving checksum file")
 	}
-	return verifyChecksum(k8sVersion)
+	return verifyChecksum(k8sVersion, containerRuntime)
 }
 
-func saveChecksumFile(k8sVersion string) error {
-	ctx := context.Background()
-	client, err := storage.NewClient(ctx, option.WithoutAuthentication())
+// saveChecksumFile saves the md5 checksum of the remote tarball. The artifact mirror holds it in a .checksum file,
+// as written by md5sum, while the checksum in GCS is part of the object metadata.
+func saveChecksumFile(k8sVersion, containerRuntime string) error {
+	checksum, err := remoteChecksum(k8sVersion, containerRuntime)
 	if err != nil {
-		return errors.Wrap(err, "getting storage client")
+		return err
 	}
-	attrs, err := client.Bucket(PreloadBucket).Object(tarballName(k8sVersion)).Attrs(ctx)
+	return ioutil.WriteFile(PreloadChecksumPath(k8sVersion, containerRuntime), checksum, 0644)
+}
+
+// remoteChecksum returns the md5 checksum of the remote tarball
+func remoteChecksum(k8sVersion, containerRuntime string) ([]byte, error) {
+	if MirrorEnabled() {
+		contents, err := fetch(remoteTarballURL(k8sVersion, containerRuntime) + ".checksum")
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching checksum file")
+		}
+		fields := strings.Fields(string(contents))
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty checksum file")
+		}
+		return hex.DecodeString(fields[0])
+	}
+
+	contents, err := fetch(fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o/%s?fields=md5Hash", PreloadBucket, TarballName(k8sVersion, containerRuntime)))
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching storage object metadata")
+	}
+	var attrs struct {
+		MD5Hash string `json:"md5Hash"`
+	}
+	if err := json.Unmarshal(contents, &attrs); err != nil {
+		return nil, errors.Wrap(err, "parsing storage object metadata")
+	}
+	return base64.StdEncoding.DecodeString(attrs.MD5Hash)
+}
+
+// SaveLocalChecksum saves the checksum of a locally generated tarball, so that it is used instead of the remote one
+func SaveLocalChecksum(k8sVersion, containerRuntime string) error {
+	contents, err := ioutil.ReadFile(TarballPath(k8sVersion, containerRuntime))
 	if err != nil {
-		return errors.Wrap(err, "getting storage object")
+		return errors.Wrap(err, "reading tarball")
 	}
-	checksum := attrs.MD5
-	return ioutil.WriteFile(PreloadChecksumPath(k8sVersion), checksum, 0644)
+	checksum := md5.Sum(contents)
+	return ioutil.WriteFile(PreloadChecksumPath(k8sVersion, containerRuntime), checksum[:], 0644)
 }
 
 // verifyChecksum returns true if the checksum of the local binary matches
 // the checksum of the remote binary
-func verifyChecksum(k8sVersion string) error {
+func verifyChecksum(k8sVersion, containerRuntime string) error {
 	// get md5 checksum of tarball path
-	contents, err := ioutil.ReadFile(TarballPath(k8sVersion))
+	contents, err := ioutil.ReadFile(TarballPath(k8sVersion, containerRuntime))
 	if err != nil {
 		return errors.Wrap(err, "reading tarball")
 	}
 	checksum := md5.Sum(contents)
 
-	remoteChecksum, err := ioutil.ReadFile(PreloadChecksumPath(k8sVersion))
+	remoteChecksum, err := ioutil.ReadFile(PreloadChecksumPath(k8sVersion, containerRuntime))
 	if err != nil {
 		return errors.Wrap(err, "reading checksum file")
 	}
 
 	// create a slice of checksum, which is [16]byte
 	if string(remoteChecksum) != string(checksum[:]) {
-		return fmt.Errorf("checksum of %s does not match remote checksum (%s != %s)", TarballPath(k8sVersion), string(remoteChecksum), string(checksum[:]))
+		return fmt.Errorf("checksum of %s does not match remote checksum (%s != %s)", TarballPath(k8sVersion, containerRuntime), string(remoteChecksum), string(checksum[:]))
 	}
 	return nil
 }
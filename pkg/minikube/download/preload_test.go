@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package download
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTarballName(t *testing.T) {
+	var tests = []struct {
+		runtime  string
+		expected string
+	}{
+		{"docker", "preloaded-images-k8s-v1-v1.18.0-docker-overlay2.tar.lz4"},
+		{"containerd", "preloaded-images-k8s-v1-v1.18.0-containerd-overlay2.tar.lz4"},
+		{"crio", "preloaded-images-k8s-v1-v1.18.0-cri-o-overlay.tar.lz4"},
+		{"cri-o", "preloaded-images-k8s-v1-v1.18.0-cri-o-overlay.tar.lz4"},
+	}
+	for _, test := range tests {
+		t.Run(test.runtime, func(t *testing.T) {
+			got := TarballName("v1.18.0", test.runtime)
+			if got != test.expected {
+				t.Errorf("TarballName(%q) = %q, want %q", test.runtime, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestPreloadUnsupportedRuntime(t *testing.T) {
+	if PreloadExists("v1.18.0", "rkt") {
+		t.Errorf("expected no preload for an unsupported runtime")
+	}
+	if err := Preload("v1.18.0", "rkt"); err != nil {
+		t.Errorf("expected preloading an unsupported runtime to be skipped, got %v", err)
+	}
+}
+
+func TestSaveLocalChecksum(t *testing.T) {
+	oldMinikubeHome := os.Getenv("MINIKUBE_HOME")
+	defer os.Setenv("MINIKUBE_HOME", oldMinikubeHome)
+
+	minikubeHome, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("error during creating tmp dir: %v", err)
+	}
+	defer os.RemoveAll(minikubeHome)
+	os.Setenv("MINIKUBE_HOME", minikubeHome)
+
+	tarball := TarballPath("v1.18.0", "containerd")
+	if err := os.MkdirAll(filepath.Dir(tarball), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := ioutil.WriteFile(tarball, []byte("images"), 0644); err != nil {
+		t.Fatalf("write tarball: %v", err)
+	}
+
+	if err := SaveLocalChecksum("v1.18.0", "containerd"); err != nil {
+		t.Fatalf("SaveLocalChecksum: %v", err)
+	}
+	if err := verifyChecksum("v1.18.0", "containerd"); err != nil {
+		t.Errorf("expected the local checksum to verify: %v", err)
+	}
+	if !PreloadExists("v1.18.0", "containerd") {
+		t.Errorf("expected the local tarball to be used")
+	}
+
+	if err := ioutil.WriteFile(tarball, []byte("changed"), 0644); err != nil {
+		t.Fatalf("write tarball: %v", err)
+	}
+	if err := verifyChecksum("v1.18.0", "containerd"); err == nil {
+		t.Errorf("expected a modified tarball to fail verification")
+	}
+}
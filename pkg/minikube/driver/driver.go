@@ -123,14 +123,15 @@ func BareMetal(name string) bool {
 	return name == None || name == Mock
 }
 
-// NeedsRoot returns true if driver needs to run with root privileges
+// NeedsRoot returns true if driver needs to run with root privileges, unless its daemon is rootless
 func NeedsRoot(name string) bool {
 	return name == None || name == Podman
 }
 
 // HasResourceLimits returns true if driver can set resource limits such as memory size or CPU count.
+// The daemon of a kic driver may still be unable to, see oci.SysInfo.
 func HasResourceLimits(name string) bool {
-	return !(name == None || name == Podman)
+	return name != None
 }
 
 // FlagHints are hints for what default options should be used for this driver
@@ -231,7 +232,7 @@ func SetLibvirtURI(v string) {
 // MachineName returns the name of the machine, as seen by the hypervisor given the cluster and node names
 func MachineName(cc config.ClusterConfig, n config.Node) string {
 	// For single node cluster, default to back to old naming
-	if len(cc.Nodes) == 1 || n.ControlPlane {
+	if len(cc.Nodes) == 1 || config.IsPrimaryControlPlane(cc, n) {
 		return cc.Name
 	}
 	return fmt.Sprintf("%s-%s", cc.Name, n.Name)
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/registry"
 )
 
@@ -83,6 +84,28 @@ func TestMachineType(t *testing.T) {
 	}
 }
 
+func TestMachineName(t *testing.T) {
+	cc := config.ClusterConfig{
+		Name: "p1",
+		Nodes: []config.Node{
+			{Name: "m01", ControlPlane: true, Worker: true},
+			{Name: "m02", ControlPlane: true, Worker: true},
+			{Name: "m03", Worker: true},
+		},
+	}
+	want := map[string]string{"m01": "p1", "m02": "p1-m02", "m03": "p1-m03"}
+	for _, n := range cc.Nodes {
+		if got := MachineName(cc, n); got != want[n.Name] {
+			t.Errorf("MachineName(%s) = %s, want %s", n.Name, got, want[n.Name])
+		}
+	}
+
+	single := config.ClusterConfig{Name: "p2", Nodes: []config.Node{{Name: "m01", ControlPlane: true}}}
+	if got := MachineName(single, single.Nodes[0]); got != "p2" {
+		t.Errorf("MachineName(single node) = %s, want p2", got)
+	}
+}
+
 func TestFlagDefaults(t *testing.T) {
 	expected := FlagHints{CacheImages: true}
 	if diff := cmp.Diff(FlagDefaults(VirtualBox), expected); diff != "" {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/golang/glog"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/problem"
 	"k8s.io/minikube/pkg/minikube/translate"
 )
@@ -47,12 +48,20 @@ const (
 
 // UsageT outputs a templated usage error and exits with error code 64
 func UsageT(format string, a ...out.V) {
+	if out.JSON {
+		register.PrintErrorExitCode(out.Fmt(format, a...), BadUsage)
+		os.Exit(BadUsage)
+	}
 	out.ErrT(out.Usage, format, a...)
 	os.Exit(BadUsage)
 }
 
 // WithCodeT outputs a templated fatal error message and exits with the supplied error code.
 func WithCodeT(code int, format string, a ...out.V) {
+	if out.JSON {
+		register.PrintErrorExitCode(out.Fmt(format, a...), code)
+		os.Exit(code)
+	}
 	out.FatalT(format, a...)
 	os.Exit(code)
 }
@@ -69,6 +78,10 @@ func WithError(msg string, err error) {
 
 // WithProblem outputs info related to a known problem and exits.
 func WithProblem(msg string, p *problem.Problem) {
+	if out.JSON {
+		register.PrintErrorExitCode(translate.T(msg), Config, p.Details())
+		os.Exit(Config)
+	}
 	out.ErrT(out.Empty, "")
 	out.FatalT(msg)
 	p.Display()
@@ -83,6 +96,9 @@ func WithProblem(msg string, p *problem.Problem) {
 // WithLogEntries outputs an error along with any important log entries, and exits.
 func WithLogEntries(msg string, err error, entries map[string][]string) {
 	displayError(msg, err)
+	if out.JSON {
+		os.Exit(Software)
+	}
 
 	for name, lines := range entries {
 		out.T(out.FailureType, "Problems detected in {{.entry}}:", out.V{"entry": name})
@@ -99,6 +115,10 @@ func WithLogEntries(msg string, err error, entries map[string][]string) {
 func displayError(msg string, err error) {
 	// use Warning because Error will display a duplicate message to stderr
 	glog.Warningf(fmt.Sprintf("%s: %v", msg, err))
+	if out.JSON {
+		register.PrintErrorExitCode(fmt.Sprintf("%s: %v", translate.T(msg), err), Software)
+		return
+	}
 	out.ErrT(out.Empty, "")
 	out.FatalT("{{.msg}}: {{.err}}", out.V{"msg": translate.T(msg), "err": err})
 	out.ErrT(out.Empty, "")
@@ -17,52 +17,48 @@ limitations under the License.
 package image
 
 import (
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/google/go-containerregistry/pkg/name"
-	"github.com/google/go-containerregistry/pkg/v1/tarball"
-	"github.com/juju/mutex"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/localpath"
-	"k8s.io/minikube/pkg/util/lock"
 )
 
-// DeleteFromCacheDir deletes tar files stored in cache dir
+// DeleteFromCacheDir deletes images from the image cache
 func DeleteFromCacheDir(images []string) error {
+	glog.Infof("Deleting images from the image cache: %s", images)
+	if err := RemoveFromCache(constants.ImageCacheDir, images); err != nil {
+		return err
+	}
+	// remove any images cached by older versions, which cached every image as a tarball
 	for _, image := range images {
-		path := filepath.Join(constants.ImageCacheDir, image)
-		path = localpath.SanitizeCacheDir(path)
-		glog.Infoln("Deleting image in cache at ", path)
-		if err := os.Remove(path); err != nil {
+		path := localpath.SanitizeCacheDir(filepath.Join(constants.ImageCacheDir, image))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
 			return err
 		}
 	}
-	return cleanImageCacheDir()
+	return CleanCacheDir()
 }
 
 // SaveToDir will cache images on the host
 //
-// The cache directory currently caches images using the imagename_tag
-// For example, k8s.gcr.io/kube-addon-manager:v6.5 would be
-// stored at $CACHE_DIR/k8s.gcr.io/kube-addon-manager_v6.5
+// The cache directory is a content-addressed store, which stores the layers that images share once.
+// Images are found within it by the names they were cached with.
 func SaveToDir(images []string, cacheDir string) error {
 	var g errgroup.Group
 	for _, image := range images {
 		image := image
 		g.Go(func() error {
-			dst := filepath.Join(cacheDir, image)
-			dst = localpath.SanitizeCacheDir(dst)
-			if err := saveToTarFile(image, dst); err != nil {
-				glog.Errorf("save image to file %q -> %q failed: %v", image, dst, err)
-				return errors.Wrapf(err, "caching image %q", dst)
+			if err := saveToCache(cacheDir, image); err != nil {
+				glog.Errorf("save image %q to %q failed: %v", image, cacheDir, err)
+				return errors.Wrapf(err, "caching image %q", image)
 			}
-			glog.Infof("save to tar file %s -> %s succeeded", image, dst)
+			glog.Infof("save image %s to %s succeeded", image, cacheDir)
 			return nil
 		})
 	}
@@ -73,37 +69,19 @@ func SaveToDir(images []string, cacheDir string) error {
 	return nil
 }
 
-// saveToTarFile caches an image
-func saveToTarFile(iname, rawDest string) error {
+// saveToCache caches an image, unless it is already cached
+func saveToCache(cacheDir string, iname string) error {
 	start := time.Now()
 	defer func() {
-		glog.Infof("cache image %q -> %q took %s", iname, rawDest, time.Since(start))
+		glog.Infof("cache image %q took %s", iname, time.Since(start))
 	}()
 
-	// OS-specific mangling of destination path
-	dst, err := localpath.DstPath(rawDest)
-	if err != nil {
-		return errors.Wrap(err, "getting destination path")
-	}
-
-	spec := lock.PathMutexSpec(dst)
-	spec.Timeout = 10 * time.Minute
-	glog.Infof("acquiring lock: %+v", spec)
-	releaser, err := mutex.Acquire(spec)
-	if err != nil {
-		return errors.Wrapf(err, "unable to acquire lock for %+v", spec)
-	}
-	defer releaser.Release()
-
-	if _, err := os.Stat(dst); err == nil {
-		glog.Infof("%s exists", dst)
+	if img, err := FindInCache(cacheDir, iname); err == nil {
+		glog.Infof("%s exists in the image cache", iname)
+		localpath.MarkUsed(BlobPath(cacheDir, img.Digest))
 		return nil
 	}
 
-	if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
-		return errors.Wrapf(err, "making cache image directory: %s", dst)
-	}
-
 	ref, err := name.ParseReference(iname, name.WeakValidation)
 	if err != nil {
 		return errors.Wrapf(err, "parsing image ref name for %s", iname)
@@ -120,37 +98,5 @@ func saveToTarFile(iname, rawDest string) error {
 		return errors.Wrapf(err, "nil image for %s", iname)
 	}
 
-	glog.Infoln("opening: ", dst)
-	f, err := ioutil.TempFile(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
-	if err != nil {
-		return err
-	}
-	defer func() {
-		// If we left behind a temp file, remove it.
-		_, err := os.Stat(f.Name())
-		if err == nil {
-			err = os.Remove(f.Name())
-			if err != nil {
-				glog.Warningf("failed to clean up the temp file %s: %v", f.Name(), err)
-			}
-		}
-	}()
-	tag, err := name.NewTag(iname, name.WeakValidation)
-	if err != nil {
-		return errors.Wrap(err, "newtag")
-	}
-	err = tarball.Write(tag, img, f)
-	if err != nil {
-		return errors.Wrap(err, "write")
-	}
-	err = f.Close()
-	if err != nil {
-		return errors.Wrap(err, "close")
-	}
-	err = os.Rename(f.Name(), dst)
-	if err != nil {
-		return errors.Wrap(err, "rename")
-	}
-	glog.Infof("%s exists", dst)
-	return nil
+	return AddToCache(cacheDir, iname, img)
 }
@@ -17,16 +17,13 @@ limitations under the License.
 package image
 
 import (
-	"context"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
-	"time"
 
-	"github.com/docker/docker/client"
 	"github.com/golang/glog"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -42,43 +39,6 @@ var defaultPlatform = v1.Platform{
 	OS:           "linux",
 }
 
-// DigestByDockerLib uses client by docker lib to return image digest
-// img.ID in as same as image digest
-func DigestByDockerLib(imgClient *client.Client, imgName string) string {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-	defer cancel()
-	imgClient.NegotiateAPIVersion(ctx)
-	img, _, err := imgClient.ImageInspectWithRaw(ctx, imgName)
-	if err != nil && !client.IsErrNotFound(err) {
-		glog.Infof("couldn't find image digest %s from local daemon: %v ", imgName, err)
-		return ""
-	}
-	return img.ID
-}
-
-// DigestByGoLib gets image digest uses go-containerregistry lib
-// which is 4s slower thabn local daemon per lookup https://github.com/google/go-containerregistry/issues/627
-func DigestByGoLib(imgName string) string {
-	ref, err := name.ParseReference(imgName, name.WeakValidation)
-	if err != nil {
-		glog.Infof("error parsing image name %s ref %v ", imgName, err)
-		return ""
-	}
-
-	img, err := retrieveImage(ref)
-	if err != nil {
-		glog.Infof("error retrieve Image %s ref %v ", imgName, err)
-		return ""
-	}
-
-	cf, err := img.ConfigName()
-	if err != nil {
-		glog.Infof("error getting Image config name %s %v ", imgName, err)
-		return cf.Hex
-	}
-	return cf.Hex
-}
-
 // WriteImageToDaemon write img to the local docker daemon
 func WriteImageToDaemon(img string) error {
 	glog.Infof("Writing %s to local daemon", img)
@@ -108,6 +68,28 @@ func WriteImageToDaemon(img string) error {
 	return err
 }
 
+// SaveFromDaemon saves an image from the local daemon to an archive, as written by 'docker save'
+func SaveFromDaemon(img string, archive string) error {
+	glog.Infof("Saving %s from local daemon to %s", img, archive)
+	if out, err := exec.Command("docker", "save", "-o", archive, img).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "docker save: %s", out)
+	}
+	return nil
+}
+
+// LoadArchiveToDaemon loads an image archive, as written by 'docker save', into the local daemon unless the image is already there
+func LoadArchiveToDaemon(img string, archive string) error {
+	if err := exec.Command("docker", "image", "inspect", img).Run(); err == nil {
+		glog.Infof("Found %s in local docker daemon, skipping load", img)
+		return nil
+	}
+	glog.Infof("Loading %s from %s to local daemon", img, archive)
+	if out, err := exec.Command("docker", "load", "-i", archive).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "docker load: %s", out)
+	}
+	return nil
+}
+
 func retrieveImage(ref name.Reference) (v1.Image, error) {
 	glog.Infof("retrieving image: %+v", ref)
 	img, err := daemon.Image(ref)
@@ -131,7 +113,8 @@ func retrieveImage(ref name.Reference) (v1.Image, error) {
 	return img, err
 }
 
-func cleanImageCacheDir() error {
+// CleanCacheDir removes the empty directories of the image cache directory
+func CleanCacheDir() error {
 	err := filepath.Walk(constants.ImageCacheDir, func(path string, info os.FileInfo, err error) error {
 		// If error is not nil, it's because the path was already deleted and doesn't exist
 		// Move on to next path
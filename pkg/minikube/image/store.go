@@ -0,0 +1,458 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/juju/mutex"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/util/lock"
+)
+
+// The image cache is an OCI image layout: every manifest, config and layer is stored once as a blob named by its digest,
+// and index.json lists the manifests of the cached images, annotated with the names of the images.
+const (
+	indexFile         = "index.json"
+	layoutFile        = "oci-layout"
+	refNameAnnotation = "org.opencontainers.image.ref.name"
+	// pendingDir lists the blobs of the images being added, which are not in the index yet but are not unused either
+	pendingDir = "pending"
+	// abandonedAge is the age after which a pending add or a partially written blob was left behind by a process which died
+	abandonedAge = 24 * time.Hour
+)
+
+// CachedImage is an image within the image cache
+type CachedImage struct {
+	Name string
+	// Digest is the digest of the manifest of the image
+	Digest v1.Hash
+	// ID is the digest of the config of the image, by which container runtimes know it
+	ID v1.Hash
+	// Layers are the digests of the compressed layers of the image
+	Layers []v1.Hash
+	// Size is the size of the manifest, config and layers of the image, including the layers shared with other images
+	Size int64
+	// Manifest is the docker-save manifest.json which loads the image from its config and layers, named by their hex digests
+	Manifest []byte
+}
+
+// Blobs returns the digests of the blobs which a container runtime needs to load the image
+func (img *CachedImage) Blobs() []v1.Hash {
+	return append([]v1.Hash{img.ID}, img.Layers...)
+}
+
+// BlobPath returns the path of a blob within the image cache
+func BlobPath(cacheDir string, h v1.Hash) string {
+	return filepath.Join(cacheDir, "blobs", h.Algorithm, h.Hex)
+}
+
+// IsCacheMetadata returns whether a path within the image cache is its index or blobs, rather than an image cached by an older version
+func IsCacheMetadata(cacheDir string, path string) bool {
+	switch path {
+	case filepath.Join(cacheDir, "blobs"), filepath.Join(cacheDir, indexFile), filepath.Join(cacheDir, indexFile+".tmp"), filepath.Join(cacheDir, layoutFile), filepath.Join(cacheDir, pendingDir):
+		return true
+	}
+	return false
+}
+
+// ListCache returns the images within the image cache
+func ListCache(cacheDir string) ([]CachedImage, error) {
+	index, err := readIndex(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	images := []CachedImage{}
+	for _, desc := range index.Manifests {
+		img, err := cachedImage(cacheDir, desc)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, *img)
+	}
+	return images, nil
+}
+
+// FindInCache returns an image within the image cache
+func FindInCache(cacheDir string, iname string) (*CachedImage, error) {
+	index, err := readIndex(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, desc := range index.Manifests {
+		if desc.Annotations[refNameAnnotation] == iname {
+			return cachedImage(cacheDir, desc)
+		}
+	}
+	return nil, fmt.Errorf("%s is not in the image cache", iname)
+}
+
+// cachedImage returns the image of a manifest within the image cache
+func cachedImage(cacheDir string, desc v1.Descriptor) (*CachedImage, error) {
+	iname := desc.Annotations[refNameAnnotation]
+	f, err := os.Open(BlobPath(cacheDir, desc.Digest))
+	if err != nil {
+		return nil, errors.Wrapf(err, "manifest of %s", iname)
+	}
+	defer f.Close()
+	m, err := v1.ParseManifest(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing manifest of %s", iname)
+	}
+
+	img := &CachedImage{Name: iname, Digest: desc.Digest, ID: m.Config.Digest, Size: desc.Size + m.Config.Size}
+	files := []string{}
+	for _, l := range m.Layers {
+		img.Layers = append(img.Layers, l.Digest)
+		img.Size += l.Size
+		files = append(files, l.Digest.Hex)
+	}
+
+	// the format of tarball.Write, which 'docker load', 'ctr images import' and 'podman load' all accept
+	archive := tarball.Descriptor{Config: m.Config.Digest.Hex, Layers: files}
+	if tag, err := name.NewTag(iname, name.WeakValidation); err == nil {
+		archive.RepoTags = []string{tag.String()}
+	}
+	if img.Manifest, err = json.Marshal(tarball.Manifest{archive}); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// AddToCache writes an image into the image cache, replacing any image of the same name
+func AddToCache(cacheDir string, iname string, img v1.Image) error {
+	layers, err := img.Layers()
+	if err != nil {
+		return errors.Wrap(err, "layers")
+	}
+
+	// the blobs are written without holding the lock of the cache, so that images are added concurrently,
+	// and are marked as pending until the index lists them, so that they are not removed as unused meanwhile
+	blobs, err := imageBlobs(img, layers)
+	if err != nil {
+		return err
+	}
+	pending, err := markPending(cacheDir, blobs)
+	if err != nil {
+		return errors.Wrap(err, "marking blobs as pending")
+	}
+	defer os.Remove(pending)
+
+	for _, l := range layers {
+		d, err := l.Digest()
+		if err != nil {
+			return errors.Wrap(err, "layer digest")
+		}
+		r, err := l.Compressed()
+		if err != nil {
+			return errors.Wrap(err, "layer")
+		}
+		err = writeBlob(cacheDir, d, r)
+		r.Close()
+		if err != nil {
+			return errors.Wrapf(err, "writing layer %s", d)
+		}
+	}
+
+	cfgName, err := img.ConfigName()
+	if err != nil {
+		return errors.Wrap(err, "config name")
+	}
+	cfg, err := img.RawConfigFile()
+	if err != nil {
+		return errors.Wrap(err, "config")
+	}
+	if err := writeBlob(cacheDir, cfgName, bytes.NewReader(cfg)); err != nil {
+		return errors.Wrap(err, "writing config")
+	}
+
+	d, err := img.Digest()
+	if err != nil {
+		return errors.Wrap(err, "digest")
+	}
+	manifest, err := img.RawManifest()
+	if err != nil {
+		return errors.Wrap(err, "manifest")
+	}
+	if err := writeBlob(cacheDir, d, bytes.NewReader(manifest)); err != nil {
+		return errors.Wrap(err, "writing manifest")
+	}
+	mt, err := img.MediaType()
+	if err != nil {
+		return errors.Wrap(err, "media type")
+	}
+
+	return updateIndex(cacheDir, func(index *v1.IndexManifest) {
+		manifests := []v1.Descriptor{}
+		for _, desc := range index.Manifests {
+			if desc.Annotations[refNameAnnotation] != iname {
+				manifests = append(manifests, desc)
+			}
+		}
+		index.Manifests = append(manifests, v1.Descriptor{
+			MediaType:   mt,
+			Size:        int64(len(manifest)),
+			Digest:      d,
+			Annotations: map[string]string{refNameAnnotation: iname},
+		})
+	})
+}
+
+// imageBlobs returns the digests of the manifest, config and layers of an image
+func imageBlobs(img v1.Image, layers []v1.Layer) ([]v1.Hash, error) {
+	d, err := img.Digest()
+	if err != nil {
+		return nil, errors.Wrap(err, "digest")
+	}
+	cfgName, err := img.ConfigName()
+	if err != nil {
+		return nil, errors.Wrap(err, "config name")
+	}
+	blobs := []v1.Hash{d, cfgName}
+	for _, l := range layers {
+		ld, err := l.Digest()
+		if err != nil {
+			return nil, errors.Wrap(err, "layer digest")
+		}
+		blobs = append(blobs, ld)
+	}
+	return blobs, nil
+}
+
+// markPending records the blobs of an image being added, holding the lock of the cache so that
+// a concurrent removal of unused blobs either completes before, or sees them. Returns the path of the record.
+func markPending(cacheDir string, blobs []v1.Hash) (string, error) {
+	releaser, err := lockCache(cacheDir)
+	if err != nil {
+		return "", err
+	}
+	defer releaser.Release()
+
+	dir := filepath.Join(cacheDir, pendingDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile(dir, "*.json")
+	if err != nil {
+		return "", err
+	}
+	if err := json.NewEncoder(f).Encode(blobs); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// pendingBlobs returns the paths of the blobs of the images being added, removing the records of adds which were abandoned
+func pendingBlobs(cacheDir string) (map[string]bool, error) {
+	pending := map[string]bool{}
+	dir := filepath.Join(cacheDir, pendingDir)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pending, nil
+		}
+		return nil, err
+	}
+	for _, fi := range files {
+		p := filepath.Join(dir, fi.Name())
+		if time.Since(fi.ModTime()) > abandonedAge {
+			glog.Infof("Removing abandoned pending add %s", p)
+			os.Remove(p)
+			continue
+		}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		var blobs []v1.Hash
+		if err := json.Unmarshal(data, &blobs); err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", p)
+		}
+		for _, h := range blobs {
+			pending[BlobPath(cacheDir, h)] = true
+		}
+	}
+	return pending, nil
+}
+
+// RemoveFromCache removes images from the image cache, along with the blobs which no other image uses
+func RemoveFromCache(cacheDir string, images []string) error {
+	remove := map[string]bool{}
+	for _, img := range images {
+		remove[img] = true
+	}
+	err := updateIndex(cacheDir, func(index *v1.IndexManifest) {
+		manifests := []v1.Descriptor{}
+		for _, desc := range index.Manifests {
+			if !remove[desc.Annotations[refNameAnnotation]] {
+				manifests = append(manifests, desc)
+			}
+		}
+		index.Manifests = manifests
+	})
+	if err != nil {
+		return err
+	}
+	return removeUnusedBlobs(cacheDir)
+}
+
+// removeUnusedBlobs removes the blobs of the image cache which no image uses
+func removeUnusedBlobs(cacheDir string) error {
+	releaser, err := lockCache(cacheDir)
+	if err != nil {
+		return err
+	}
+	defer releaser.Release()
+
+	images, err := ListCache(cacheDir)
+	if err != nil {
+		return err
+	}
+	used, err := pendingBlobs(cacheDir)
+	if err != nil {
+		return err
+	}
+	for _, img := range images {
+		used[BlobPath(cacheDir, img.Digest)] = true
+		for _, h := range img.Blobs() {
+			used[BlobPath(cacheDir, h)] = true
+		}
+	}
+
+	return filepath.Walk(filepath.Join(cacheDir, "blobs"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || used[path] {
+			return nil
+		}
+		// a blob being written by an add, unless it was abandoned
+		if strings.HasSuffix(path, ".tmp") && time.Since(info.ModTime()) < abandonedAge {
+			return nil
+		}
+		glog.Infof("Removing unused blob %s", path)
+		return os.Remove(path)
+	})
+}
+
+// writeBlob writes a blob into the image cache, unless it is already there, verifying its digest
+func writeBlob(cacheDir string, h v1.Hash, r io.Reader) error {
+	dst := BlobPath(cacheDir, h)
+	if _, err := os.Stat(dst); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so that a partially written blob is never mistaken for a whole one
+	f, err := ioutil.TempFile(filepath.Dir(dst), h.Hex+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	got, _, err := v1.SHA256(io.TeeReader(r, f))
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if got != h {
+		return fmt.Errorf("digest mismatch: got %s, want %s", got, h)
+	}
+	return os.Rename(f.Name(), dst)
+}
+
+// readIndex reads the index of the image cache, which is empty if there is none yet
+func readIndex(cacheDir string) (*v1.IndexManifest, error) {
+	f, err := os.Open(filepath.Join(cacheDir, indexFile))
+	if os.IsNotExist(err) {
+		return &v1.IndexManifest{SchemaVersion: 2}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	index, err := v1.ParseIndexManifest(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing image cache index")
+	}
+	return index, nil
+}
+
+// updateIndex updates the index of the image cache, holding a lock so that concurrent updates are not lost
+func updateIndex(cacheDir string, update func(*v1.IndexManifest)) error {
+	releaser, err := lockCache(cacheDir)
+	if err != nil {
+		return err
+	}
+	defer releaser.Release()
+
+	index, err := readIndex(cacheDir)
+	if err != nil {
+		return err
+	}
+	update(index)
+	data, err := json.MarshalIndent(index, "", "   ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(cacheDir, layoutFile), []byte(`{"imageLayoutVersion": "1.0.0"}`), 0644); err != nil {
+		return err
+	}
+	tmp := filepath.Join(cacheDir, indexFile+".tmp")
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(cacheDir, indexFile))
+}
+
+// lockCache acquires the lock of the index of the image cache
+func lockCache(cacheDir string) (mutex.Releaser, error) {
+	spec := lock.PathMutexSpec(filepath.Join(cacheDir, indexFile))
+	spec.Timeout = 10 * time.Minute
+	glog.Infof("acquiring lock: %+v", spec)
+	releaser, err := mutex.Acquire(spec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to acquire lock for %+v", spec)
+	}
+	return releaser, nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// countBlobs returns the number of blobs within the image cache
+func countBlobs(t *testing.T, cacheDir string) int {
+	files, err := ioutil.ReadDir(filepath.Join(cacheDir, "blobs", "sha256"))
+	if err != nil {
+		t.Fatalf("reading blobs: %v", err)
+	}
+	return len(files)
+}
+
+func TestCache(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "image-cache")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	base, err := random.Image(64, 2)
+	if err != nil {
+		t.Fatalf("random image: %v", err)
+	}
+	layer, err := random.Layer(64, types.DockerLayer)
+	if err != nil {
+		t.Fatalf("random layer: %v", err)
+	}
+	app, err := mutate.AppendLayers(base, layer)
+	if err != nil {
+		t.Fatalf("append layers: %v", err)
+	}
+
+	if err := AddToCache(cacheDir, "base:1", base); err != nil {
+		t.Fatalf("AddToCache(base): %v", err)
+	}
+	if err := AddToCache(cacheDir, "app:1", app); err != nil {
+		t.Fatalf("AddToCache(app): %v", err)
+	}
+	// the layers of base are stored once: 3 layers, 2 configs and 2 manifests
+	if got := countBlobs(t, cacheDir); got != 7 {
+		t.Errorf("blobs = %d, want 7", got)
+	}
+
+	img, err := FindInCache(cacheDir, "app:1")
+	if err != nil {
+		t.Fatalf("FindInCache: %v", err)
+	}
+	id, err := app.ConfigName()
+	if err != nil {
+		t.Fatalf("config name: %v", err)
+	}
+	if img.ID != id || len(img.Layers) != 3 {
+		t.Errorf("FindInCache(app:1) = %+v, want ID %s and 3 layers", img, id)
+	}
+	var m tarball.Manifest
+	if err := json.Unmarshal(img.Manifest, &m); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	if len(m) != 1 || m[0].Config != id.Hex || len(m[0].Layers) != 3 || len(m[0].RepoTags) != 1 {
+		t.Errorf("unexpected manifest: %+v", m)
+	}
+	if _, err := FindInCache(cacheDir, "missing:1"); err == nil {
+		t.Errorf("FindInCache(missing:1) should fail")
+	}
+
+	// replacing an image of the same name does not list it twice
+	if err := AddToCache(cacheDir, "app:1", app); err != nil {
+		t.Fatalf("AddToCache(app): %v", err)
+	}
+	images, err := ListCache(cacheDir)
+	if err != nil {
+		t.Fatalf("ListCache: %v", err)
+	}
+	if len(images) != 2 {
+		t.Errorf("ListCache = %+v, want 2 images", images)
+	}
+
+	if err := RemoveFromCache(cacheDir, []string{"app:1"}); err != nil {
+		t.Fatalf("RemoveFromCache: %v", err)
+	}
+	// only the blobs of app which base does not share are removed
+	if got := countBlobs(t, cacheDir); got != 4 {
+		t.Errorf("blobs = %d, want 4", got)
+	}
+	if _, err := FindInCache(cacheDir, "base:1"); err != nil {
+		t.Errorf("FindInCache(base:1): %v", err)
+	}
+}
+
+func TestWriteBlobVerifiesDigest(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "image-cache")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	h := v1.Hash{Algorithm: "sha256", Hex: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"}
+	if err := writeBlob(cacheDir, h, bytes.NewReader([]byte("not empty"))); err == nil {
+		t.Errorf("writeBlob should fail for the wrong content")
+	}
+	if _, err := os.Stat(BlobPath(cacheDir, h)); !os.IsNotExist(err) {
+		t.Errorf("the wrong content should not be stored: %v", err)
+	}
+	if err := writeBlob(cacheDir, h, bytes.NewReader(nil)); err != nil {
+		t.Errorf("writeBlob: %v", err)
+	}
+}
+
+func TestRemoveKeepsPendingBlobs(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "image-cache")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	old, err := random.Image(64, 1)
+	if err != nil {
+		t.Fatalf("random image: %v", err)
+	}
+	if err := AddToCache(cacheDir, "old:1", old); err != nil {
+		t.Fatalf("AddToCache(old): %v", err)
+	}
+
+	// an add in flight, which has written a layer and is writing another, but not updated the index yet
+	img, err := random.Image(64, 2)
+	if err != nil {
+		t.Fatalf("random image: %v", err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		t.Fatalf("layers: %v", err)
+	}
+	blobs, err := imageBlobs(img, layers)
+	if err != nil {
+		t.Fatalf("imageBlobs: %v", err)
+	}
+	pending, err := markPending(cacheDir, blobs)
+	if err != nil {
+		t.Fatalf("markPending: %v", err)
+	}
+	d, err := layers[0].Digest()
+	if err != nil {
+		t.Fatalf("digest: %v", err)
+	}
+	r, err := layers[0].Compressed()
+	if err != nil {
+		t.Fatalf("compressed: %v", err)
+	}
+	if err := writeBlob(cacheDir, d, r); err != nil {
+		t.Fatalf("writeBlob: %v", err)
+	}
+	r.Close()
+	tmp := filepath.Join(cacheDir, "blobs", "sha256", "partial.123.tmp")
+	if err := ioutil.WriteFile(tmp, []byte("partial"), 0644); err != nil {
+		t.Fatalf("writing tmp: %v", err)
+	}
+
+	if err := RemoveFromCache(cacheDir, []string{"old:1"}); err != nil {
+		t.Fatalf("RemoveFromCache: %v", err)
+	}
+	if _, err := os.Stat(BlobPath(cacheDir, d)); err != nil {
+		t.Errorf("pending blob was removed: %v", err)
+	}
+	if _, err := os.Stat(tmp); err != nil {
+		t.Errorf("partially written blob was removed: %v", err)
+	}
+	if got := countBlobs(t, cacheDir); got != 2 {
+		t.Errorf("blobs = %d, want the pending and partial ones", got)
+	}
+
+	// once the add is no longer pending, an unlisted blob is unused
+	if err := os.Remove(pending); err != nil {
+		t.Fatalf("removing pending: %v", err)
+	}
+	if err := RemoveFromCache(cacheDir, nil); err != nil {
+		t.Fatalf("RemoveFromCache: %v", err)
+	}
+	if _, err := os.Stat(BlobPath(cacheDir, d)); !os.IsNotExist(err) {
+		t.Errorf("unused blob was kept: %v", err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
@@ -54,6 +55,15 @@ func MakeMiniPath(fileName ...string) string {
 	return filepath.Join(args...)
 }
 
+// MarkUsed records that a cached file was used, by setting its modification time to now.
+// The modification time of a cached file is its last used time, which pruning the cache relies upon.
+func MarkUsed(path string) {
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		glog.Warningf("unable to mark %s used: %v", path, err)
+	}
+}
+
 // MachinePath returns the Minikube machine path of a machine
 func MachinePath(machine string, miniHome ...string) string {
 	miniPath := MiniPath()
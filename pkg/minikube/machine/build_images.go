@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"os"
+	"os/exec"
+	"path"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// buildRoot is where build contexts are copied to on the nodes
+var buildRoot = path.Join(vmpath.GuestPersistentDir, "build")
+
+// BuildImage builds a context directory on the host, using the named Dockerfile within it, into an image with the given name,
+// on every running node of a profile. The image is built by the container runtime of the node, so pods may use it without it being pushed.
+func BuildImage(api libmachine.API, cc *config.ClusterConfig, src string, file string, name string) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return errors.Wrapf(err, "stat %s", src)
+	}
+	if !fi.IsDir() {
+		return errors.Errorf("%s is not a directory", src)
+	}
+
+	dir := path.Join(buildRoot, localSafeName(name))
+	return forEachRunningNode(api, cc, func(n config.Node, runner command.Runner, cr cruntime.Manager) error {
+		if err := removeBuildContext(runner, dir); err != nil {
+			return err
+		}
+		if _, err := runner.RunCmd(exec.Command("sudo", "mkdir", "-p", buildRoot)); err != nil {
+			return errors.Wrap(err, "creating build root")
+		}
+		defer func() {
+			if err := removeBuildContext(runner, dir); err != nil {
+				glog.Warningf("unable to remove build context: %v", err)
+			}
+		}()
+
+		glog.Infof("Copying build context %s to %s", src, dir)
+		if err := CopyTo(runner, src, dir); err != nil {
+			return errors.Wrap(err, "transferring build context")
+		}
+		return cr.BuildImage(dir, file, name)
+	})
+}
+
+// removeBuildContext removes a build context from a node
+func removeBuildContext(runner command.Runner, dir string) error {
+	if rr, err := runner.RunCmd(exec.Command("sudo", "rm", "-rf", dir)); err != nil {
+		return errors.Wrapf(err, "removing %s: %s", dir, rr.Output())
+	}
+	return nil
+}
@@ -18,17 +18,15 @@ package machine
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
 	"path"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/docker/docker/client"
 	"github.com/docker/machine/libmachine/state"
 	"github.com/golang/glog"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 	"k8s.io/minikube/pkg/minikube/assets"
@@ -46,9 +44,15 @@ import (
 // loadRoot is where images should be loaded from within the guest VM
 var loadRoot = path.Join(vmpath.GuestPersistentDir, "images")
 
+// blobRoot is where the blobs of cached images are kept within the guest VM
+var blobRoot = path.Join(loadRoot, "blobs", "sha256")
+
 // loadImageLock is used to serialize image loads to avoid overloading the guest VM
 var loadImageLock sync.Mutex
 
+// blobLocks serialize the transfers of each blob, which images may share
+var blobLocks sync.Map
+
 // CacheImagesForBootstrapper will cache images for a bootstrapper
 func CacheImagesForBootstrapper(imageRepository string, version string, clusterBootstrapper string) error {
 	images, err := bootstrapper.GetCachedImageList(imageRepository, version, clusterBootstrapper)
@@ -66,7 +70,7 @@ func CacheImagesForBootstrapper(imageRepository string, version string, clusterB
 // LoadImages loads previously cached images into the container runtime
 func LoadImages(cc *config.ClusterConfig, runner command.Runner, images []string, cacheDir string) error {
 	// Skip loading images if images already exist
-	if imagesPreloaded(runner, images) {
+	if imagesPreloaded(runner, cc.KubernetesConfig.ContainerRuntime, images) {
 		glog.Infof("Images are preloaded, skipping loading")
 		return nil
 	}
@@ -84,42 +88,91 @@ func LoadImages(cc *config.ClusterConfig, runner command.Runner, images []string
 		return errors.Wrap(err, "runtime")
 	}
 
-	imgClient, err := client.NewClientWithOpts(client.FromEnv) // image client
-	if err != nil {
-		glog.Infof("couldn't get a local image daemon which might be ok: %v", err)
-		imgClient = nil
-	}
-
-	for _, image := range images {
-		image := image
+	for _, name := range images {
+		name := name
 		g.Go(func() error {
-			err := needsTransfer(imgClient, image, cr)
+			img, err := image.FindInCache(cacheDir, name)
+			if err != nil {
+				return err
+			}
+			err = needsTransfer(img, cr)
 			if err == nil {
 				return nil
 			}
-			glog.Infof("%q needs transfer: %v", image, err)
-			return transferAndLoadImage(runner, cc.KubernetesConfig, image, cacheDir)
+			glog.Infof("%q needs transfer: %v", name, err)
+			return transferAndLoadImage(runner, cr, img, cacheDir)
 		})
 	}
 	if err := g.Wait(); err != nil {
 		return errors.Wrap(err, "loading cached images")
 	}
 	glog.Infoln("Successfully loaded all cached images")
+
+	if err := pruneBlobs(runner, cacheDir); err != nil {
+		glog.Warningf("unable to remove unused blobs from the node: %v", err)
+	}
 	return nil
 }
 
-func imagesPreloaded(runner command.Runner, images []string) bool {
-	rr, err := runner.RunCmd(exec.Command("docker", "images", "--format", "{{.Repository}}:{{.Tag}}"))
+// pruneBlobs removes the blobs on the node which no image of the image cache uses,
+// such as the layers of images since removed with 'minikube cache delete'
+func pruneBlobs(runner command.Runner, cacheDir string) error {
+	if _, err := runner.RunCmd(exec.Command("sudo", "test", "-d", blobRoot)); err != nil {
+		// no blob was ever transferred
+		return nil
+	}
+	images, err := image.ListCache(cacheDir)
+	if err != nil {
+		return errors.Wrap(err, "listing cached images")
+	}
+	used := map[string]bool{}
+	for _, img := range images {
+		for _, h := range img.Blobs() {
+			used[h.Hex] = true
+		}
+	}
+
+	rr, err := runner.RunCmd(exec.Command("sudo", "ls", "-1", blobRoot))
+	if err != nil {
+		return errors.Wrapf(err, "listing blobs: %s", rr.Output())
+	}
+	var unused []string
+	for _, f := range strings.Fields(rr.Stdout.String()) {
+		// a blob which is being transferred is not in the way
+		if used[f] || strings.HasSuffix(f, ".partial") {
+			continue
+		}
+		unused = append(unused, path.Join(blobRoot, f))
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+	glog.Infof("Removing %d unused blobs from the node", len(unused))
+	if rr, err := runner.RunCmd(exec.Command("sudo", append([]string{"rm", "-f"}, unused...)...)); err != nil {
+		return errors.Wrapf(err, "removing blobs: %s", rr.Output())
+	}
+	return nil
+}
+
+func imagesPreloaded(runner command.Runner, containerRuntime string, images []string) bool {
+	cr, err := cruntime.New(cruntime.Config{Type: containerRuntime, Runner: runner})
 	if err != nil {
 		return false
 	}
+	list, err := cr.ListImages()
+	if err != nil {
+		glog.Infof("unable to list %s images: %v", cr.Name(), err)
+		return false
+	}
+	glog.Infof("Got preloaded images: %s", list)
+
 	preloadedImages := map[string]struct{}{}
-	for _, i := range strings.Split(rr.Stdout.String(), "\n") {
+	for _, i := range list {
 		preloadedImages[i] = struct{}{}
+		// CRI runtimes name images of Docker Hub in full, unlike the image lists
+		preloadedImages[strings.TrimPrefix(strings.TrimPrefix(i, "docker.io/"), "library/")] = struct{}{}
 	}
 
-	glog.Infof("Got preloaded images: %s", rr.Output())
-
 	// Make sure images == imgs
 	for _, i := range images {
 		if _, ok := preloadedImages[i]; !ok {
@@ -131,24 +184,10 @@ func imagesPreloaded(runner command.Runner, images []string) bool {
 }
 
 // needsTransfer returns an error if an image needs to be retransfered
-func needsTransfer(imgClient *client.Client, imgName string, cr cruntime.Manager) error {
-	imgDgst := ""         // for instance sha256:7c92a2c6bbcb6b6beff92d0a940779769c2477b807c202954c537e2e0deb9bed
-	if imgClient != nil { // if possible try to get img digest from Client lib which is 4s faster.
-		imgDgst = image.DigestByDockerLib(imgClient, imgName)
-		if imgDgst != "" {
-			if !cr.ImageExists(imgName, imgDgst) {
-				return fmt.Errorf("%q does not exist at hash %q in container runtime", imgName, imgDgst)
-			}
-			return nil
-		}
-	}
-	// if not found with method above try go-container lib (which is 4s slower)
-	imgDgst = image.DigestByGoLib(imgName)
-	if imgDgst == "" {
-		return fmt.Errorf("got empty img digest %q for %s", imgDgst, imgName)
-	}
-	if !cr.ImageExists(imgName, imgDgst) {
-		return fmt.Errorf("%q does not exist at hash %q in container runtime", imgName, imgDgst)
+func needsTransfer(img *image.CachedImage, cr cruntime.Manager) error {
+	// the image cache records the ID of the image, so there is no need to ask a docker daemon or registry for it
+	if !cr.ImageExists(img.Name, img.ID.Hex) {
+		return fmt.Errorf("%q does not exist at hash %q in container runtime", img.Name, img.ID.Hex)
 	}
 	return nil
 }
@@ -200,36 +239,76 @@ func CacheAndLoadImages(images []string) error {
 	return err
 }
 
-// transferAndLoadImage transfers and loads a single image from the cache
-func transferAndLoadImage(cr command.Runner, k8s config.KubernetesConfig, imgName string, cacheDir string) error {
-	r, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Runner: cr})
-	if err != nil {
-		return errors.Wrap(err, "runtime")
+// transferAndLoadImage transfers and loads a single image from the cache.
+// The blobs of images are kept on the node, so that only the layers which it does not already have are transferred.
+func transferAndLoadImage(runner command.Runner, cr cruntime.Manager, img *image.CachedImage, cacheDir string) error {
+	glog.Infof("Loading image from cache: %s", img.Name)
+	localpath.MarkUsed(image.BlobPath(cacheDir, img.Digest))
+	for _, h := range img.Blobs() {
+		if err := transferBlob(runner, cacheDir, h); err != nil {
+			return errors.Wrapf(err, "transferring %s of %s", h, img.Name)
+		}
 	}
-	src := filepath.Join(cacheDir, imgName)
-	src = localpath.SanitizeCacheDir(src)
-	glog.Infof("Loading image from cache: %s", src)
-	filename := filepath.Base(src)
-	if _, err := os.Stat(src); err != nil {
-		return err
+
+	loadImageLock.Lock()
+	defer loadImageLock.Unlock()
+
+	// assemble an archive of the image on the node from its blobs there
+	dir := path.Join(loadRoot, img.Digest.Hex)
+	archive := dir + ".tar"
+	defer func() {
+		if _, err := runner.RunCmd(exec.Command("sudo", "rm", "-rf", dir, archive)); err != nil {
+			glog.Warningf("unable to remove %s: %v", dir, err)
+		}
+	}()
+	if rr, err := runner.RunCmd(exec.Command("sudo", "mkdir", "-p", dir)); err != nil {
+		return errors.Wrapf(err, "mkdir %s: %s", dir, rr.Output())
 	}
-	dst := path.Join(loadRoot, filename)
-	f, err := assets.NewFileAsset(src, loadRoot, filename, "0644")
-	if err != nil {
-		return errors.Wrapf(err, "creating copyable file asset: %s", filename)
+	if err := runner.Copy(assets.NewMemoryAssetTarget(img.Manifest, path.Join(dir, "manifest.json"), "0644")); err != nil {
+		return errors.Wrap(err, "transferring manifest")
 	}
-	if err := cr.Copy(f); err != nil {
-		return errors.Wrap(err, "transferring cached image")
+	files := []string{"manifest.json"}
+	ln := []string{"ln", "-sf"}
+	for _, h := range img.Blobs() {
+		files = append(files, h.Hex)
+		ln = append(ln, path.Join(blobRoot, h.Hex))
+	}
+	if rr, err := runner.RunCmd(exec.Command("sudo", append(ln, dir)...)); err != nil {
+		return errors.Wrapf(err, "linking blobs: %s", rr.Output())
+	}
+	if rr, err := runner.RunCmd(exec.Command("sudo", append([]string{"tar", "-chf", archive, "-C", dir}, files...)...)); err != nil {
+		return errors.Wrapf(err, "archiving %s: %s", img.Name, rr.Output())
 	}
 
-	loadImageLock.Lock()
-	defer loadImageLock.Unlock()
+	if err := cr.LoadImage(archive); err != nil {
+		return errors.Wrapf(err, "%s load %s", cr.Name(), archive)
+	}
+
+	glog.Infof("Transferred and loaded %s from cache", img.Name)
+	return nil
+}
 
-	err = r.LoadImage(dst)
+// transferBlob transfers a blob of the image cache to the node, unless the node already has it
+func transferBlob(runner command.Runner, cacheDir string, h v1.Hash) error {
+	l, _ := blobLocks.LoadOrStore(h, &sync.Mutex{})
+	l.(*sync.Mutex).Lock()
+	defer l.(*sync.Mutex).Unlock()
+
+	dst := path.Join(blobRoot, h.Hex)
+	if _, err := runner.RunCmd(exec.Command("sudo", "test", "-f", dst)); err == nil {
+		return nil
+	}
+	glog.Infof("Transferring blob %s", h)
+	// transfer under another name first, so that a partially transferred blob is never mistaken for a whole one
+	f, err := assets.NewFileAsset(image.BlobPath(cacheDir, h), blobRoot, h.Hex+".partial", "0644")
 	if err != nil {
-		return errors.Wrapf(err, "%s load %s", r.Name(), dst)
+		return errors.Wrapf(err, "creating copyable file asset: %s", h)
+	}
+	if err := runner.Copy(f); err != nil {
+		return err
+	}
+	if rr, err := runner.RunCmd(exec.Command("sudo", "mv", path.Join(blobRoot, h.Hex+".partial"), dst)); err != nil {
+		return errors.Wrapf(err, "mv: %s", rr.Output())
 	}
-
-	glog.Infof("Transferred and loaded %s from cache", src)
 	return nil
 }
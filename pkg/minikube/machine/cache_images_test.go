@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/image"
+)
+
+// blobRunner is a command runner which keeps track of the files on the node
+type blobRunner struct {
+	command.Runner
+	files  map[string][]byte
+	copied []string
+}
+
+func (r *blobRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
+	rr := &command.RunResult{Args: cmd.Args}
+	switch cmd.Args[1] {
+	case "test":
+		if cmd.Args[2] == "-d" {
+			if len(r.list(cmd.Args[3])) == 0 {
+				return rr, &exec.ExitError{}
+			}
+			break
+		}
+		if _, ok := r.files[cmd.Args[3]]; !ok {
+			return rr, &exec.ExitError{}
+		}
+	case "mv":
+		r.files[cmd.Args[3]] = r.files[cmd.Args[2]]
+		delete(r.files, cmd.Args[2])
+	case "ls":
+		for _, f := range r.list(cmd.Args[3]) {
+			fmt.Fprintln(&rr.Stdout, f)
+		}
+	case "rm":
+		for _, f := range cmd.Args[3:] {
+			delete(r.files, f)
+		}
+	}
+	return rr, nil
+}
+
+// list returns the names of the files within a directory on the node
+func (r *blobRunner) list(dir string) []string {
+	var names []string
+	for f := range r.files {
+		if path.Dir(f) == dir {
+			names = append(names, path.Base(f))
+		}
+	}
+	return names
+}
+
+func (r *blobRunner) Copy(f assets.CopyableFile) error {
+	var b bytes.Buffer
+	if _, err := io.Copy(&b, f); err != nil {
+		return err
+	}
+	dst := path.Join(f.GetTargetDir(), f.GetTargetName())
+	r.files[dst] = b.Bytes()
+	r.copied = append(r.copied, dst)
+	return nil
+}
+
+func TestTransferAndLoadImage(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "image-cache")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	base, err := random.Image(64, 2)
+	if err != nil {
+		t.Fatalf("random image: %v", err)
+	}
+	layer, err := random.Layer(64, types.DockerLayer)
+	if err != nil {
+		t.Fatalf("random layer: %v", err)
+	}
+	app, err := mutate.AppendLayers(base, layer)
+	if err != nil {
+		t.Fatalf("append layers: %v", err)
+	}
+	if err := image.AddToCache(cacheDir, "base:1", base); err != nil {
+		t.Fatalf("AddToCache(base): %v", err)
+	}
+	if err := image.AddToCache(cacheDir, "app:1", app); err != nil {
+		t.Fatalf("AddToCache(app): %v", err)
+	}
+
+	runner := &blobRunner{files: map[string][]byte{}}
+	cr, err := cruntime.New(cruntime.Config{Type: "docker", Runner: runner})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+
+	var tests = []struct {
+		name string
+		// the config and the layers which the node does not have yet
+		want int
+	}{
+		{"base:1", 3},
+		{"app:1", 2},
+		{"app:1", 0},
+	}
+	for _, tc := range tests {
+		img, err := image.FindInCache(cacheDir, tc.name)
+		if err != nil {
+			t.Fatalf("FindInCache(%s): %v", tc.name, err)
+		}
+		runner.copied = nil
+		if err := transferAndLoadImage(runner, cr, img, cacheDir); err != nil {
+			t.Fatalf("transferAndLoadImage(%s): %v", tc.name, err)
+		}
+
+		blobs := 0
+		for _, c := range runner.copied {
+			if path.Dir(c) == blobRoot {
+				blobs++
+			}
+		}
+		if blobs != tc.want {
+			t.Errorf("transferAndLoadImage(%s) transferred %d blobs, want %d: %v", tc.name, blobs, tc.want, runner.copied)
+		}
+
+		var m tarball.Manifest
+		if err := json.Unmarshal(runner.files[path.Join(loadRoot, img.Digest.Hex, "manifest.json")], &m); err != nil {
+			t.Fatalf("transferAndLoadImage(%s) manifest: %v", tc.name, err)
+		}
+		if len(m) != 1 || m[0].Config != img.ID.Hex {
+			t.Errorf("transferAndLoadImage(%s) manifest = %+v", tc.name, m)
+		}
+	}
+}
+
+func TestPruneBlobs(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "image-cache")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	runner := &blobRunner{files: map[string][]byte{}}
+	if err := pruneBlobs(runner, cacheDir); err != nil {
+		t.Fatalf("pruneBlobs without blobs: %v", err)
+	}
+
+	base, err := random.Image(64, 2)
+	if err != nil {
+		t.Fatalf("random image: %v", err)
+	}
+	app, err := random.Image(64, 1)
+	if err != nil {
+		t.Fatalf("random image: %v", err)
+	}
+	if err := image.AddToCache(cacheDir, "base:1", base); err != nil {
+		t.Fatalf("AddToCache(base): %v", err)
+	}
+	if err := image.AddToCache(cacheDir, "app:1", app); err != nil {
+		t.Fatalf("AddToCache(app): %v", err)
+	}
+
+	cr, err := cruntime.New(cruntime.Config{Type: "docker", Runner: runner})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+	for _, name := range []string{"base:1", "app:1"} {
+		img, err := image.FindInCache(cacheDir, name)
+		if err != nil {
+			t.Fatalf("FindInCache(%s): %v", name, err)
+		}
+		if err := transferAndLoadImage(runner, cr, img, cacheDir); err != nil {
+			t.Fatalf("transferAndLoadImage(%s): %v", name, err)
+		}
+	}
+	partial := path.Join(blobRoot, "abc.partial")
+	runner.files[partial] = []byte("partial")
+
+	if err := image.RemoveFromCache(cacheDir, []string{"app:1"}); err != nil {
+		t.Fatalf("RemoveFromCache: %v", err)
+	}
+	if err := pruneBlobs(runner, cacheDir); err != nil {
+		t.Fatalf("pruneBlobs: %v", err)
+	}
+
+	base1, err := image.FindInCache(cacheDir, "base:1")
+	if err != nil {
+		t.Fatalf("FindInCache(base:1): %v", err)
+	}
+	want := map[string]bool{"abc.partial": true}
+	for _, h := range base1.Blobs() {
+		want[h.Hex] = true
+	}
+	got := runner.list(blobRoot)
+	if len(got) != len(want) {
+		t.Errorf("blobs on the node = %v, want those of base:1 and the partial one", got)
+	}
+	for _, f := range got {
+		if !want[f] {
+			t.Errorf("unused blob %s was kept on the node", f)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/image"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// Kinds of cache entries
+const (
+	CacheImage   = "image"
+	CachePreload = "preload"
+	CacheISO     = "iso"
+	CacheKic     = "kic"
+)
+
+// CacheEntry is a file within the local cache, such as a cached image or a preloaded images tarball
+type CacheEntry struct {
+	Kind string
+	// Name is the image name of images, and otherwise the file name
+	Name string
+	Path string
+	Size int64
+	// LastUsed is the last time minikube used the file, or else when it was downloaded
+	LastUsed time.Time
+	// Profiles are the names of the profiles which use the file
+	Profiles []string
+
+	// stored is whether the entry is an image within the content-addressed image cache, rather than a file of its own
+	stored bool
+}
+
+// cacheDirs returns the directories of the local cache which hold entries, by the kind of entry within them
+func cacheDirs() map[string]string {
+	return map[string]string{
+		CacheImage:   localpath.MakeMiniPath("cache", "images"),
+		CachePreload: localpath.MakeMiniPath("cache", "preloaded-tarball"),
+		CacheISO:     localpath.MakeMiniPath("cache", "iso"),
+		CacheKic:     localpath.MakeMiniPath("cache", "images-archives"),
+	}
+}
+
+// CacheEntries returns the entries of the local cache of a kind, or of every kind if kind is empty,
+// along with the profiles which use them. Images added with 'minikube cache add' are used by every profile.
+func CacheEntries(kind string, profiles []*config.Profile, added []string) ([]CacheEntry, error) {
+	dirs := cacheDirs()
+	users := map[string][]string{}
+	names := map[string]string{}
+	imageUsers := map[string][]string{}
+	for _, p := range profiles {
+		if p.Config == nil {
+			continue
+		}
+		for path, name := range cachePathsUsedBy(p.Config, dirs) {
+			users[path] = append(users[path], p.Name)
+			names[path] = name
+		}
+		for _, img := range cacheImagesUsedBy(p.Config, added) {
+			imageUsers[img] = append(imageUsers[img], p.Name)
+		}
+	}
+
+	entries := []CacheEntry{}
+	if kind == "" || kind == CacheImage {
+		images, err := image.ListCache(dirs[CacheImage])
+		if err != nil {
+			return nil, errors.Wrap(err, "listing the image cache")
+		}
+		for _, img := range images {
+			path := image.BlobPath(dirs[CacheImage], img.Digest)
+			e := CacheEntry{Kind: CacheImage, Name: img.Name, Path: path, Size: img.Size, Profiles: imageUsers[img.Name], stored: true}
+			if fi, err := os.Stat(path); err == nil {
+				e.LastUsed = fi.ModTime()
+			}
+			entries = append(entries, e)
+		}
+	}
+
+	for k, dir := range dirs {
+		if kind != "" && k != kind {
+			continue
+		}
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			// besides its index and blobs, the image cache only holds the tarballs of images cached by older versions
+			if k == CacheImage && image.IsCacheMetadata(dir, path) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.IsDir() || isPartialDownload(path) {
+				return nil
+			}
+			if k == CachePreload && strings.HasSuffix(path, ".checksum") {
+				return nil
+			}
+
+			e := CacheEntry{Kind: k, Name: names[path], Path: path, Size: info.Size(), LastUsed: info.ModTime(), Profiles: users[path]}
+			if e.Name == "" {
+				e.Name = cacheEntryName(k, dir, path)
+			}
+			if k == CachePreload {
+				if fi, err := os.Stat(path + ".checksum"); err == nil {
+					e.Size += fi.Size()
+				}
+			}
+			entries = append(entries, e)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "walking %s", dir)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// cacheImagesUsedBy returns the images of the image cache which a cluster uses
+func cacheImagesUsedBy(cc *config.ClusterConfig, added []string) []string {
+	k8s := cc.KubernetesConfig
+	images, err := bootstrapper.GetCachedImageList(k8s.ImageRepository, k8s.KubernetesVersion, "")
+	if err != nil {
+		glog.Warningf("unable to list images of %s: %v", cc.Name, err)
+	}
+	return append(images, added...)
+}
+
+// cachePathsUsedBy returns the paths within the local cache which a cluster uses, along with the names of their entries
+func cachePathsUsedBy(cc *config.ClusterConfig, dirs map[string]string) map[string]string {
+	paths := map[string]string{}
+	k8s := cc.KubernetesConfig
+
+	tarball := download.TarballName(k8s.KubernetesVersion, k8s.ContainerRuntime)
+	paths[filepath.Join(dirs[CachePreload], tarball)] = tarball
+
+	if driver.IsKIC(cc.Driver) {
+		p, err := download.ImageArchiveCachePath(kic.BaseImage)
+		if err != nil {
+			glog.Warningf("unable to find the archive of %s: %v", kic.BaseImage, err)
+		} else {
+			paths[p] = kic.BaseImage
+		}
+	} else if !driver.BareMetal(cc.Driver) && cc.MinikubeISO != "" {
+		p := download.LocalISOPath(cc.MinikubeISO)
+		paths[p] = filepath.Base(p)
+	}
+	return paths
+}
+
+// cacheEntryName returns the name of a cache entry which no profile uses
+func cacheEntryName(kind string, dir string, path string) string {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return path
+	}
+	rel = filepath.ToSlash(rel)
+	if kind != CacheImage {
+		return rel
+	}
+	// the tag separator of images was replaced when they were cached
+	if i := strings.LastIndex(rel, "_"); i > strings.LastIndex(rel, "/") {
+		return rel[:i] + ":" + rel[i+1:]
+	}
+	return rel
+}
+
+// isPartialDownload returns whether a file is an incomplete download, which is not an entry of the cache
+func isPartialDownload(path string) bool {
+	return strings.HasSuffix(path, ".download") || strings.HasSuffix(path, ".tmp")
+}
+
+// RemoveCacheEntries removes entries from the local cache
+func RemoveCacheEntries(entries []CacheEntry) error {
+	failed := []string{}
+	images := []string{}
+	for _, e := range entries {
+		if e.stored {
+			images = append(images, e.Name)
+			continue
+		}
+		glog.Infof("Removing %s from the cache", e.Path)
+		if err := os.Remove(e.Path); err != nil && !os.IsNotExist(err) {
+			failed = append(failed, err.Error())
+			continue
+		}
+		if e.Kind == CachePreload {
+			if err := os.Remove(e.Path + ".checksum"); err != nil && !os.IsNotExist(err) {
+				failed = append(failed, err.Error())
+			}
+		}
+	}
+	if len(images) > 0 {
+		if err := image.RemoveFromCache(cacheDirs()[CacheImage], images); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if err := image.CleanCacheDir(); err != nil {
+		glog.Warningf("unable to clean the image cache directory: %v", err)
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/image"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/tests"
+)
+
+func TestCacheEntries(t *testing.T) {
+	oldMinikubeHome := os.Getenv(localpath.MinikubeHome)
+	defer os.Setenv(localpath.MinikubeHome, oldMinikubeHome)
+	home := tests.MakeTempDir()
+	defer os.RemoveAll(filepath.Dir(home))
+
+	tarball := download.TarballName("v1.18.0", "docker")
+	for _, name := range []string{"k8s.gcr.io/pause:3.1", "busybox:latest"} {
+		img, err := random.Image(4, 1)
+		if err != nil {
+			t.Fatalf("random image: %v", err)
+		}
+		if err := image.AddToCache(filepath.Join(home, "cache", "images"), name, img); err != nil {
+			t.Fatalf("AddToCache: %v", err)
+		}
+	}
+
+	files := []string{
+		filepath.Join("cache", "images", "stale", "app_v1"),
+		filepath.Join("cache", "images", "stale", "app_v2.123.tmp"),
+		filepath.Join("cache", "preloaded-tarball", tarball),
+		filepath.Join("cache", "preloaded-tarball", tarball+".checksum"),
+		filepath.Join("cache", "iso", "minikube-v1.10.0.iso"),
+		filepath.Join("cache", "iso", "minikube-v1.9.0.iso"),
+	}
+	for _, f := range files {
+		p := filepath.Join(home, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(p, []byte("data"), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	profiles := []*config.Profile{
+		{
+			Name: "p1",
+			Config: &config.ClusterConfig{
+				Name:             "p1",
+				Driver:           "virtualbox",
+				MinikubeISO:      "https://storage.googleapis.com/minikube/iso/minikube-v1.10.0.iso",
+				KubernetesConfig: config.KubernetesConfig{KubernetesVersion: "v1.18.0", ContainerRuntime: "docker"},
+			},
+		},
+	}
+	entries, err := CacheEntries("", profiles, []string{"busybox:latest"})
+	if err != nil {
+		t.Fatalf("CacheEntries: %v", err)
+	}
+
+	type entry struct {
+		Kind     string
+		Name     string
+		Stored   bool
+		Profiles []string
+	}
+	got := []entry{}
+	for _, e := range entries {
+		got = append(got, entry{e.Kind, e.Name, e.stored, e.Profiles})
+	}
+	want := []entry{
+		{CacheImage, "busybox:latest", true, []string{"p1"}},
+		{CacheImage, "k8s.gcr.io/pause:3.1", true, []string{"p1"}},
+		{CacheImage, "stale/app:v1", false, nil},
+		{CacheISO, "minikube-v1.10.0.iso", false, []string{"p1"}},
+		{CacheISO, "minikube-v1.9.0.iso", false, nil},
+		{CachePreload, tarball, false, []string{"p1"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CacheEntries returned diff (-want +got):\n%s", diff)
+	}
+
+	images, err := CacheEntries(CacheImage, nil, nil)
+	if err != nil {
+		t.Fatalf("CacheEntries: %v", err)
+	}
+	if len(images) != 3 {
+		t.Errorf("CacheEntries(%s) = %+v, want 3 images", CacheImage, images)
+	}
+
+	if entries[5].Size != 8 {
+		t.Errorf("size of %s = %d, want the size of the tarball and its checksum", entries[5].Name, entries[5].Size)
+	}
+
+	if err := RemoveCacheEntries([]CacheEntry{entries[0], entries[5]}); err != nil {
+		t.Fatalf("RemoveCacheEntries: %v", err)
+	}
+	images, err = CacheEntries(CacheImage, nil, nil)
+	if err != nil {
+		t.Fatalf("CacheEntries: %v", err)
+	}
+	if len(images) != 2 || images[0].Name != "k8s.gcr.io/pause:3.1" {
+		t.Errorf("CacheEntries(%s) after removing busybox:latest = %+v", CacheImage, images)
+	}
+	for _, f := range []string{tarball, tarball + ".checksum"} {
+		if _, err := os.Stat(filepath.Join(home, "cache", "preloaded-tarball", f)); !os.IsNotExist(err) {
+			t.Errorf("%s was not removed: %v", f, err)
+		}
+	}
+}
+
+func TestMarkUsed(t *testing.T) {
+	f, err := ioutil.TempFile("", "cached")
+	if err != nil {
+		t.Fatalf("tempfile: %v", err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(f.Name(), old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	localpath.MarkUsed(f.Name())
+	fi, err := os.Stat(f.Name())
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if time.Since(fi.ModTime()) > time.Hour {
+		t.Errorf("MarkUsed did not update the modification time: %s", fi.ModTime())
+	}
+}
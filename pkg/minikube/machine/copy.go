@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+)
+
+// CopyTo copies a local file or directory to the node, preserving permissions
+func CopyTo(r command.Runner, src string, dst string) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return errors.Wrapf(err, "stat %s", src)
+	}
+	// like cp, copy into the target if it is an existing directory
+	if _, err := r.RunCmd(exec.Command("sudo", "test", "-d", dst)); err == nil {
+		dst = path.Join(dst, filepath.Base(src))
+	}
+	if !fi.IsDir() {
+		return copyFileTo(r, src, dst, fi.Mode())
+	}
+
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := path.Join(dst, filepath.ToSlash(rel))
+		if info.IsDir() {
+			glog.Infof("creating %s on node", target)
+			c := exec.Command("sudo", "mkdir", "-p", target)
+			if rr, err := r.RunCmd(c); err != nil {
+				return errors.Wrapf(err, "mkdir %s: %s", target, rr.Output())
+			}
+			c = exec.Command("sudo", "chmod", fmt.Sprintf("%o", info.Mode().Perm()), target)
+			if rr, err := r.RunCmd(c); err != nil {
+				return errors.Wrapf(err, "chmod %s: %s", target, rr.Output())
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			glog.Warningf("skipping %s: not a regular file", p)
+			return nil
+		}
+		return copyFileTo(r, p, target, info.Mode())
+	})
+}
+
+// copyFileTo copies a single local file to the node
+func copyFileTo(r command.Runner, src string, dst string, mode os.FileMode) error {
+	f, err := assets.NewFileAsset(src, path.Dir(dst), path.Base(dst), fmt.Sprintf("%#o", mode.Perm()))
+	if err != nil {
+		return err
+	}
+	glog.Infof("copying %s to %s", src, dst)
+	return r.Copy(f)
+}
+
+// CopyFrom copies a file or directory from the node to the host, preserving permissions
+func CopyFrom(r command.Runner, src string, dst string) error {
+	// like cp, copy into the target if it is an existing directory
+	if fi, err := os.Stat(dst); err == nil && fi.IsDir() {
+		dst = filepath.Join(dst, path.Base(src))
+	}
+
+	// stat each entry under src, as "<perms>|<type>|<path>"
+	c := exec.Command("sudo", "find", src, "-exec", "stat", "-c", "%a|%F|%n", "{}", "+")
+	rr, err := r.RunCmd(c)
+	if err != nil {
+		return errors.Wrapf(err, "listing %s: %s", src, rr.Output())
+	}
+
+	// directory permissions are applied last, in case they are not writable
+	dirPerms := map[string]os.FileMode{}
+	for _, line := range strings.Split(strings.TrimSpace(rr.Stdout.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "|", 3)
+		if len(fields) != 3 {
+			return fmt.Errorf("unexpected stat output: %q", line)
+		}
+		perms, err := strconv.ParseUint(fields[0], 8, 32)
+		if err != nil {
+			return errors.Wrapf(err, "parsing permissions in %q", line)
+		}
+
+		rel := strings.TrimPrefix(strings.TrimPrefix(fields[2], path.Clean(src)), "/")
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+
+		switch fields[1] {
+		case "directory":
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return errors.Wrapf(err, "mkdir %s", target)
+			}
+			dirPerms[target] = os.FileMode(perms)
+		case "regular file", "regular empty file":
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return errors.Wrapf(err, "mkdir %s", filepath.Dir(target))
+			}
+			glog.Infof("copying %s to %s", fields[2], target)
+			if err := r.CopyFrom(fields[2], target); err != nil {
+				return err
+			}
+		default:
+			glog.Warningf("skipping %s: %s", fields[2], fields[1])
+		}
+	}
+
+	for d, perms := range dirPerms {
+		if err := os.Chmod(d, perms); err != nil {
+			return errors.Wrapf(err, "chmod %s", d)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/command"
+)
+
+func TestCopyFrom(t *testing.T) {
+	dst, err := ioutil.TempDir("", "minikube-cp")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dst)
+
+	r := command.NewFakeCommandRunner()
+	r.SetCommandToOutput(map[string]string{
+		`sudo find /etc/certs -exec stat -c %a|%F|%n {} +`: "700|directory|/etc/certs\n" +
+			"755|directory|/etc/certs/sub\n" +
+			"644|regular file|/etc/certs/ca.crt\n" +
+			"644|regular file|/etc/certs/sub/client.crt\n",
+	})
+	r.SetFileToContents(map[string]string{
+		"/etc/certs/ca.crt":         "ca",
+		"/etc/certs/sub/client.crt": "client",
+	})
+
+	if err := CopyFrom(r, "/etc/certs", dst); err != nil {
+		t.Fatalf("CopyFrom: %v", err)
+	}
+
+	for p, want := range map[string]string{"ca.crt": "ca", "sub/client.crt": "client"} {
+		got, err := ioutil.ReadFile(filepath.Join(dst, "certs", p))
+		if err != nil {
+			t.Errorf("read %s: %v", p, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", p, got, want)
+		}
+	}
+
+	fi, err := os.Stat(filepath.Join(dst, "certs"))
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if fi.Mode().Perm() != 0700 {
+		t.Errorf("permissions = %o, want 700", fi.Mode().Perm())
+	}
+}
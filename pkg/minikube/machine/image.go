@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/image"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// forEachRunningNode runs fn against the container runtime of every running node of a profile,
+// returning the combined errors of the nodes which failed
+func forEachRunningNode(api libmachine.API, cc *config.ClusterConfig, fn func(n config.Node, runner command.Runner, cr cruntime.Manager) error) error {
+	failed := []string{}
+	for _, n := range cc.Nodes {
+		m := driver.MachineName(*cc, n)
+		st, err := GetHostStatus(api, m)
+		if err != nil || st != state.Running.String() {
+			out.WarningT("Skipping {{.name}}, which is not running", out.V{"name": m})
+			continue
+		}
+		h, err := api.Load(m)
+		if err != nil {
+			return errors.Wrapf(err, "load %s", m)
+		}
+		runner, err := CommandRunner(h)
+		if err != nil {
+			return errors.Wrapf(err, "command runner %s", m)
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: runner})
+		if err != nil {
+			return errors.Wrap(err, "runtime")
+		}
+		if err := fn(n, runner, cr); err != nil {
+			glog.Errorf("%s failed: %v", m, err)
+			failed = append(failed, fmt.Sprintf("%s: %v", m, err))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "\n"))
+	}
+	return nil
+}
+
+// LoadImage loads an image archive on the host, or else an image from the host's docker daemon,
+// into every running node of a profile
+func LoadImage(api libmachine.API, cc *config.ClusterConfig, src string) error {
+	archive := src
+	if _, err := os.Stat(src); err != nil {
+		tmp, err := ioutil.TempDir("", "minikube-image")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+
+		archive = filepath.Join(tmp, "image.tar")
+		if err := image.SaveFromDaemon(src, archive); err != nil {
+			return errors.Wrapf(err, "saving %s from the docker daemon", src)
+		}
+	}
+
+	filename := filepath.Base(localSafeName(src))
+	return forEachRunningNode(api, cc, func(n config.Node, runner command.Runner, cr cruntime.Manager) error {
+		f, err := assets.NewFileAsset(archive, loadRoot, filename, "0644")
+		if err != nil {
+			return errors.Wrapf(err, "creating copyable file asset: %s", filename)
+		}
+		if err := runner.Copy(f); err != nil {
+			return errors.Wrap(err, "transferring image")
+		}
+		defer func() {
+			if err := runner.Remove(f); err != nil {
+				glog.Warningf("unable to remove %s: %v", filename, err)
+			}
+		}()
+
+		loadImageLock.Lock()
+		defer loadImageLock.Unlock()
+		return cr.LoadImage(path.Join(loadRoot, filename))
+	})
+}
+
+// localSafeName returns a file name for an image or archive which is safe to use on a node
+func localSafeName(src string) string {
+	return strings.NewReplacer(":", "_", "/", "_", "@", "_").Replace(filepath.Base(src))
+}
+
+// PullImages pulls images into every running node of a profile
+func PullImages(api libmachine.API, cc *config.ClusterConfig, images []string) error {
+	return forEachRunningNode(api, cc, func(n config.Node, runner command.Runner, cr cruntime.Manager) error {
+		for _, img := range images {
+			if err := cr.PullImage(img); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveImages removes images from every running node of a profile
+func RemoveImages(api libmachine.API, cc *config.ClusterConfig, images []string) error {
+	return forEachRunningNode(api, cc, func(n config.Node, runner command.Runner, cr cruntime.Manager) error {
+		for _, img := range images {
+			if err := cr.RemoveImage(img); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListImages returns the images within the container runtime of a node
+func ListImages(api libmachine.API, cc *config.ClusterConfig, n config.Node) ([]string, error) {
+	h, err := CheckIfHostExistsAndLoad(api, driver.MachineName(*cc, n))
+	if err != nil {
+		return nil, err
+	}
+	runner, err := CommandRunner(h)
+	if err != nil {
+		return nil, err
+	}
+	cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: runner})
+	if err != nil {
+		return nil, errors.Wrap(err, "runtime")
+	}
+	return cr.ListImages()
+}
@@ -63,20 +63,21 @@ var (
 
 // StartHost starts a host VM.
 func StartHost(api libmachine.API, cfg config.ClusterConfig, n config.Node) (*host.Host, error) {
+	machineName := driver.MachineName(cfg, n)
 	// Prevent machine-driver boot races, as well as our own certificate race
-	releaser, err := acquireMachinesLock(cfg.Name)
+	releaser, err := acquireMachinesLock(machineName, cfg.Driver)
 	if err != nil {
 		return nil, errors.Wrap(err, "boot lock")
 	}
 	start := time.Now()
 	defer func() {
-		glog.Infof("releasing machines lock for %q, held for %s", cfg.Name, time.Since(start))
+		glog.Infof("releasing machines lock for %q, held for %s", machineName, time.Since(start))
 		releaser.Release()
 	}()
 
-	exists, err := api.Exists(cfg.Name)
+	exists, err := api.Exists(machineName)
 	if err != nil {
-		return nil, errors.Wrapf(err, "exists: %s", cfg.Name)
+		return nil, errors.Wrapf(err, "exists: %s", machineName)
 	}
 	if !exists {
 		glog.Infof("Provisioning new machine with config: %+v", cfg)
@@ -237,8 +238,13 @@ func commandRunner(h *host.Host) (command.Runner, error) {
 }
 
 // acquireMachinesLock protects against code that is not parallel-safe (libmachine, cert setup)
-func acquireMachinesLock(name string) (mutex.Releaser, error) {
-	spec := lock.PathMutexSpec(filepath.Join(localpath.MiniPath(), "machines"))
+func acquireMachinesLock(name string, drvName string) (mutex.Releaser, error) {
+	lockPath := filepath.Join(localpath.MiniPath(), "machines")
+	// Containers do not race each other while booting, so nodes of kic clusters may be created in parallel
+	if driver.IsKIC(drvName) {
+		lockPath = filepath.Join(lockPath, name)
+	}
+	spec := lock.PathMutexSpec(lockPath)
 	// NOTE: Provisioning generally completes within 60 seconds
 	spec.Timeout = 15 * time.Minute
 
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"net"
+	"net/url"
+	"runtime"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/autopause"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/version"
+)
+
+// disableAutoPause stops the auto-pause proxy of a node, so that it does not pause the cluster while it is being started
+func disableAutoPause(r command.Runner, cc config.ClusterConfig) {
+	cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: r})
+	if err != nil {
+		glog.Warningf("unable to disable auto-pause: %v", err)
+		return
+	}
+	if err := autopause.Disable(r, cr); err != nil {
+		glog.Warningf("unable to disable auto-pause: %v", err)
+	}
+}
+
+// enableAutoPause starts the auto-pause proxy in front of the apiserver of the node, and points the kubeconfig at it
+func enableAutoPause(r command.Runner, cc config.ClusterConfig, n config.Node, kcs *kubeconfig.Settings) error {
+	port := constants.AutoPauseProxyPort
+	if driver.IsKIC(cc.Driver) {
+		p, err := oci.HostPortBinding(cc.Driver, driver.MachineName(cc, n), constants.AutoPauseProxyPort)
+		if err != nil {
+			return errors.Wrapf(err, "the %q container does not publish the auto-pause port, run 'minikube delete' to recreate it", driver.MachineName(cc, n))
+		}
+		port = p
+	}
+
+	v, err := version.GetSemverVersion()
+	if err != nil {
+		return errors.Wrap(err, "minikube version")
+	}
+	bin, err := download.AutoPause(v, runtime.GOARCH)
+	if err != nil {
+		return errors.Wrap(err, "download")
+	}
+	if err := autopause.Enable(r, cc, n, bin); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(kcs.ClusterServerAddress)
+	if err != nil {
+		return errors.Wrapf(err, "parse %s", kcs.ClusterServerAddress)
+	}
+	u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(port))
+	kcs.ClusterServerAddress = u.String()
+	if err := kubeconfig.Update(kcs); err != nil {
+		return errors.Wrap(err, "kubeconfig")
+	}
+	out.T(out.Pause, "The cluster will be paused after {{.interval}} without API requests", out.V{"interval": cc.AutoPauseInterval})
+	return nil
+}
@@ -19,12 +19,15 @@ package node
 import (
 	"os"
 	"runtime"
+	"strings"
 
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"golang.org/x/sync/errgroup"
 	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
 	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/download"
@@ -93,6 +96,19 @@ func doCacheBinaries(k8sVersion string) error {
 // beginDownloadKicArtifacts downloads the kic image + preload tarball, returns true if preload is available
 func beginDownloadKicArtifacts(g *errgroup.Group) {
 	glog.Info("Beginning downloading kic artifacts")
+	if download.MirrorEnabled() {
+		// Images loaded from archives have no digest, so the base image is referred to by its tag
+		kic.BaseImage = strings.Split(kic.BaseImage, "@")[0]
+		g.Go(func() error {
+			archive, err := download.ImageArchive(kic.BaseImage)
+			if err != nil {
+				return err
+			}
+			return image.LoadArchiveToDaemon(kic.BaseImage, archive)
+		})
+		return
+	}
+
 	g.Go(func() error {
 		glog.Infof("Downloading %s to local daemon", kic.BaseImage)
 		return image.WriteImageToDaemon(kic.BaseImage)
@@ -120,7 +136,7 @@ func waitCacheRequiredImages(g *errgroup.Group) {
 // saveImagesToTarFromConfig saves images to tar in cache which specified in config file.
 // currently only used by download-only option
 func saveImagesToTarFromConfig() error {
-	images, err := imagesInConfigFile()
+	images, err := ImagesInConfigFile()
 	if err != nil {
 		return err
 	}
@@ -130,7 +146,8 @@ func saveImagesToTarFromConfig() error {
 	return image.SaveToDir(images, constants.ImageCacheDir)
 }
 
-func imagesInConfigFile() ([]string, error) {
+// ImagesInConfigFile returns the images added with 'minikube cache add'
+func ImagesInConfigFile() ([]string, error) {
 	configFile, err := config.ReadConfig(localpath.ConfigFile())
 	if err != nil {
 		return nil, err
@@ -148,7 +165,7 @@ func imagesInConfigFile() ([]string, error) {
 // CacheAndLoadImagesInConfig loads the images currently in the config file
 // called by 'start' and 'cache reload' commands.
 func CacheAndLoadImagesInConfig() error {
-	images, err := imagesInConfigFile()
+	images, err := ImagesInConfigFile()
 	if err != nil {
 		return err
 	}
@@ -157,3 +174,18 @@ func CacheAndLoadImagesInConfig() error {
 	}
 	return machine.CacheAndLoadImages(images)
 }
+
+// loadCachedImages loads the images in the config file into the container runtime of a single node
+func loadCachedImages(cc config.ClusterConfig, runner command.Runner) error {
+	images, err := ImagesInConfigFile()
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		return nil
+	}
+	if err := image.SaveToDir(images, constants.ImageCacheDir); err != nil {
+		return errors.Wrap(err, "caching images")
+	}
+	return machine.LoadImages(&cc, runner, images, constants.ImageCacheDir)
+}
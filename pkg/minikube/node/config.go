@@ -27,6 +27,7 @@ import (
 	"github.com/docker/machine/libmachine"
 	"github.com/docker/machine/libmachine/host"
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
 	"k8s.io/minikube/pkg/minikube/bootstrapper"
@@ -55,11 +56,11 @@ var (
 )
 
 // configureRuntimes does what needs to happen to get a runtime going.
-func configureRuntimes(runner cruntime.CommandRunner, drvName string, k8s config.KubernetesConfig) cruntime.Manager {
+func configureRuntimes(runner cruntime.CommandRunner, drvName string, k8s config.KubernetesConfig) (cruntime.Manager, error) {
 	config := cruntime.Config{Type: viper.GetString(containerRuntime), Runner: runner, ImageRepository: k8s.ImageRepository, KubernetesVersion: k8s.KubernetesVersion}
 	cr, err := cruntime.New(config)
 	if err != nil {
-		exit.WithError("Failed runtime", err)
+		return nil, errors.Wrap(err, "Failed runtime")
 	}
 
 	disableOthers := true
@@ -70,17 +71,16 @@ func configureRuntimes(runner cruntime.CommandRunner, drvName string, k8s config
 		if err := cr.Preload(k8s.KubernetesVersion); err != nil {
 			glog.Errorf("Failed to preload container runtime %s: %v, falling back to caching images", cr.Name(), err)
 			if err := machine.CacheImagesForBootstrapper(k8s.ImageRepository, k8s.KubernetesVersion, viper.GetString(cmdcfg.Bootstrapper)); err != nil {
-				exit.WithError("Failed to cache images", err)
+				return nil, errors.Wrap(err, "Failed to cache images")
 			}
 		}
 	}
 
-	err = cr.Enable(disableOthers)
-	if err != nil {
-		exit.WithError("Failed to enable container runtime", err)
+	if err := cr.Enable(disableOthers); err != nil {
+		return nil, errors.Wrap(err, "Failed to enable container runtime")
 	}
 
-	return cr
+	return cr, nil
 }
 
 func showVersionInfo(k8sVersion string, cr cruntime.Manager) {
@@ -104,7 +104,7 @@ func setupKubeAdm(mAPI libmachine.API, cfg config.ClusterConfig, node config.Nod
 		out.T(out.Option, "{{.extra_option_component_name}}.{{.key}}={{.value}}", out.V{"extra_option_component_name": eo.Component, "key": eo.Key, "value": eo.Value})
 	}
 	// Loads cached images, generates config files, download binaries
-	if err := bs.UpdateCluster(cfg); err != nil {
+	if err := bs.UpdateCluster(cfg, node); err != nil {
 		exit.WithError("Failed to update cluster", err)
 	}
 	if err := bs.SetupCerts(cfg.KubernetesConfig, node); err != nil {
@@ -126,6 +126,12 @@ func setupKubeconfig(h *host.Host, c *config.ClusterConfig, n *config.Node, clus
 	if c.KubernetesConfig.APIServerName != constants.APIServerName {
 		addr = strings.Replace(addr, n.IP, c.KubernetesConfig.APIServerName, -1)
 	}
+	if c.HA {
+		addr, err = haEndpoint(*c)
+		if err != nil {
+			return nil, errors.Wrap(err, "load balancer endpoint")
+		}
+	}
 	kcs := &kubeconfig.Settings{
 		ClusterName:          clusterName,
 		ClusterServerAddress: addr,
@@ -144,18 +150,24 @@ func setupKubeconfig(h *host.Host, c *config.ClusterConfig, n *config.Node, clus
 }
 
 // configureMounts configures any requested filesystem mounts
-func configureMounts() {
-	if !viper.GetBool(createMount) {
+func configureMounts(cc config.ClusterConfig) {
+	if !cc.Mount {
+		return
+	}
+
+	// kic nodes bind mount the directory when they are created, so no mount server is needed
+	if driver.IsKIC(cc.Driver) {
+		out.T(out.Mounting, "Bind mounted {{.name}} into the node", out.V{"name": cc.MountString})
 		return
 	}
 
-	out.T(out.Mounting, "Creating mount {{.name}} ...", out.V{"name": viper.GetString(mountString)})
+	out.T(out.Mounting, "Creating mount {{.name}} ...", out.V{"name": cc.MountString})
 	path := os.Args[0]
 	mountDebugVal := 0
 	if glog.V(8) {
 		mountDebugVal = 1
 	}
-	mountCmd := exec.Command(path, "mount", fmt.Sprintf("--v=%d", mountDebugVal), viper.GetString(mountString))
+	mountCmd := exec.Command(path, "mount", fmt.Sprintf("--v=%d", mountDebugVal), cc.MountString)
 	mountCmd.Env = append(os.Environ(), constants.IsMinikubeChildProcess+"=true")
 	if glog.V(8) {
 		mountCmd.Stdout = os.Stdout
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/driver"
+)
+
+// setupHA points an HA cluster at the load balancer fronting its apiservers, saving its address within the cluster config.
+// With kic the load balancer is a container, with VMs it is a virtual IP advertised by the control planes.
+// Workers only join through the load balancer, so it is set up by the control planes alone.
+func setupHA(cc *config.ClusterConfig, n config.Node, r command.Runner) error {
+	if !cc.HA || !n.ControlPlane {
+		return nil
+	}
+
+	vip := cc.KubernetesConfig.APIServerHAVIP
+	if driver.IsKIC(cc.Driver) {
+		backends := []kic.LoadBalancerBackend{}
+		for _, cp := range config.ControlPlanes(*cc) {
+			if cp.IP == "" {
+				continue
+			}
+			backends = append(backends, kic.LoadBalancerBackend{Name: cp.Name, Address: net.JoinHostPort(cp.IP, strconv.Itoa(apiServerPort(cp)))})
+		}
+		cp, err := config.PrimaryControlPlane(*cc)
+		if err != nil {
+			return err
+		}
+		ip, err := kic.StartLoadBalancer(cc.Driver, cc.Name, cc.Network, cc.Subnet, filepath.Join(config.ProfileFolderPath(cc.Name), "loadbalancer"), apiServerPort(cp), backends)
+		if err != nil {
+			return errors.Wrap(err, "load balancer")
+		}
+		vip = ip
+	} else if vip == "" {
+		ip, err := virtualIP(*cc, func(ip string) bool { return addressInUse(r, ip) })
+		if err != nil {
+			return err
+		}
+		vip = ip
+	}
+
+	if vip == cc.KubernetesConfig.APIServerHAVIP {
+		return nil
+	}
+	glog.Infof("apiservers of %s are fronted by %s", cc.Name, vip)
+	cc.KubernetesConfig.APIServerHAVIP = vip
+	return config.SaveProfile(viper.GetString(config.ProfileName), cc)
+}
+
+// virtualIP picks an unused address within the /24 network of the primary control plane, counting down from .254.
+// The driver's DHCP server may lease any of them (libvirt leases .2-.254), so addresses answering inUse are skipped too.
+func virtualIP(cc config.ClusterConfig, inUse func(ip string) bool) (string, error) {
+	cp, err := config.PrimaryControlPlane(cc)
+	if err != nil {
+		return "", err
+	}
+	ip := net.ParseIP(cp.IP).To4()
+	if ip == nil {
+		return "", fmt.Errorf("primary control plane has no IPv4 address: %q", cp.IP)
+	}
+
+	used := map[string]bool{}
+	for _, n := range cc.Nodes {
+		used[n.IP] = true
+	}
+	for last := 254; last > 1; last-- {
+		vip := net.IPv4(ip[0], ip[1], ip[2], byte(last)).String()
+		if used[vip] {
+			continue
+		}
+		if inUse(vip) {
+			glog.Infof("%s is in use, not taking it as the virtual IP", vip)
+			continue
+		}
+		return vip, nil
+	}
+	return "", fmt.Errorf("no free address for a virtual IP near %s", cp.IP)
+}
+
+// addressInUse reports whether a host answers at ip, as seen from the node run by r.
+// dnsmasq, which serves DHCP for libvirt, likewise pings an address before leasing it, so a live virtual IP is not handed out.
+func addressInUse(r command.Runner, ip string) bool {
+	_, err := r.RunCmd(exec.Command("ping", "-c", "1", "-W", "1", ip))
+	return err == nil
+}
+
+// haEndpoint returns the URL of the load balancer fronting the apiservers of an HA cluster, as reached from the host
+func haEndpoint(cc config.ClusterConfig) (string, error) {
+	cp, err := config.PrimaryControlPlane(cc)
+	if err != nil {
+		return "", err
+	}
+	if driver.IsKIC(cc.Driver) {
+		port, err := kic.LoadBalancerHostPort(cc.Driver, cc.Name, apiServerPort(cp))
+		if err != nil {
+			return "", errors.Wrap(err, "load balancer port")
+		}
+		return fmt.Sprintf("https://%s", net.JoinHostPort(oci.DefaultBindIPV4, strconv.Itoa(port))), nil
+	}
+	return fmt.Sprintf("https://%s", net.JoinHostPort(cc.KubernetesConfig.APIServerHAVIP, strconv.Itoa(apiServerPort(cp)))), nil
+}
+
+// apiServerPort returns the port the apiserver of a node listens on
+func apiServerPort(n config.Node) int {
+	if n.Port <= 0 {
+		return constants.APIServerPort
+	}
+	return n.Port
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestVirtualIP(t *testing.T) {
+	var tests = []struct {
+		description string
+		nodes       []config.Node
+		inUse       []string
+		expected    string
+		err         bool
+	}{
+		{
+			description: "last address of the primary network",
+			nodes:       []config.Node{{Name: "m01", IP: "192.168.39.10", ControlPlane: true}},
+			expected:    "192.168.39.254",
+		},
+		{
+			description: "skips addresses of nodes",
+			nodes: []config.Node{
+				{Name: "m01", IP: "192.168.39.10", ControlPlane: true},
+				{Name: "m02", IP: "192.168.39.254", ControlPlane: true},
+			},
+			expected: "192.168.39.253",
+		},
+		{
+			description: "skips addresses in use",
+			nodes:       []config.Node{{Name: "m01", IP: "192.168.39.10", ControlPlane: true}},
+			inUse:       []string{"192.168.39.254", "192.168.39.253"},
+			expected:    "192.168.39.252",
+		},
+		{
+			description: "primary without address",
+			nodes:       []config.Node{{Name: "m01", ControlPlane: true}},
+			err:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			inUse := func(ip string) bool {
+				for _, u := range test.inUse {
+					if u == ip {
+						return true
+					}
+				}
+				return false
+			}
+			got, err := virtualIP(config.ClusterConfig{Nodes: test.nodes}, inUse)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("virtualIP: %v", err)
+			}
+			if got != test.expected {
+				t.Errorf("virtualIP = %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
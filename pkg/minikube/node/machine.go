@@ -44,13 +44,16 @@ func startMachine(cfg *config.ClusterConfig, node *config.Node) (runner command.
 	if err != nil {
 		exit.WithError("Failed to get machine client", err)
 	}
+	if err := setupNetwork(cfg, node); err != nil {
+		exit.WithError("Failed to set up the network", err)
+	}
 	host, preExists = startHost(m, *cfg, *node)
 	runner, err = machine.CommandRunner(host)
 	if err != nil {
 		exit.WithError("Failed to get command runner", err)
 	}
 
-	ip := validateNetwork(host, runner)
+	ip := nodeIP(*cfg, *node, validateNetwork(host, runner))
 
 	// Bypass proxy for minikube's vm host ip
 	err = proxy.ExcludeIP(ip)
@@ -69,7 +72,7 @@ func startMachine(cfg *config.ClusterConfig, node *config.Node) (runner command.
 
 // startHost starts a new minikube host using a VM or None
 func startHost(api libmachine.API, mc config.ClusterConfig, n config.Node) (*host.Host, bool) {
-	exists, err := api.Exists(mc.Name)
+	exists, err := api.Exists(driver.MachineName(mc, n))
 	if err != nil {
 		exit.WithError("Failed to check if machine exists", err)
 	}
@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+)
+
+// setupNetwork creates the network of a kic cluster, recording its subnet, and gives the node a static IP within it
+func setupNetwork(cc *config.ClusterConfig, n *config.Node) error {
+	if !driver.IsKIC(cc.Driver) || cc.Network == "" {
+		return nil
+	}
+	subnet, err := kic.CreateNetwork(cc.Driver, cc.Name, cc.Network, cc.Subnet)
+	if err != nil {
+		return errors.Wrap(err, "create network")
+	}
+	cc.Subnet = subnet
+	return assignStaticIP(*cc, n)
+}
+
+// nodeIP returns the IP of a node as reported by its driver, unless the node has a static IP on the network
+// of its kic cluster, which it was created with. Reporting the IP of a container is best effort for podman.
+func nodeIP(cc config.ClusterConfig, n config.Node, reported string) string {
+	if !driver.IsKIC(cc.Driver) || cc.Network == "" || n.IP == "" {
+		return reported
+	}
+	if reported != n.IP {
+		glog.Warningf("%s reported IP %s for %s, which has the static IP %s", cc.Driver, reported, n.Name, n.IP)
+	}
+	return n.IP
+}
+
+// assignStaticIP gives a node of a kic cluster the lowest free address within the subnet of its network, unless it has one already
+func assignStaticIP(cc config.ClusterConfig, n *config.Node) error {
+	if !driver.IsKIC(cc.Driver) || cc.Network == "" || cc.Subnet == "" || n.IP != "" {
+		return nil
+	}
+	used := []string{}
+	for _, o := range cc.Nodes {
+		if o.Name != n.Name && o.IP != "" {
+			used = append(used, o.IP)
+		}
+	}
+	ip, err := kic.StaticIP(cc.Subnet, used)
+	if err != nil {
+		return errors.Wrapf(err, "static IP for %s", n.Name)
+	}
+	n.IP = ip
+	return nil
+}
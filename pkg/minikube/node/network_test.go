@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestAssignStaticIP(t *testing.T) {
+	kic := config.ClusterConfig{
+		Driver:  "docker",
+		Network: "p1",
+		Subnet:  "192.168.49.0/24",
+		Nodes:   []config.Node{{Name: "m01", IP: "192.168.49.2"}, {Name: "m03", IP: "192.168.49.4"}},
+	}
+	var tests = []struct {
+		name string
+		cc   config.ClusterConfig
+		node config.Node
+		want string
+	}{
+		{"fills gaps", kic, config.Node{Name: "m04"}, "192.168.49.3"},
+		{"keeps existing", kic, config.Node{Name: "m03", IP: "192.168.49.4"}, "192.168.49.4"},
+		{"default bridge", config.ClusterConfig{Driver: "docker", Nodes: kic.Nodes}, config.Node{Name: "m04"}, ""},
+		{"vm", config.ClusterConfig{Driver: "virtualbox", Network: "p1", Subnet: kic.Subnet}, config.Node{Name: "m01"}, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			n := tc.node
+			if err := assignStaticIP(tc.cc, &n); err != nil {
+				t.Fatalf("assignStaticIP: %v", err)
+			}
+			if n.IP != tc.want {
+				t.Errorf("IP = %q, want %q", n.IP, tc.want)
+			}
+		})
+	}
+}
+
+func TestNodeIP(t *testing.T) {
+	podman := config.ClusterConfig{
+		Driver:  "podman",
+		Network: "p1",
+		Subnet:  "192.168.49.0/24",
+		Nodes:   []config.Node{{Name: "m01", IP: "192.168.49.2"}},
+	}
+	var tests = []struct {
+		name     string
+		cc       config.ClusterConfig
+		node     config.Node
+		reported string
+		want     string
+	}{
+		{"podman keeps static IP", podman, config.Node{Name: "m02", IP: "192.168.49.3"}, "127.0.0.1", "192.168.49.3"},
+		{"docker keeps static IP", config.ClusterConfig{Driver: "docker", Network: "p1"}, config.Node{Name: "m01", IP: "192.168.49.2"}, "192.168.49.2", "192.168.49.2"},
+		{"default bridge", config.ClusterConfig{Driver: "podman"}, config.Node{Name: "m01"}, "10.88.0.5", "10.88.0.5"},
+		{"vm", config.ClusterConfig{Driver: "virtualbox"}, config.Node{Name: "m01", IP: "192.168.99.100"}, "192.168.99.101", "192.168.99.101"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := nodeIP(tc.cc, tc.node, tc.reported); got != tc.want {
+				t.Errorf("nodeIP = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	// a podman node keeping its static IP is not handed out again
+	n := config.Node{Name: "m02", IP: "192.168.49.3"}
+	n.IP = nodeIP(podman, n, "127.0.0.1")
+	podman.Nodes = append(podman.Nodes, n)
+	next := config.Node{Name: "m03"}
+	if err := assignStaticIP(podman, &next); err != nil {
+		t.Fatalf("assignStaticIP: %v", err)
+	}
+	if next.IP != "192.168.49.4" {
+		t.Errorf("IP of the next node = %q, want 192.168.49.4", next.IP)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/viper"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/machine"
 )
@@ -33,8 +34,6 @@ const (
 	containerRuntime    = "container-runtime"
 	embedCerts          = "embed-certs"
 	keepContext         = "keep-context"
-	mountString         = "mount-string"
-	createMount         = "mount"
 	waitTimeout         = "wait-timeout"
 )
 
@@ -47,6 +46,10 @@ func Add(cc *config.ClusterConfig, name string, controlPlane bool, worker bool,
 
 	if controlPlane {
 		n.ControlPlane = true
+		n.Port = constants.APIServerPort
+		if cp, err := config.PrimaryControlPlane(*cc); err == nil && cp.Port > 0 {
+			n.Port = cp.Port
+		}
 	}
 
 	if worker {
@@ -59,6 +62,9 @@ func Add(cc *config.ClusterConfig, name string, controlPlane bool, worker bool,
 		n.KubernetesVersion = cc.KubernetesConfig.KubernetesVersion
 	}
 
+	if err := assignStaticIP(*cc, &n); err != nil {
+		return nil, err
+	}
 	cc.Nodes = append(cc.Nodes, n)
 	err := config.SaveProfile(profileName, cc)
 	if err != nil {
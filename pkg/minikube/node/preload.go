@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/image"
+	"k8s.io/minikube/pkg/minikube/kubelet"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/out"
+)
+
+// preloadBuildPath is where the preloaded tarball is generated within the node
+const preloadBuildPath = "/tmp/preloaded-images.tar.lz4"
+
+// BuildPreload generates a preloaded images tarball at dst from a running node, which holds the kubernetes images
+// and binaries along with any extra images. The container runtime and the kubelet are stopped while the tarball
+// is written, so that the image store is consistent on disk, and restarted afterwards.
+func BuildPreload(runner command.Runner, cc config.ClusterConfig, extraImages []string, dst string) error {
+	if err := PullPreloadImages(runner, cc, extraImages); err != nil {
+		return err
+	}
+
+	k8s := preloadKubernetesConfig(cc)
+	cr, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Runner: runner, KubernetesVersion: k8s.KubernetesVersion})
+	if err != nil {
+		return errors.Wrap(err, "container runtime")
+	}
+
+	kubeletRunning := kubelet.Check(runner) == nil
+	if kubeletRunning {
+		if err := kubelet.Stop(runner); err != nil {
+			return errors.Wrap(err, "stopping kubelet")
+		}
+		defer func() {
+			if err := kubelet.Start(runner); err != nil {
+				glog.Warningf("unable to restart kubelet: %v", err)
+			}
+		}()
+	}
+	if err := cr.Disable(); err != nil {
+		return errors.Wrapf(err, "stopping %s", cr.Name())
+	}
+	defer func() {
+		if err := cr.Enable(false); err != nil {
+			glog.Warningf("unable to restart %s: %v", cr.Name(), err)
+		}
+	}()
+
+	return WritePreload(runner, cc, dst)
+}
+
+// PullPreloadImages makes sure the container runtime of a node has the kubernetes images and any extra images,
+// and transfers the kubernetes binaries, which are the contents of a preloaded tarball. Images the runtime already
+// has are kept, images within the local image cache are loaded from it, and only the others are pulled.
+func PullPreloadImages(runner command.Runner, cc config.ClusterConfig, extraImages []string) error {
+	k8s := preloadKubernetesConfig(cc)
+	cr, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Runner: runner, KubernetesVersion: k8s.KubernetesVersion})
+	if err != nil {
+		return errors.Wrap(err, "container runtime")
+	}
+
+	imgs, err := images.Kubeadm(k8s.ImageRepository, k8s.KubernetesVersion)
+	if err != nil {
+		return errors.Wrap(err, "kubeadm images")
+	}
+	if driver.IsKIC(cc.Driver) {
+		imgs = append(imgs, kic.OverlayImage)
+	}
+	imgs = append(imgs, extraImages...)
+
+	have, err := cr.ListImages()
+	if err != nil {
+		glog.Warningf("unable to list %s images, assuming there are none: %v", cr.Name(), err)
+	}
+	load, pull := splitPreloadImages(imgs, have, func(img string) bool {
+		_, err := image.FindInCache(constants.ImageCacheDir, img)
+		return err == nil
+	})
+
+	if len(load) > 0 {
+		cc.KubernetesConfig = k8s
+		if err := machine.LoadImages(&cc, runner, load, constants.ImageCacheDir); err != nil {
+			return errors.Wrap(err, "loading cached images")
+		}
+	}
+	for _, img := range pull {
+		out.T(out.Pulling, "Pulling {{.image}} ...", out.V{"image": img})
+		if err := cr.PullImage(img); err != nil {
+			return errors.Wrapf(err, "pulling %s", img)
+		}
+	}
+
+	if err := bsutil.TransferBinaries(k8s, runner); err != nil {
+		return errors.Wrap(err, "transferring k8s binaries")
+	}
+	return nil
+}
+
+// splitPreloadImages returns the images which the runtime does not have yet, split between those to load
+// from the local image cache and those to pull
+func splitPreloadImages(imgs []string, have []string, inCache func(string) bool) (load []string, pull []string) {
+	existing := map[string]bool{}
+	for _, i := range have {
+		existing[i] = true
+		// CRI runtimes name images of Docker Hub in full, unlike the image lists
+		existing[strings.TrimPrefix(strings.TrimPrefix(i, "docker.io/"), "library/")] = true
+	}
+	for _, img := range imgs {
+		switch {
+		case existing[img]:
+			glog.Infof("%s is already in the container runtime", img)
+		case inCache(img):
+			load = append(load, img)
+		default:
+			pull = append(pull, img)
+		}
+	}
+	return load, pull
+}
+
+// WritePreload writes the preloaded images tarball of a node to dst. The container runtime of the node
+// must be stopped, as its image store may otherwise be mid-write.
+func WritePreload(runner command.Runner, cc config.ClusterConfig, dst string) error {
+	k8s := preloadKubernetesConfig(cc)
+
+	out.T(out.Caching, "Generating the preloaded images tarball for Kubernetes {{.version}} on {{.runtime}} ...", out.V{"version": k8s.KubernetesVersion, "runtime": k8s.ContainerRuntime})
+	dirs := append(download.PreloadImageDirs(k8s.ContainerRuntime), "./lib/minikube/binaries")
+	args := append([]string{"tar", "-I", "lz4", "-C", "/var", "-cf", preloadBuildPath}, dirs...)
+	t := time.Now()
+	if rr, err := runner.RunCmd(exec.Command("sudo", args...)); err != nil {
+		return errors.Wrapf(err, "creating tarball: %s", rr.Output())
+	}
+	glog.Infof("Took %s to create the preloaded tarball", time.Since(t))
+	defer func() {
+		if _, err := runner.RunCmd(exec.Command("sudo", "rm", "-f", preloadBuildPath)); err != nil {
+			glog.Warningf("unable to remove %s: %v", preloadBuildPath, err)
+		}
+	}()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := runner.CopyFrom(preloadBuildPath, dst); err != nil {
+		return errors.Wrap(err, "copying tarball")
+	}
+	return nil
+}
+
+// preloadKubernetesConfig returns the kubernetes config of a cluster, defaulting to the docker runtime
+func preloadKubernetesConfig(cc config.ClusterConfig) config.KubernetesConfig {
+	k8s := cc.KubernetesConfig
+	if k8s.ContainerRuntime == "" {
+		k8s.ContainerRuntime = "docker"
+	}
+	return k8s
+}
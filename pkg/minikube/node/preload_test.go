@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPreloadImages(t *testing.T) {
+	imgs := []string{"k8s.gcr.io/pause:3.1", "busybox:latest", "my-org/app:1.0", "nginx:1.19"}
+	have := []string{"k8s.gcr.io/pause:3.1", "docker.io/library/busybox:latest"}
+	cached := map[string]bool{"my-org/app:1.0": true}
+
+	load, pull := splitPreloadImages(imgs, have, func(img string) bool { return cached[img] })
+	if !reflect.DeepEqual(load, []string{"my-org/app:1.0"}) {
+		t.Errorf("load = %v, want [my-org/app:1.0]", load)
+	}
+	if !reflect.DeepEqual(pull, []string{"nginx:1.19"}) {
+		t.Errorf("pull = %v, want [nginx:1.19]", pull)
+	}
+}
@@ -19,16 +19,22 @@ package node
 import (
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"golang.org/x/sync/errgroup"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
 	"k8s.io/minikube/pkg/addons"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/logs"
+	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/util"
 )
 
@@ -37,6 +43,7 @@ func Start(mc config.ClusterConfig, n config.Node, primary bool, existingAddons
 	k8sVersion := mc.KubernetesConfig.KubernetesVersion
 	driverName := mc.Driver
 
+	register.Reg.SetStep(register.DownloadingArtifacts)
 	// If using kic, make sure we download the kic base image
 	var kicGroup errgroup.Group
 	if driver.IsKIC(driverName) {
@@ -56,40 +63,69 @@ func Start(mc config.ClusterConfig, n config.Node, primary bool, existingAddons
 	handleDownloadOnly(&cacheGroup, &kicGroup, k8sVersion)
 	waitDownloadKicArtifacts(&kicGroup)
 
+	register.Reg.SetStep(register.StartingNode)
 	mRunner, preExists, machineAPI, host := startMachine(&mc, &n)
 	defer machineAPI.Close()
 
 	// wait for preloaded tarball to finish downloading before configuring runtimes
 	waitCacheRequiredImages(&cacheGroup)
 
+	// the auto-pause proxy is started again once the cluster is healthy
+	if primary {
+		disableAutoPause(mRunner, mc)
+	}
+
 	// configure the runtime (docker, containerd, crio)
-	cr := configureRuntimes(mRunner, driverName, mc.KubernetesConfig)
+	register.Reg.SetStep(register.PreparingKubernetes)
+	cr, err := configureRuntimes(mRunner, driverName, mc.KubernetesConfig)
+	if err != nil {
+		exit.WithError("Failed to configure container runtime", err)
+	}
 	showVersionInfo(k8sVersion, cr)
 
-	//TODO(sharifelgamal): Part out the cluster-wide operations, perhaps using the "primary" param
+	// Must come before bootstrapping, so that kubeadm and the certificates include the load balancer
+	if err := setupHA(&mc, n, mRunner); err != nil {
+		exit.WithError("Failed to set up the load balancer", err)
+	}
 
-	// Must be written before bootstrap, otherwise health checks may flake due to stale IP
-	kubeconfig, err := setupKubeconfig(host, &mc, &n, mc.Name)
-	if err != nil {
-		exit.WithError("Failed to setup kubeconfig", err)
+	var kcs *kubeconfig.Settings
+	if primary {
+		// Must be written before bootstrap, otherwise health checks may flake due to stale IP
+		kcs, err = setupKubeconfig(host, &mc, &n, mc.Name)
+		if err != nil {
+			exit.WithError("Failed to setup kubeconfig", err)
+		}
 	}
 
 	// setup kubeadm (must come after setupKubeconfig)
 	bs := setupKubeAdm(machineAPI, mc, n)
 
+	register.Reg.SetStep(register.LaunchingKubernetes)
+	if !primary {
+		out.T(out.Launch, "Joining {{.name}} to the cluster ...", out.V{"name": n.Name})
+		if err := joinCluster(mc, n, bs); err != nil {
+			exit.WithLogEntries("Error joining cluster", err, logs.FindProblems(cr, bs, mRunner))
+		}
+		if err := CacheAndLoadImagesInConfig(); err != nil {
+			out.T(out.FailureType, "Unable to load cached images from config file.")
+		}
+		return nil, nil
+	}
+
 	// pull images or restart cluster
 	out.T(out.Launch, "Launching Kubernetes ... ")
 	if err := bs.StartCluster(mc); err != nil {
 		exit.WithLogEntries("Error starting cluster", err, logs.FindProblems(cr, bs, mRunner))
 	}
-	configureMounts()
+	configureMounts(mc)
 
 	// enable addons, both old and new!
 	if existingAddons != nil {
+		register.Reg.SetStep(register.EnablingAddons)
 		addons.Start(viper.GetString(config.ProfileName), existingAddons, AddonList)
 	}
 
-	if err = CacheAndLoadImagesInConfig(); err != nil {
+	if err := CacheAndLoadImagesInConfig(); err != nil {
 		out.T(out.FailureType, "Unable to load cached images from config file.")
 	}
 
@@ -100,12 +136,47 @@ func Start(mc config.ClusterConfig, n config.Node, primary bool, existingAddons
 
 	// Skip pre-existing, because we already waited for health
 	if viper.GetBool(waitUntilHealthy) && !preExists {
+		register.Reg.SetStep(register.VerifyingKubernetes)
 		if err := bs.WaitForCluster(mc, viper.GetDuration(waitTimeout)); err != nil {
 			exit.WithError("Wait failed", err)
 		}
 	}
 
-	return kubeconfig, nil
+	if mc.AutoPauseInterval > 0 {
+		if err := enableAutoPause(mRunner, mc, n, kcs); err != nil {
+			out.WarningT("Unable to enable auto-pause: {{.error}}", out.V{"error": err})
+		}
+	}
+
+	return kcs, nil
+}
+
+// joinCluster joins a node to the cluster using a join command generated by the primary control plane
+func joinCluster(cc config.ClusterConfig, n config.Node, bs bootstrapper.Bootstrapper) error {
+	joinCmd, err := primaryJoinCommand(cc, n.ControlPlane)
+	if err != nil {
+		return errors.Wrap(err, "generating join token")
+	}
+	return bs.JoinCluster(cc, n, joinCmd)
+}
+
+// primaryJoinCommand returns the command joining a node to the cluster, generated by the primary control plane
+func primaryJoinCommand(cc config.ClusterConfig, controlPlane bool) (string, error) {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		return "", errors.Wrap(err, "machine client")
+	}
+	defer api.Close()
+
+	cp, err := config.PrimaryControlPlane(cc)
+	if err != nil {
+		return "", errors.Wrap(err, "getting primary control plane")
+	}
+	bs, err := cluster.Bootstrapper(api, viper.GetString(cmdcfg.Bootstrapper), cc, cp)
+	if err != nil {
+		return "", errors.Wrap(err, "getting primary control plane bootstrapper")
+	}
+	return bs.GenerateToken(cc, controlPlane)
 }
 
 // prepareNone prepares the user and host for the joy of the "none" driver
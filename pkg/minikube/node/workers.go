@@ -0,0 +1,229 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/proxy"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+var (
+	// For testing
+	joinCommand     = primaryJoinCommand
+	provisionWorker = startWorker
+	removeWorker    = deleteWorker
+)
+
+// NextNames returns the names of the next count nodes of a cluster, skipping names already in use
+func NextNames(cc config.ClusterConfig, count int) []string {
+	used := map[string]bool{}
+	for _, n := range cc.Nodes {
+		used[n.Name] = true
+	}
+
+	names := []string{}
+	for i := len(cc.Nodes) + 1; len(names) < count; i++ {
+		name := fmt.Sprintf("m%02d", i)
+		if !used[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// AddWorkers adds worker nodes with the given names to a running cluster, provisioning them in parallel using a single join token.
+// Nodes which fail to start are deleted and removed from the cluster config, and their errors are returned by node name.
+func AddWorkers(cc *config.ClusterConfig, names []string, profile string) (map[string]error, error) {
+	nodes := []config.Node{}
+	for _, name := range names {
+		n := config.Node{
+			Name:              name,
+			Worker:            true,
+			KubernetesVersion: cc.KubernetesConfig.KubernetesVersion,
+		}
+		// Assigned one at a time, so that each node sees the addresses given to the previous ones
+		if err := assignStaticIP(*cc, &n); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+		cc.Nodes = append(cc.Nodes, n)
+	}
+	if err := config.SaveProfile(profile, cc); err != nil {
+		return nil, errors.Wrap(err, "save config")
+	}
+
+	failed := map[string]error{}
+	ips := map[string]string{}
+
+	joinCmd, err := joinCommand(*cc, false)
+	if err != nil {
+		for _, n := range nodes {
+			failed[n.Name] = errors.Wrap(err, "generating join token")
+		}
+	} else {
+		start := time.Now()
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, n := range nodes {
+			wg.Add(1)
+			go func(n config.Node) {
+				defer wg.Done()
+				out.T(out.Provisioning, "Starting node {{.name}} ...", out.V{"name": n.Name})
+				started, err := provisionWorker(*cc, n, joinCmd)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					glog.Errorf("node %s failed to start: %v", n.Name, err)
+					failed[n.Name] = err
+					out.FailureT("Node {{.name}} failed to start", out.V{"name": n.Name})
+					return
+				}
+				ips[n.Name] = started.IP
+				out.T(out.Ready, "Node {{.name}} joined the cluster", out.V{"name": n.Name})
+			}(n)
+		}
+		wg.Wait()
+		glog.Infof("duration metric: took %s to provision %d workers", time.Since(start), len(nodes))
+	}
+
+	// Only keep the nodes which started, so that the config matches the cluster
+	kept := []config.Node{}
+	for _, n := range cc.Nodes {
+		if _, ok := failed[n.Name]; ok {
+			removeWorker(*cc, n)
+			continue
+		}
+		if ip, ok := ips[n.Name]; ok {
+			n.IP = ip
+		}
+		kept = append(kept, n)
+	}
+	cc.Nodes = kept
+	if err := config.SaveProfile(profile, cc); err != nil {
+		return failed, errors.Wrap(err, "save config")
+	}
+	return failed, nil
+}
+
+// startWorker starts the machine of a worker node and joins it to the cluster, returning the node along with its IP
+func startWorker(cc config.ClusterConfig, n config.Node, joinCmd string) (config.Node, error) {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		return n, errors.Wrap(err, "machine client")
+	}
+	defer api.Close()
+
+	h, err := machine.StartHost(api, cc, n)
+	if err != nil {
+		return n, errors.Wrap(err, "starting host")
+	}
+	ip, err := h.Driver.GetIP()
+	if err != nil {
+		return n, errors.Wrap(err, "getting IP")
+	}
+	ip = nodeIP(cc, n, ip)
+	n.IP = ip
+	if err := proxy.ExcludeIP(ip); err != nil {
+		glog.Warningf("unable to add %s to NO_PROXY: %v", ip, err)
+	}
+
+	runner, err := machine.CommandRunner(h)
+	if err != nil {
+		return n, errors.Wrap(err, "command runner")
+	}
+	if _, err := configureRuntimes(runner, cc.Driver, cc.KubernetesConfig); err != nil {
+		return n, errors.Wrap(err, "container runtime")
+	}
+
+	bs, err := cluster.Bootstrapper(api, viper.GetString(cmdcfg.Bootstrapper), cc, n)
+	if err != nil {
+		return n, errors.Wrap(err, "bootstrapper")
+	}
+	if err := bs.UpdateCluster(cc, n); err != nil {
+		return n, errors.Wrap(err, "update cluster")
+	}
+	if err := bs.SetupCerts(cc.KubernetesConfig, n); err != nil {
+		return n, errors.Wrap(err, "setup certs")
+	}
+	// only this node is loaded, as the other workers may still be starting
+	if err := loadCachedImages(cc, runner); err != nil {
+		glog.Warningf("unable to load cached images on %s: %v", n.Name, err)
+		out.FailureT("Unable to load cached images on {{.name}}", out.V{"name": n.Name})
+	}
+	if err := bs.JoinCluster(cc, n, joinCmd); err != nil {
+		return n, errors.Wrap(err, "join cluster")
+	}
+	return n, nil
+}
+
+// deleteWorker deletes a worker which failed to start: its Kubernetes node, in case it joined before failing,
+// and its machine, if it was created
+func deleteWorker(cc config.ClusterConfig, n config.Node) {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		glog.Warningf("unable to delete %s: %v", n.Name, err)
+		return
+	}
+	defer api.Close()
+
+	if err := deleteKubernetesNode(api, cc, n); err != nil {
+		glog.Warningf("unable to delete the kubernetes node %s: %v", n.Name, err)
+	}
+	if err := machine.DeleteHost(api, driver.MachineName(cc, n)); err != nil {
+		glog.Warningf("unable to delete %s: %v", n.Name, err)
+	}
+}
+
+// deleteKubernetesNode deletes the node object of a worker from the cluster, using the kubectl of the primary control plane
+func deleteKubernetesNode(api libmachine.API, cc config.ClusterConfig, n config.Node) error {
+	cp, err := config.PrimaryControlPlane(cc)
+	if err != nil {
+		return errors.Wrap(err, "getting primary control plane")
+	}
+	h, err := machine.CheckIfHostExistsAndLoad(api, driver.MachineName(cc, cp))
+	if err != nil {
+		return errors.Wrap(err, "loading primary control plane")
+	}
+	runner, err := machine.CommandRunner(h)
+	if err != nil {
+		return errors.Wrap(err, "command runner")
+	}
+	kubectl := path.Join(vmpath.GuestPersistentDir, "binaries", cc.KubernetesConfig.KubernetesVersion, "kubectl")
+	c := exec.Command("sudo", kubectl, fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")),
+		"delete", "node", n.Name, "--ignore-not-found")
+	if rr, err := runner.RunCmd(c); err != nil {
+		return errors.Wrapf(err, "kubectl delete node: %s", rr.Output())
+	}
+	return nil
+}
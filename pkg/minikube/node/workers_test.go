@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestNextNames(t *testing.T) {
+	cc := config.ClusterConfig{Nodes: []config.Node{{Name: "m01"}, {Name: "m03"}}}
+	got := NextNames(cc, 2)
+	if !reflect.DeepEqual(got, []string{"m04", "m05"}) {
+		t.Errorf("names = %v, want [m04 m05]", got)
+	}
+
+	cc = config.ClusterConfig{Nodes: []config.Node{{Name: "m01"}, {Name: "m02"}}}
+	if got := NextNames(cc, 1); !reflect.DeepEqual(got, []string{"m03"}) {
+		t.Errorf("names = %v, want [m03]", got)
+	}
+}
+
+func TestAddWorkers(t *testing.T) {
+	td, err := ioutil.TempDir("", "workers")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(td)
+	originalHome := os.Getenv(localpath.MinikubeHome)
+	defer os.Setenv(localpath.MinikubeHome, originalHome)
+	if err := os.Setenv(localpath.MinikubeHome, td); err != nil {
+		t.Fatalf("setenv: %v", err)
+	}
+	profile := filepath.Base(td)
+	if err := os.MkdirAll(config.ProfileFolderPath(profile), 0777); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	originalJoin, originalProvision, originalRemove := joinCommand, provisionWorker, removeWorker
+	defer func() {
+		joinCommand, provisionWorker, removeWorker = originalJoin, originalProvision, originalRemove
+	}()
+
+	tokens := 0
+	joinCommand = func(_ config.ClusterConfig, controlPlane bool) (string, error) {
+		if controlPlane {
+			t.Errorf("workers should not join as control planes")
+		}
+		tokens++
+		return "kubeadm join", nil
+	}
+	provisionWorker = func(_ config.ClusterConfig, n config.Node, joinCmd string) (config.Node, error) {
+		if n.Name == "m03" {
+			return n, fmt.Errorf("boom")
+		}
+		n.IP = "10.0.0." + n.Name[2:]
+		return n, nil
+	}
+	removed := []string{}
+	removeWorker = func(_ config.ClusterConfig, n config.Node) {
+		removed = append(removed, n.Name)
+	}
+
+	cc := &config.ClusterConfig{Name: profile, Nodes: []config.Node{{Name: "m01", IP: "10.0.0.1", ControlPlane: true, Worker: true}}}
+	failed, err := AddWorkers(cc, []string{"m02", "m03", "m04"}, profile)
+	if err != nil {
+		t.Fatalf("AddWorkers: %v", err)
+	}
+
+	if tokens != 1 {
+		t.Errorf("expected a single join token, got %d", tokens)
+	}
+	if len(failed) != 1 || failed["m03"] == nil {
+		t.Errorf("expected only m03 to fail, got %v", failed)
+	}
+	if !reflect.DeepEqual(removed, []string{"m03"}) {
+		t.Errorf("removed = %v, want [m03]", removed)
+	}
+
+	saved, err := config.DefaultLoader.LoadConfigFromFile(profile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	got := []string{}
+	for _, n := range saved.Nodes {
+		got = append(got, n.Name+"="+n.IP)
+	}
+	sort.Strings(got)
+	expected := []string{"m01=10.0.0.1", "m02=10.0.0.2", "m04=10.0.0.4"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("saved nodes = %v, want %v", got, expected)
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/golang/glog"
 	isatty "github.com/mattn/go-isatty"
+	"k8s.io/minikube/pkg/minikube/out/register"
 )
 
 // By design, this package uses global references to language and output objects, in preference
@@ -49,6 +50,8 @@ var (
 	useColor = false
 	// OverrideEnv is the environment variable used to override color/emoji usage
 	OverrideEnv = "MINIKUBE_IN_STYLE"
+	// JSON is whether output is emitted as newline-delimited JSON events instead of text. Set using SetJSON()
+	JSON = false
 )
 
 // fdWriter is the subset of file.File that implements io.Writer and Fd()
@@ -62,12 +65,20 @@ type V map[string]interface{}
 
 // T writes a stylized and templated message to stdout
 func T(style StyleEnum, format string, a ...V) {
+	if JSON {
+		printJSON(style, format, a...)
+		return
+	}
 	outStyled := applyTemplateFormatting(style, useColor, format, a...)
 	String(outStyled)
 }
 
 // String writes a basic formatted string to stdout
 func String(format string, a ...interface{}) {
+	if JSON {
+		printJSONString(format, a...)
+		return
+	}
 	if outFile == nil {
 		glog.Warningf("[unset outFile]: %s", fmt.Sprintf(format, a...))
 		return
@@ -85,12 +96,20 @@ func Ln(format string, a ...interface{}) {
 
 // ErrT writes a stylized and templated error message to stderr
 func ErrT(style StyleEnum, format string, a ...V) {
+	if JSON {
+		printJSON(style, format, a...)
+		return
+	}
 	errStyled := applyTemplateFormatting(style, useColor, format, a...)
 	Err(errStyled)
 }
 
 // Err writes a basic formatted string to stderr
 func Err(format string, a ...interface{}) {
+	if JSON {
+		printJSONString(format, a...)
+		return
+	}
 	if errFile == nil {
 		glog.Errorf("[unset errFile]: %s", fmt.Sprintf(format, a...))
 		return
@@ -126,6 +145,42 @@ func FailureT(format string, a ...V) {
 	ErrT(FailureType, format, a...)
 }
 
+// SetJSON configures whether output is emitted as newline-delimited JSON events to stdout, instead of text
+func SetJSON(j bool) {
+	glog.Infof("Setting JSON to %v", j)
+	JSON = j
+}
+
+// printJSON emits a templated message as a structured event, the kind of event depending on its style
+func printJSON(style StyleEnum, format string, a ...V) {
+	msg := Fmt(format, a...)
+	if msg == "" {
+		return
+	}
+
+	switch style {
+	case WarningType, Conflict:
+		register.PrintWarning(msg)
+	case FatalType, FailureType:
+		register.PrintError(msg)
+	default:
+		// Indented messages are details of the current step, rather than progress
+		if strings.HasPrefix(styles[style].Prefix, "  ") {
+			register.PrintInfo(msg)
+			return
+		}
+		register.PrintStep(msg)
+	}
+}
+
+// printJSONString emits a basic formatted string as an informational event
+func printJSONString(format string, a ...interface{}) {
+	msg := strings.TrimSpace(fmt.Sprintf(format, a...))
+	if msg != "" {
+		register.PrintInfo(msg)
+	}
+}
+
 // SetOutFile configures which writer standard output goes to.
 func SetOutFile(w fdWriter) {
 	glog.Infof("Setting OutFile to fd %d ...", w.Fd())
@@ -17,11 +17,15 @@ limitations under the License.
 package out
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
+	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/tests"
 	"k8s.io/minikube/pkg/minikube/translate"
 )
@@ -114,3 +118,55 @@ func TestErr(t *testing.T) {
 		t.Errorf("Err() = %q, want %q", got, want)
 	}
 }
+
+func TestJSON(t *testing.T) {
+	SetJSON(true)
+	buf := bytes.NewBuffer([]byte{})
+	register.SetOutputFile(buf)
+	defer func() {
+		SetJSON(false)
+		register.SetOutputFile(os.Stdout)
+	}()
+
+	f := tests.NewFakeFile()
+	SetOutFile(f)
+	SetErrFile(f)
+
+	T(Happy, "minikube {{.version}}", V{"version": "v1.9.2"})
+	T(Option, "MINIKUBE_HOME=/tmp")
+	WarningT("low on {{.resource}}", V{"resource": "memory"})
+	ErrT(FatalType, "failed")
+	T(Empty, "")
+	String("plain text\n")
+
+	if f.String() != "" {
+		t.Errorf("expected no text output, got %q", f.String())
+	}
+
+	want := []struct {
+		eventType string
+		message   string
+	}{
+		{"io.k8s.sigs.minikube.step", "minikube v1.9.2"},
+		{"io.k8s.sigs.minikube.info", "MINIKUBE_HOME=/tmp"},
+		{"io.k8s.sigs.minikube.warning", "low on memory"},
+		{"io.k8s.sigs.minikube.error", "failed"},
+		{"io.k8s.sigs.minikube.info", "plain text"},
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d events, want %d: %s", len(lines), len(want), buf.String())
+	}
+	for i, l := range lines {
+		ev := struct {
+			Type string            `json:"type"`
+			Data map[string]string `json:"data"`
+		}{}
+		if err := json.Unmarshal([]byte(l), &ev); err != nil {
+			t.Fatalf("unmarshal %q: %v", l, err)
+		}
+		if ev.Type != want[i].eventType || ev.Data["message"] != want[i].message {
+			t.Errorf("event %d = %s %q, want %s %q", i, ev.Type, ev.Data["message"], want[i].eventType, want[i].message)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package register
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/pborman/uuid"
+)
+
+const (
+	specVersion = "1.0"
+	source      = "https://minikube.sigs.k8s.io/"
+
+	// The types of events emitted
+	stepType    = "io.k8s.sigs.minikube.step"
+	infoType    = "io.k8s.sigs.minikube.info"
+	warningType = "io.k8s.sigs.minikube.warning"
+	errorType   = "io.k8s.sigs.minikube.error"
+)
+
+var (
+	// outputFile is where events are written to. Set using SetOutputFile()
+	outputFile io.Writer = os.Stdout
+	// outputLock prevents events written concurrently from interleaving
+	outputLock sync.Mutex
+
+	// For testing
+	newID = uuid.New
+)
+
+// event is a CloudEvents formatted record
+type event struct {
+	SpecVersion     string            `json:"specversion"`
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	Type            string            `json:"type"`
+	DataContentType string            `json:"datacontenttype"`
+	Data            map[string]string `json:"data"`
+}
+
+// SetOutputFile configures which writer events are written to
+func SetOutputFile(w io.Writer) {
+	outputLock.Lock()
+	defer outputLock.Unlock()
+	outputFile = w
+}
+
+// PrintStep prints a message about the progress of the current step
+func PrintStep(message string) {
+	name, current, total := Reg.currentStep()
+	printEvent(stepType, map[string]string{
+		"name":        string(name),
+		"currentstep": strconv.Itoa(current),
+		"totalsteps":  strconv.Itoa(total),
+		"message":     message,
+	})
+}
+
+// PrintInfo prints an informational message
+func PrintInfo(message string) {
+	printEvent(infoType, map[string]string{"message": message})
+}
+
+// PrintWarning prints a warning
+func PrintWarning(message string) {
+	printEvent(warningType, map[string]string{"message": message})
+}
+
+// PrintError prints an error which does not cause minikube to exit
+func PrintError(message string) {
+	printEvent(errorType, map[string]string{"message": message})
+}
+
+// PrintErrorExitCode prints an error which causes minikube to exit with the given code,
+// along with any details such as the problem ID and advice
+func PrintErrorExitCode(message string, exitcode int, details ...map[string]string) {
+	data := map[string]string{
+		"message":  message,
+		"exitcode": strconv.Itoa(exitcode),
+	}
+	for _, d := range details {
+		for k, v := range d {
+			data[k] = v
+		}
+	}
+	printEvent(errorType, data)
+}
+
+// printEvent writes an event as a single line of JSON
+func printEvent(eventType string, data map[string]string) {
+	b, err := json.Marshal(event{
+		SpecVersion:     specVersion,
+		ID:              newID(),
+		Source:          source,
+		Type:            eventType,
+		DataContentType: "application/json",
+		Data:            data,
+	})
+	if err != nil {
+		glog.Errorf("marshalling %s event: %v", eventType, err)
+		return
+	}
+
+	outputLock.Lock()
+	defer outputLock.Unlock()
+	if _, err := fmt.Fprintln(outputFile, string(b)); err != nil {
+		glog.Errorf("writing %s event: %v", eventType, err)
+	}
+}
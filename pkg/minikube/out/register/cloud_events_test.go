@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package register
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestPrintEvents(t *testing.T) {
+	originalID := newID
+	newID = func() string { return "random-id" }
+	originalReg := Reg
+	Reg = Register{steps: Reg.steps}
+	defer func() {
+		newID = originalID
+		Reg = originalReg
+		SetOutputFile(os.Stdout)
+	}()
+
+	Reg.SetStep(InitialSetup)
+
+	var tests = []struct {
+		description string
+		print       func()
+		want        string
+	}{
+		{
+			description: "step",
+			print:       func() { PrintStep("minikube v1.9.2 on Ubuntu") },
+			want:        `{"specversion":"1.0","id":"random-id","source":"https://minikube.sigs.k8s.io/","type":"io.k8s.sigs.minikube.step","datacontenttype":"application/json","data":{"currentstep":"0","message":"minikube v1.9.2 on Ubuntu","name":"Initial Minikube Setup","totalsteps":"9"}}`,
+		},
+		{
+			description: "info",
+			print:       func() { PrintInfo("MINIKUBE_HOME=/tmp") },
+			want:        `{"specversion":"1.0","id":"random-id","source":"https://minikube.sigs.k8s.io/","type":"io.k8s.sigs.minikube.info","datacontenttype":"application/json","data":{"message":"MINIKUBE_HOME=/tmp"}}`,
+		},
+		{
+			description: "warning",
+			print:       func() { PrintWarning("too little memory") },
+			want:        `{"specversion":"1.0","id":"random-id","source":"https://minikube.sigs.k8s.io/","type":"io.k8s.sigs.minikube.warning","datacontenttype":"application/json","data":{"message":"too little memory"}}`,
+		},
+		{
+			description: "error with exit code",
+			print: func() {
+				PrintErrorExitCode("failed to start", 70, map[string]string{"name": "KVM_UNAVAILABLE", "advice": "enable virtualization"})
+			},
+			want: `{"specversion":"1.0","id":"random-id","source":"https://minikube.sigs.k8s.io/","type":"io.k8s.sigs.minikube.error","datacontenttype":"application/json","data":{"advice":"enable virtualization","exitcode":"70","message":"failed to start","name":"KVM_UNAVAILABLE"}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			SetOutputFile(buf)
+			test.print()
+			if got := buf.String(); got != test.want+"\n" {
+				t.Errorf("printed %s, want %s", got, test.want)
+			}
+		})
+	}
+}
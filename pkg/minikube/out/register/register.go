@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package register tracks the steps of a minikube command, and emits its output as structured events.
+package register
+
+import (
+	"github.com/golang/glog"
+)
+
+// RegStep is a step of a minikube command
+type RegStep string
+
+// The steps of the commands which support structured output
+const (
+	InitialSetup         RegStep = "Initial Minikube Setup"
+	SelectingDriver      RegStep = "Selecting Driver"
+	DownloadingArtifacts RegStep = "Downloading Artifacts"
+	StartingNode         RegStep = "Starting Node"
+	PreparingKubernetes  RegStep = "Preparing Kubernetes"
+	LaunchingKubernetes  RegStep = "Launching Kubernetes"
+	EnablingAddons       RegStep = "Enabling Addons"
+	DisablingAddons      RegStep = "Disabling Addons"
+	VerifyingKubernetes  RegStep = "Verifying Kubernetes"
+	Stopping             RegStep = "Stopping"
+	Deleting             RegStep = "Deleting"
+	Done                 RegStep = "Done"
+)
+
+// Register tracks the current step of a command
+type Register struct {
+	// steps are the steps of each command, keyed by the command's first step
+	steps   map[RegStep][]RegStep
+	first   RegStep
+	current RegStep
+}
+
+// Reg is the register of the running command
+var Reg Register
+
+func init() {
+	Reg = Register{
+		steps: map[RegStep][]RegStep{
+			InitialSetup: {
+				InitialSetup,
+				SelectingDriver,
+				DownloadingArtifacts,
+				StartingNode,
+				PreparingKubernetes,
+				LaunchingKubernetes,
+				EnablingAddons,
+				VerifyingKubernetes,
+				Done,
+			},
+			Stopping:        {Stopping, Done},
+			Deleting:        {Deleting, Done},
+			EnablingAddons:  {EnablingAddons, Done},
+			DisablingAddons: {DisablingAddons, Done},
+		},
+	}
+}
+
+// SetStep sets the current step, the first step set determining which command is running
+func (r *Register) SetStep(s RegStep) {
+	if r.first == "" {
+		if _, ok := r.steps[s]; !ok {
+			glog.Infof("%q is not the first step of any command, ignoring", s)
+			return
+		}
+		r.first = s
+	}
+	if r.index(s) < 0 {
+		glog.Infof("%q is not a step of %q, ignoring", s, r.first)
+		return
+	}
+	r.current = s
+}
+
+// index returns the position of a step within the running command, or -1
+func (r *Register) index(s RegStep) int {
+	for i, step := range r.steps[r.first] {
+		if step == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// currentStep returns the name, position and total number of steps of the current step
+func (r *Register) currentStep() (RegStep, int, int) {
+	if r.current == "" {
+		return "", 0, 0
+	}
+	return r.current, r.index(r.current), len(r.steps[r.first])
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package register
+
+import (
+	"testing"
+)
+
+func TestSetStep(t *testing.T) {
+	var tests = []struct {
+		description string
+		steps       []RegStep
+		name        RegStep
+		current     int
+		total       int
+	}{
+		{
+			description: "start",
+			steps:       []RegStep{InitialSetup, SelectingDriver, StartingNode},
+			name:        StartingNode,
+			current:     3,
+			total:       9,
+		},
+		{
+			description: "addons enabled while starting",
+			steps:       []RegStep{InitialSetup, EnablingAddons},
+			name:        EnablingAddons,
+			current:     6,
+			total:       9,
+		},
+		{
+			description: "enabling an addon",
+			steps:       []RegStep{EnablingAddons, Done},
+			name:        Done,
+			current:     1,
+			total:       2,
+		},
+		{
+			description: "step of another command",
+			steps:       []RegStep{Stopping, Deleting},
+			name:        Stopping,
+			current:     0,
+			total:       2,
+		},
+		{
+			description: "not the first step of any command",
+			steps:       []RegStep{StartingNode},
+			name:        "",
+			current:     0,
+			total:       0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			r := Register{steps: Reg.steps}
+			for _, s := range test.steps {
+				r.SetStep(s)
+			}
+			name, current, total := r.currentStep()
+			if name != test.name || current != test.current || total != test.total {
+				t.Errorf("currentStep() = %q, %d, %d, want %q, %d, %d", name, current, total, test.name, test.current, test.total)
+			}
+		})
+	}
+}
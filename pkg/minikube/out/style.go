@@ -166,22 +166,40 @@ func applyTemplateFormatting(style StyleEnum, useColor bool, format string, a ..
 	}
 	format = applyStyle(style, useColor, format)
 
+	outStyled, ok := applyTemplate(format, a[0])
+	if !ok {
+		return outStyled
+	}
+
+	// escape any outstanding '%' signs so that they don't get interpreted
+	// as a formatting directive down the line
+	outStyled = strings.Replace(outStyled, "%", "%%", -1)
+
+	return outStyled
+}
+
+// applyTemplate executes a message template, returning the raw message and false if it is invalid
+func applyTemplate(format string, v V) (string, bool) {
 	var buf bytes.Buffer
 	t, err := template.New(format).Parse(format)
 	if err != nil {
 		glog.Errorf("unable to parse %q: %v - returning raw string.", format, err)
-		return format
+		return format, false
 	}
-	err = t.Execute(&buf, a[0])
+	err = t.Execute(&buf, v)
 	if err != nil {
 		glog.Errorf("unable to execute %s: %v - returning raw string.", format, err)
-		return format
+		return format, false
 	}
-	outStyled := buf.String()
-
-	// escape any outstanding '%' signs so that they don't get interpreted
-	// as a formatting directive down the line
-	outStyled = strings.Replace(outStyled, "%", "%%", -1)
+	return buf.String(), true
+}
 
-	return outStyled
+// Fmt returns a translated and templated message without any style, as used for structured output
+func Fmt(format string, a ...V) string {
+	v := V{}
+	if len(a) > 0 {
+		v = a[0]
+	}
+	msg, _ := applyTemplate(translate.T(format), v)
+	return strings.TrimSpace(msg)
 }
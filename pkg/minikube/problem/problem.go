@@ -20,6 +20,7 @@ package problem
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/translate"
@@ -55,6 +56,21 @@ type match struct {
 	ShowIssueLink bool
 }
 
+// Details returns the problem metadata, as used for structured output
+func (p *Problem) Details() map[string]string {
+	issues := []string{}
+	for _, i := range p.Issues {
+		issues = append(issues, fmt.Sprintf("%s/%d", issueBase, i))
+	}
+	return map[string]string{
+		"name":   p.ID,
+		"error":  fmt.Sprintf("%v", p.Err),
+		"advice": translate.T(p.Advice),
+		"url":    p.URL,
+		"issues": strings.Join(issues, ","),
+	}
+}
+
 // Display problem metadata to the console
 func (p *Problem) Display() {
 	out.ErrT(out.FailureType, "Error: [{{.id}}] {{.error}}", out.V{"id": p.ID, "error": p.Err})
@@ -19,6 +19,7 @@ package problem
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -100,6 +101,21 @@ func TestDisplay(t *testing.T) {
 	}
 }
 
+func TestDetails(t *testing.T) {
+	p := Problem{ID: "example", URL: "example.com", Err: fmt.Errorf("test"), Issues: []int{0, 1}, Advice: "you need a hug"}
+	got := p.Details()
+	expected := map[string]string{
+		"name":   "example",
+		"error":  "test",
+		"advice": "you need a hug",
+		"url":    "example.com",
+		"issues": "https://github.com/kubernetes/minikube/issues/0,https://github.com/kubernetes/minikube/issues/1",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Details() = %v, want %v", got, expected)
+	}
+}
+
 func TestFromError(t *testing.T) {
 	var tests = []struct {
 		issue int
@@ -23,9 +23,11 @@ import (
 	"time"
 
 	"github.com/docker/machine/libmachine/drivers"
+	"github.com/golang/glog"
 	"k8s.io/minikube/pkg/drivers/kic"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/registry"
@@ -44,6 +46,24 @@ func init() {
 }
 
 func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
+	var ports []string
+	// fixed host ports can only be published by a single node
+	if config.IsPrimaryControlPlane(cc, n) {
+		ports = cc.ExposedPorts
+	}
+	pm, err := kic.ExtraPortMappings(cc.AutoPauseInterval > 0, ports)
+	if err != nil {
+		return nil, err
+	}
+	var mounts []oci.Mount
+	if cc.Mount {
+		m, err := kic.ParseMount(cc.MountString)
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, m)
+	}
+
 	return kic.NewDriver(kic.Config{
 		MachineName:       driver.MachineName(cc, n),
 		StorePath:         localpath.MiniPath(),
@@ -52,8 +72,12 @@ func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
 		Memory:            cc.Memory,
 		OCIBinary:         oci.Docker,
 		APIServerPort:     cc.Nodes[0].Port,
+		PortMappings:      pm,
+		Mounts:            mounts,
 		KubernetesVersion: cc.KubernetesConfig.KubernetesVersion,
 		ContainerRuntime:  cc.KubernetesConfig.ContainerRuntime,
+		Network:           cc.Network,
+		IP:                n.IP,
 	}), nil
 }
 
@@ -72,5 +96,27 @@ func status() registry.State {
 		return registry.State{Error: err, Installed: true, Healthy: false, Fix: "Docker is not running or is responding too slow. Try: restarting docker desktop."}
 	}
 
+	return rootlessStatus()
+}
+
+// rootlessStatus reports whether a rootless docker is able to run kic nodes, which are delegated cgroups by systemd
+func rootlessStatus() registry.State {
+	si, err := oci.DaemonInfo(oci.Docker)
+	if err != nil {
+		// docker is running, which is all that can be told
+		glog.Warningf("unable to get docker info: %v", err)
+		return registry.State{Installed: true, Healthy: true}
+	}
+
+	// the version is only known once minikube start validates its flags, which accepts a newer one
+	err = oci.CheckRootless(si, constants.DefaultKubernetesVersion)
+	switch err {
+	case oci.ErrRootlessCgroupV1:
+		return registry.State{Error: err, Installed: true, Healthy: false, Fix: "Boot with cgroup v2 by adding systemd.unified_cgroup_hierarchy=1 to the kernel command line, or use a docker daemon running as root.", Doc: "https://rootlesscontaine.rs/getting-started/common/cgroup2/"}
+	case oci.ErrRootlessCgroupDriver:
+		return registry.State{Error: err, Installed: true, Healthy: false, Fix: "Run the rootless docker daemon as a systemd user service, with --exec-opt native.cgroupdriver=systemd.", Doc: "https://minikube.sigs.k8s.io/docs/reference/drivers/docker/#rootless-docker"}
+	case oci.ErrRootlessKubernetesVersion:
+		return registry.State{Error: err, Installed: true, Healthy: false, Fix: "Start with --kubernetes-version=v1.22.0 or newer, whose kubelet runs in a user namespace, or use a docker daemon running as root.", Doc: "https://minikube.sigs.k8s.io/docs/reference/drivers/docker/#rootless-docker"}
+	}
 	return registry.State{Installed: true, Healthy: true}
 }
@@ -29,6 +29,7 @@ import (
 	"k8s.io/minikube/pkg/drivers/kic"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/registry"
@@ -50,6 +51,24 @@ func init() {
 }
 
 func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
+	var ports []string
+	// fixed host ports can only be published by a single node
+	if config.IsPrimaryControlPlane(cc, n) {
+		ports = cc.ExposedPorts
+	}
+	pm, err := kic.ExtraPortMappings(cc.AutoPauseInterval > 0, ports)
+	if err != nil {
+		return nil, err
+	}
+	var mounts []oci.Mount
+	if cc.Mount {
+		m, err := kic.ParseMount(cc.MountString)
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, m)
+	}
+
 	return kic.NewDriver(kic.Config{
 		MachineName:   driver.MachineName(cc, n),
 		StorePath:     localpath.MiniPath(),
@@ -58,6 +77,10 @@ func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
 		Memory:        cc.Memory,
 		OCIBinary:     oci.Podman,
 		APIServerPort: cc.Nodes[0].Port,
+		PortMappings:  pm,
+		Mounts:        mounts,
+		Network:       cc.Network,
+		IP:            n.IP,
 	}), nil
 }
 
@@ -94,5 +117,26 @@ func status() registry.State {
 		return registry.State{Error: err, Installed: true, Healthy: false, Fix: "Podman is not running or taking too long to respond. Try: restarting podman."}
 	}
 
+	return rootlessStatus()
+}
+
+// rootlessStatus reports whether a rootless podman is able to run kic nodes, which are delegated cgroups by systemd
+func rootlessStatus() registry.State {
+	si, err := oci.DaemonInfo(oci.Podman)
+	if err != nil {
+		glog.Warningf("unable to get podman info: %v", err)
+		return registry.State{Installed: true, Healthy: true}
+	}
+
+	// the version is only known once minikube start validates its flags, which accepts a newer one
+	err = oci.CheckRootless(si, constants.DefaultKubernetesVersion)
+	switch err {
+	case oci.ErrRootlessCgroupV1:
+		return registry.State{Error: err, Installed: true, Healthy: false, Fix: "Boot with cgroup v2 by adding systemd.unified_cgroup_hierarchy=1 to the kernel command line, or run minikube as root with 'sudo minikube --driver=podman'.", Doc: "https://rootlesscontaine.rs/getting-started/common/cgroup2/"}
+	case oci.ErrRootlessCgroupDriver:
+		return registry.State{Error: err, Installed: true, Healthy: false, Fix: "Set cgroup_manager = \"systemd\" in ~/.config/containers/containers.conf.", Doc: "https://rootlesscontaine.rs/getting-started/common/cgroup2/"}
+	case oci.ErrRootlessKubernetesVersion:
+		return registry.State{Error: err, Installed: true, Healthy: false, Fix: "Start with --kubernetes-version=v1.22.0 or newer, whose kubelet runs in a user namespace, or use podman as root with 'sudo minikube --driver=podman'.", Doc: "https://minikube.sigs.k8s.io/docs/reference/drivers/docker/#rootless-docker"}
+	}
 	return registry.State{Installed: true, Healthy: true}
 }
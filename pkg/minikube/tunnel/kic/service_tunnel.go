@@ -32,6 +32,7 @@ type ServiceTunnel struct {
 	sshKey  string
 	v1Core  typed_core.CoreV1Interface
 	sshConn *sshConn
+	forward *forward
 }
 
 // NewServiceTunnel ...
@@ -50,20 +51,22 @@ func (t *ServiceTunnel) Start(svcName, namespace string) ([]string, error) {
 		return nil, errors.Wrapf(err, "Service %s was not found in %q namespace. You may select another namespace by using 'minikube service %s -n <namespace>", svcName, namespace, svcName)
 	}
 
-	t.sshConn, err = createSSHConnWithRandomPorts(svcName, t.sshPort, t.sshKey, svc)
+	t.sshConn, err = newSSHConn(t.sshPort, t.sshKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating ssh conn")
 	}
 
-	go func() {
-		err = t.sshConn.startAndWait()
-		if err != nil {
-			glog.Errorf("error starting ssh tunnel: %v", err)
+	t.forward, err = newForward(svcName, t.sshConn, svc, true)
+	if err != nil {
+		if err := t.forward.stop(); err != nil {
+			glog.Warningf("stopping partial tunnel: %v", err)
 		}
-	}()
+		return nil, errors.Wrap(err, "creating ssh tunnel")
+	}
+	t.forward.start()
 
 	urls := make([]string, 0, len(svc.Spec.Ports))
-	for _, port := range t.sshConn.ports {
+	for _, port := range t.forward.ports {
 		urls = append(urls, fmt.Sprintf("http://127.0.0.1:%d", port))
 	}
 
@@ -72,10 +75,10 @@ func (t *ServiceTunnel) Start(svcName, namespace string) ([]string, error) {
 
 // Stop ...
 func (t *ServiceTunnel) Stop() error {
-	err := t.sshConn.stop()
+	err := t.forward.stop()
 	if err != nil {
 		return errors.Wrap(err, "stopping ssh tunnel")
 	}
 
-	return nil
+	return t.sshConn.close()
 }
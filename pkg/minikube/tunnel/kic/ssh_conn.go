@@ -18,133 +18,213 @@ package kic
 
 import (
 	"fmt"
-	"os/exec"
-
-	"github.com/phayes/freeport"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
 	v1 "k8s.io/api/core/v1"
 
 	"k8s.io/minikube/pkg/minikube/out"
 )
 
+// sshConn is the single SSH connection to the node, which the forwards of all services are multiplexed over
 type sshConn struct {
-	name    string
-	service string
-	cmd     *exec.Cmd
-	ports   []int
+	addr   string
+	config *ssh.ClientConfig
+
+	mu     sync.Mutex
+	client *ssh.Client
 }
 
-func createSSHConn(name, sshPort, sshKey string, svc *v1.Service) *sshConn {
-	// extract sshArgs
-	sshArgs := []string{
-		// TODO: document the options here
-		"-o", "UserKnownHostsFile=/dev/null",
-		"-o", "StrictHostKeyChecking no",
-		"-N",
-		"docker@127.0.0.1",
-		"-p", sshPort,
-		"-i", sshKey,
+// newSSHConn returns a connection to the node listening for SSH on 127.0.0.1:sshPort, authenticated with sshKey.
+// The connection is only established when first used.
+func newSSHConn(sshPort, sshKey string) (*sshConn, error) {
+	key, err := ioutil.ReadFile(sshKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading ssh key")
 	}
-
-	askForSudo := false
-	var privilegedPorts []int32
-	for _, port := range svc.Spec.Ports {
-		arg := fmt.Sprintf(
-			"-L %d:%s:%d",
-			port.Port,
-			svc.Spec.ClusterIP,
-			port.Port,
-		)
-
-		// check if any port is privileged
-		if port.Port < 1024 {
-			privilegedPorts = append(privilegedPorts, port.Port)
-			askForSudo = true
-		}
-
-		sshArgs = append(sshArgs, arg)
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing ssh key")
 	}
+	return &sshConn{
+		addr: net.JoinHostPort("127.0.0.1", sshPort),
+		config: &ssh.ClientConfig{
+			User: "docker",
+			Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			// the node is only reachable from this host, and its host key changes whenever it is recreated
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			Timeout:         10 * time.Second,
+		},
+	}, nil
+}
 
-	command := "ssh"
+// connect returns the SSH client, establishing the connection if there is none
+func (c *sshConn) connect() (*ssh.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil {
+		return c.client, nil
+	}
+	client, err := ssh.Dial("tcp", c.addr, c.config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "ssh to %s", c.addr)
+	}
+	glog.Infof("connected to %s for tunnels", c.addr)
+	c.client = client
+	return client, nil
+}
 
-	if askForSudo {
-		out.T(
-			out.WarningType,
-			"The service {{.service}} requires privileged ports to be exposed: {{.ports}}",
-			out.V{"service": svc.Name, "ports": fmt.Sprintf("%v", privilegedPorts)},
-		)
+// reset drops the SSH client if it is still the given one, so that the next dial reconnects
+func (c *sshConn) reset(client *ssh.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == client {
+		c.client.Close()
+		c.client = nil
+	}
+}
 
-		out.T(out.Permissions, "sudo permission will be asked for it.")
+// dial opens a connection to addr as seen from the node, reconnecting once if the SSH connection was lost,
+// such as when the node was restarted
+func (c *sshConn) dial(addr string) (net.Conn, error) {
+	client, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := client.Dial("tcp", addr)
+	if err == nil {
+		return conn, nil
+	}
+	glog.Infof("dial %s over ssh failed, reconnecting: %v", addr, err)
+	c.reset(client)
+	client, err = c.connect()
+	if err != nil {
+		return nil, err
+	}
+	return client.Dial("tcp", addr)
+}
 
-		command = "sudo"
-		sshArgs = append([]string{"ssh"}, sshArgs...)
+// close closes the SSH connection, if any
+func (c *sshConn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == nil {
+		return nil
 	}
+	err := c.client.Close()
+	c.client = nil
+	return err
+}
 
-	cmd := exec.Command(command, sshArgs...)
+// forward listens on local ports for the ports of a service, and forwards connections to its cluster IP over the SSH connection
+type forward struct {
+	name      string
+	service   string
+	conn      *sshConn
+	listeners []net.Listener
+	targets   []string
+	ports     []int
+}
 
-	return &sshConn{
+// newForward listens on 127.0.0.1 for each port of the service, on the same port or on a free one if randomPorts is set.
+// Ports which cannot be listened on are skipped and reported by the error, alongside the forward of the others.
+func newForward(name string, conn *sshConn, svc *v1.Service, randomPorts bool) (*forward, error) {
+	f := &forward{
 		name:    name,
 		service: svc.Name,
-		cmd:     cmd,
+		conn:    conn,
 	}
-}
-
-func createSSHConnWithRandomPorts(name, sshPort, sshKey string, svc *v1.Service) (*sshConn, error) {
-	// extract sshArgs
-	sshArgs := []string{
-		// TODO: document the options here
-		"-o", "UserKnownHostsFile=/dev/null",
-		"-o", "StrictHostKeyChecking no",
-		"-N",
-		"docker@127.0.0.1",
-		"-p", sshPort,
-		"-i", sshKey,
-	}
-
-	usedPorts := make([]int, 0, len(svc.Spec.Ports))
 
+	var failed []string
 	for _, port := range svc.Spec.Ports {
-		freeport, err := freeport.GetFreePort()
+		local := int(port.Port)
+		if randomPorts {
+			local = 0
+		}
+		l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(local)))
 		if err != nil {
-			return nil, err
+			if local > 0 && local < 1024 {
+				out.WarningT("The service {{.service}} requires privileged port {{.port}}, which may require running minikube as root", out.V{"service": svc.Name, "port": local})
+			}
+			failed = append(failed, fmt.Sprintf("%d: %v", port.Port, err))
+			continue
 		}
-
-		arg := fmt.Sprintf(
-			"-L %d:%s:%d",
-			freeport,
-			svc.Spec.ClusterIP,
-			port.Port,
-		)
-
-		sshArgs = append(sshArgs, arg)
-		usedPorts = append(usedPorts, freeport)
+		f.listeners = append(f.listeners, l)
+		f.targets = append(f.targets, net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(int(port.Port))))
+		f.ports = append(f.ports, l.Addr().(*net.TCPAddr).Port)
 	}
 
-	cmd := exec.Command("ssh", sshArgs...)
+	if len(failed) > 0 {
+		return f, fmt.Errorf("unable to listen for service %s on ports %v", svc.Name, failed)
+	}
+	return f, nil
+}
 
-	return &sshConn{
-		name:    name,
-		service: svc.Name,
-		cmd:     cmd,
-		ports:   usedPorts,
-	}, nil
+// start accepts connections on the local ports until the forward is stopped
+func (f *forward) start() {
+	out.T(out.Running, "Starting tunnel for service {{.service}}.", out.V{"service": f.service})
+	for i := range f.listeners {
+		go f.serve(f.listeners[i], f.targets[i])
+	}
 }
 
-func (c *sshConn) startAndWait() error {
-	out.T(out.Running, "Starting tunnel for service {{.service}}.", out.V{"service": c.service})
+// serve forwards each connection accepted by l to target
+func (f *forward) serve(l net.Listener, target string) {
+	for {
+		local, err := l.Accept()
+		if err != nil {
+			// the listener was closed by stop
+			return
+		}
+		go f.handle(local, target)
+	}
+}
 
-	err := c.cmd.Start()
+// handle copies data both ways between a local connection and target, until either side closes
+func (f *forward) handle(local net.Conn, target string) {
+	defer local.Close()
+	remote, err := f.conn.dial(target)
 	if err != nil {
-		return err
+		glog.Warningf("tunnel for service %s: unable to reach %s: %v", f.service, target, err)
+		return
 	}
+	defer remote.Close()
 
-	// we ignore wait error because the process will be killed
-	_ = c.cmd.Wait()
-
-	return nil
+	done := make(chan struct{}, 2)
+	go func() {
+		if _, err := io.Copy(remote, local); err != nil {
+			glog.Infof("tunnel for service %s: copy to %s: %v", f.service, target, err)
+		}
+		done <- struct{}{}
+	}()
+	go func() {
+		if _, err := io.Copy(local, remote); err != nil {
+			glog.Infof("tunnel for service %s: copy from %s: %v", f.service, target, err)
+		}
+		done <- struct{}{}
+	}()
+	<-done
 }
 
-func (c *sshConn) stop() error {
-	out.T(out.Stopping, "Stopping tunnel for service {{.service}}.", out.V{"service": c.service})
+// stop closes the local listeners, so that no more connections are forwarded
+func (f *forward) stop() error {
+	out.T(out.Stopping, "Stopping tunnel for service {{.service}}.", out.V{"service": f.service})
 
-	return c.cmd.Process.Kill()
+	var errs []error
+	for _, l := range f.listeners {
+		if err := l.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("closing listeners: %v", errs)
+	}
+	return nil
 }
@@ -0,0 +1,319 @@
+/*
+Copyright 2020 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kic
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	v1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeSSHServer stands in for the sshd of a node, accepting the given key and forwarding direct-tcpip channels
+type fakeSSHServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+
+	mu    sync.Mutex
+	conns int
+}
+
+func newFakeSSHServer(t *testing.T, authorized ssh.PublicKey) *fakeSSHServer {
+	hostKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatalf("host key signer: %v", err)
+	}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorized.Marshal()) {
+				return nil, io.EOF
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeSSHServer{listener: l, config: config}
+	go s.serve()
+	return s
+}
+
+func (s *fakeSSHServer) port() string {
+	return strconv.Itoa(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+func (s *fakeSSHServer) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+func (s *fakeSSHServer) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(c)
+	}
+}
+
+func (s *fakeSSHServer) handle(c net.Conn) {
+	_, chans, reqs, err := ssh.NewServerConn(c, s.config)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.conns++
+	s.mu.Unlock()
+
+	go ssh.DiscardRequests(reqs)
+	for nc := range chans {
+		if nc.ChannelType() != "direct-tcpip" {
+			nc.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		var target struct {
+			Host     string
+			Port     uint32
+			OrigHost string
+			OrigPort uint32
+		}
+		if err := ssh.Unmarshal(nc.ExtraData(), &target); err != nil {
+			nc.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		remote, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			nc.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		ch, creqs, err := nc.Accept()
+		if err != nil {
+			remote.Close()
+			continue
+		}
+		go ssh.DiscardRequests(creqs)
+		go func() {
+			defer ch.Close()
+			defer remote.Close()
+			go io.Copy(remote, ch) // nolint
+			io.Copy(ch, remote)    // nolint
+		}()
+	}
+}
+
+// echoServer stands in for a service in the cluster, echoing back whatever it receives
+func echoServer(t *testing.T) (net.Listener, int32) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				io.Copy(c, c) // nolint
+			}()
+		}
+	}()
+	return l, int32(l.Addr().(*net.TCPAddr).Port)
+}
+
+// writeKey writes a new private key to dir, returning its path and public key
+func writeKey(t *testing.T, dir string) (string, ssh.PublicKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	path := filepath.Join(dir, "id_rsa")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := ioutil.WriteFile(path, pemBytes, 0600); err != nil {
+		t.Fatalf("writing key: %v", err)
+	}
+	pub, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("public key: %v", err)
+	}
+	return path, pub
+}
+
+func roundTrip(t *testing.T, port int, msg string) {
+	c, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		t.Fatalf("dial forwarded port %d: %v", port, err)
+	}
+	defer c.Close()
+	if _, err := c.Write([]byte(msg)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(c, buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(buf) != msg {
+		t.Errorf("got %q back, want %q", buf, msg)
+	}
+}
+
+func TestForward(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ssh_conn")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyPath, pub := writeKey(t, dir)
+	server := newFakeSSHServer(t, pub)
+	defer server.listener.Close()
+
+	echo, echoPort := echoServer(t)
+	defer echo.Close()
+
+	conn, err := newSSHConn(server.port(), keyPath)
+	if err != nil {
+		t.Fatalf("newSSHConn: %v", err)
+	}
+	defer conn.close()
+
+	svc := func(name string) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1.ServiceSpec{
+				ClusterIP: "127.0.0.1",
+				Ports:     []v1.ServicePort{{Port: echoPort}},
+			},
+		}
+	}
+
+	var forwards []*forward
+	for _, name := range []string{"first", "second"} {
+		f, err := newForward(name, conn, svc(name), true)
+		if err != nil {
+			t.Fatalf("newForward(%s): %v", name, err)
+		}
+		if len(f.ports) != 1 || f.ports[0] == 0 {
+			t.Fatalf("newForward(%s) ports = %v, want one assigned port", name, f.ports)
+		}
+		f.start()
+		forwards = append(forwards, f)
+	}
+
+	for _, f := range forwards {
+		roundTrip(t, f.ports[0], "hello "+f.name)
+	}
+	if got := server.connections(); got != 1 {
+		t.Errorf("forwards opened %d ssh connections, want 1", got)
+	}
+
+	if err := forwards[0].stop(); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if c, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(forwards[0].ports[0]))); err == nil {
+		c.Close()
+		t.Errorf("stopped forward still accepts connections")
+	}
+	roundTrip(t, forwards[1].ports[0], "still forwarding")
+	if err := forwards[1].stop(); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+}
+
+func TestForwardReconnects(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ssh_conn")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyPath, pub := writeKey(t, dir)
+	server := newFakeSSHServer(t, pub)
+	defer server.listener.Close()
+
+	echo, echoPort := echoServer(t)
+	defer echo.Close()
+
+	conn, err := newSSHConn(server.port(), keyPath)
+	if err != nil {
+		t.Fatalf("newSSHConn: %v", err)
+	}
+	defer conn.close()
+
+	f, err := newForward("svc", conn, &v1.Service{
+		ObjectMeta: meta.ObjectMeta{Name: "svc"},
+		Spec:       v1.ServiceSpec{ClusterIP: "127.0.0.1", Ports: []v1.ServicePort{{Port: echoPort}}},
+	}, true)
+	if err != nil {
+		t.Fatalf("newForward: %v", err)
+	}
+	f.start()
+	defer f.stop() // nolint
+
+	roundTrip(t, f.ports[0], "before")
+	// simulate the node dropping the connection, as when it restarts
+	conn.mu.Lock()
+	conn.client.Conn.Close()
+	conn.mu.Unlock()
+	roundTrip(t, f.ports[0], "after")
+
+	if got := server.connections(); got != 2 {
+		t.Errorf("got %d ssh connections, want 2 after reconnecting", got)
+	}
+}
+
+func TestNewSSHConnBadKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ssh_conn")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := newSSHConn("22", filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("newSSHConn with a missing key succeeded")
+	}
+	bad := filepath.Join(dir, "bad")
+	if err := ioutil.WriteFile(bad, []byte("not a key"), 0600); err != nil {
+		t.Fatalf("writing key: %v", err)
+	}
+	if _, err := newSSHConn("22", bad); err == nil {
+		t.Errorf("newSSHConn with an invalid key succeeded")
+	}
+}
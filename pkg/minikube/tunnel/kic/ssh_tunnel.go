@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	typed_core "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -37,8 +38,9 @@ type SSHTunnel struct {
 	sshKey               string
 	v1Core               typed_core.CoreV1Interface
 	LoadBalancerEmulator tunnel.LoadBalancerEmulator
-	conns                map[string]*sshConn
-	connsToStop          map[string]*sshConn
+	conn                 *sshConn
+	conns                map[string]*forward
+	connsToStop          map[string]*forward
 }
 
 // NewSSHTunnel ...
@@ -49,16 +51,25 @@ func NewSSHTunnel(ctx context.Context, sshPort, sshKey string, v1Core typed_core
 		sshKey:               sshKey,
 		v1Core:               v1Core,
 		LoadBalancerEmulator: tunnel.NewLoadBalancerEmulator(v1Core),
-		conns:                make(map[string]*sshConn),
-		connsToStop:          make(map[string]*sshConn),
+		conns:                make(map[string]*forward),
+		connsToStop:          make(map[string]*forward),
 	}
 }
 
 // Start ...
 func (t *SSHTunnel) Start() error {
+	conn, err := newSSHConn(t.sshPort, t.sshKey)
+	if err != nil {
+		return errors.Wrap(err, "ssh connection")
+	}
+	t.conn = conn
+	defer t.conn.close()
+
 	for {
 		select {
 		case <-t.ctx.Done():
+			t.markConnectionsToBeStopped()
+			t.stopMarkedConnections()
 			_, err := t.LoadBalancerEmulator.Cleanup()
 			if err != nil {
 				glog.Errorf("error cleaning up: %v", err)
@@ -103,37 +114,34 @@ func (t *SSHTunnel) startConnection(svc v1.Service) {
 		return
 	}
 
-	// create new ssh conn
-	newSSHConn := createSSHConn(uniqName, t.sshPort, t.sshKey, &svc)
-	t.conns[newSSHConn.name] = newSSHConn
-
-	go func() {
-		err := newSSHConn.startAndWait()
-		if err != nil {
-			glog.Errorf("error starting ssh tunnel: %v", err)
-		}
-	}()
+	// forward the ports of the service over the ssh connection shared by all services
+	f, err := newForward(uniqName, t.conn, &svc, false)
+	if err != nil {
+		glog.Errorf("error starting ssh tunnel: %v", err)
+	}
+	t.conns[f.name] = f
+	f.start()
 
-	err := t.LoadBalancerEmulator.PatchServiceIP(t.v1Core.RESTClient(), svc, "127.0.0.1")
+	err = t.LoadBalancerEmulator.PatchServiceIP(t.v1Core.RESTClient(), svc, "127.0.0.1")
 	if err != nil {
 		glog.Errorf("error patching service: %v", err)
 	}
 }
 
 func (t *SSHTunnel) stopMarkedConnections() {
-	for _, sshConn := range t.connsToStop {
-		err := sshConn.stop()
+	for _, f := range t.connsToStop {
+		err := f.stop()
 		if err != nil {
 			glog.Errorf("error stopping ssh tunnel: %v", err)
 		}
-		delete(t.conns, sshConn.name)
-		delete(t.connsToStop, sshConn.name)
+		delete(t.conns, f.name)
+		delete(t.connsToStop, f.name)
 	}
 }
 
 // sshConnName creates a uniq name for the tunnel, using its name/clusterIP/ports.
-// This allows a new process to be created if an existing service was changed,
-// the new process will support the IP/Ports change occurred.
+// This allows a new forward to be created if an existing service was changed,
+// the new forward will support the IP/Ports change occurred.
 func sshConnUniqName(service v1.Service) string {
 	n := []string{
 		service.Name,
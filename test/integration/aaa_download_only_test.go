@@ -28,7 +28,6 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
-	"strings"
 	"testing"
 	"time"
 
@@ -39,6 +38,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/image"
 	"k8s.io/minikube/pkg/minikube/localpath"
 )
 
@@ -81,12 +81,10 @@ func TestDownloadOnly(t *testing.T) {
 
 				// skip verify for cache images if --driver=none
 				if !NoneDriver() {
+					cacheDir := filepath.Join(localpath.MiniPath(), "cache", "images")
 					for _, img := range imgs {
-						img = strings.Replace(img, ":", "_", 1) // for example kube-scheduler:v1.15.2 --> kube-scheduler_v1.15.2
-						fp := filepath.Join(localpath.MiniPath(), "cache", "images", img)
-						_, err := os.Stat(fp)
-						if err != nil {
-							t.Errorf("expected image file exist at %q but got error: %v", fp, err)
+						if _, err := image.FindInCache(cacheDir, img); err != nil {
+							t.Errorf("expected image %q in the image cache at %q but got error: %v", img, cacheDir, err)
 						}
 					}
 				}
@@ -183,14 +181,14 @@ func TestDownloadOnlyDocker(t *testing.T) {
 	}
 
 	// Make sure the downloaded image tarball exists
-	tarball := download.TarballPath(constants.DefaultKubernetesVersion)
+	tarball := download.TarballPath(constants.DefaultKubernetesVersion, "docker")
 	contents, err := ioutil.ReadFile(tarball)
 	if err != nil {
 		t.Errorf("reading tarball: %v", err)
 	}
 	// Make sure it has the correct checksum
 	checksum := md5.Sum(contents)
-	remoteChecksum, err := ioutil.ReadFile(download.PreloadChecksumPath(constants.DefaultKubernetesVersion))
+	remoteChecksum, err := ioutil.ReadFile(download.PreloadChecksumPath(constants.DefaultKubernetesVersion, "docker"))
 	if err != nil {
 		t.Errorf("reading checksum file: %v", err)
 	}
@@ -50,6 +50,9 @@ func validateMountCmd(ctx context.Context, t *testing.T, profile string) {
 	if HyperVDriver() {
 		t.Skip("skipping: mount broken on hyperv: https://github.com/kubernetes/minikube/issues/5029")
 	}
+	if KicDriver() {
+		t.Skip("skipping: kic drivers bind mount with start --mount rather than minikube mount")
+	}
 
 	tempDir, err := ioutil.TempDir("", "mounttest")
 	if err != nil {
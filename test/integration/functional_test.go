@@ -287,11 +287,15 @@ func validateStatusCmd(ctx context.Context, t *testing.T, profile string) {
 	if err != nil {
 		t.Errorf("%s failed: %v", rr.Args, err)
 	}
-	var jsonObject map[string]interface{}
-	err = json.Unmarshal(rr.Stdout.Bytes(), &jsonObject)
+	var jsonObjects []map[string]interface{}
+	err = json.Unmarshal(rr.Stdout.Bytes(), &jsonObjects)
 	if err != nil {
 		t.Errorf("%s failed: %v", rr.Args, err)
 	}
+	if len(jsonObjects) != 1 {
+		t.Fatalf("%s failed: expected the status of 1 node, got %d", rr.Args, len(jsonObjects))
+	}
+	jsonObject := jsonObjects[0]
 	if _, ok := jsonObject["Host"]; !ok {
 		t.Errorf("%s failed: %v. Missing key %s in json object", rr.Args, err, "Host")
 	}
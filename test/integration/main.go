@@ -69,6 +69,11 @@ func HyperVDriver() bool {
 	return strings.Contains(*startArgs, "--driver=hyperv")
 }
 
+// KicDriver returns whether or not this test is using the docker or podman driver
+func KicDriver() bool {
+	return strings.Contains(*startArgs, "--driver=docker") || strings.Contains(*startArgs, "--driver=podman")
+}
+
 // ExpectedDefaultDriver returns the expected default driver, if any
 func ExpectedDefaultDriver() string {
 	return *defaultDriver
@@ -252,8 +252,8 @@ func testPause(ctx context.Context, t *testing.T, profile string) {
 	}
 
 	got = Status(ctx, t, Target(), profile, "Kubelet")
-	if got != state.Stopped.String() {
-		t.Errorf("post-pause kubelet status = %q; want = %q", got, state.Stopped)
+	if got != state.Paused.String() {
+		t.Errorf("post-pause kubelet status = %q; want = %q", got, state.Paused)
 	}
 
 	rr, err = Run(t, exec.CommandContext(ctx, Target(), "unpause", "-p", profile, "--alsologtostderr", "-v=1"))